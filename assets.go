@@ -2,7 +2,7 @@ package assets
 
 import (
 	"bytes"
-	_ "embed" // for the go:embed directive
+	"embed"
 )
 
 // GQLPlaygroundHTML stores the content of the playground/index.html file.
@@ -11,6 +11,10 @@ var GQLPlaygroundHTML []byte
 
 // InitGQLPlaygroundHTML prepares the playground and puts the right port in there.
 func InitGQLPlaygroundHTML(port []byte, endpoint []byte) {
-	GQLPlaygroundHTML = bytes.Replace(GQLPlaygroundHTML, []byte("{{.port}}"), port, 1)
-	GQLPlaygroundHTML = bytes.Replace(GQLPlaygroundHTML, []byte("{{.endpoint}}"), endpoint, 1)
+	GQLPlaygroundHTML = bytes.ReplaceAll(GQLPlaygroundHTML, []byte("{{.port}}"), port)
+	GQLPlaygroundHTML = bytes.ReplaceAll(GQLPlaygroundHTML, []byte("{{.endpoint}}"), endpoint)
 }
+
+// MailTemplates stores the templates of the emails sent to the users.
+//go:embed templates/mail/*.tmpl
+var MailTemplates embed.FS
@@ -1,15 +1,26 @@
 package main
 
 import (
+	assets "simplestforum"
 	"simplestforum/internal/delivery/api"
 	"simplestforum/internal/delivery/api/middleware"
 	"simplestforum/internal/delivery/gql/resolvers"
+	"simplestforum/internal/delivery/sse"
+	"simplestforum/internal/delivery/worker"
+	"simplestforum/internal/domain/entity"
 	"simplestforum/internal/domain/service"
 	"simplestforum/internal/domain/usecase"
+	"simplestforum/internal/infrastructure/broadcast"
+	"simplestforum/internal/infrastructure/filter"
+	"simplestforum/internal/infrastructure/mail"
+	"simplestforum/internal/infrastructure/ratelimit"
 	"simplestforum/internal/infrastructure/repository"
+	"simplestforum/internal/infrastructure/search"
+	"simplestforum/internal/infrastructure/webhook"
 
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -27,6 +38,8 @@ const shutdownTimeout = 5 * time.Second
 
 const pathToMigrations = "migrations"
 
+const pathToMailTemplates = "templates/mail"
+
 func main() {
 	// Showing timestamps in the log
 	log.SetFlags(log.Lmsgprefix | log.LstdFlags)
@@ -74,20 +87,120 @@ func main() {
 		log.Println("No new migrations found")
 	}
 
+	// Initializing the mail delivery
+	mailSender, err := newMailSender(&c.Mail)
+	if err != nil {
+		log.Println("Error initializing the mail sender:", err)
+
+		return
+	}
+
+	mailRenderer, err := mail.NewRenderer(assets.MailTemplates, pathToMailTemplates)
+	if err != nil {
+		log.Println("Error parsing the mail templates:", err)
+
+		return
+	}
+
+	// Initializing the content filters
+	contentFilters, err := newContentFilters(&c.Filter, repository.NewFilterRepository(&repository.DBConn{Connection: dbPool}))
+	if err != nil {
+		log.Println("Error initializing the content filters:", err)
+
+		return
+	}
+
+	// Initializing the rate limits
+	rateLimitStorage, err := newRateLimitStorage(&c.RateLimit, &repository.DBConn{Connection: dbPool})
+	if err != nil {
+		log.Println("Error initializing the rate limits:", err)
+
+		return
+	}
+
+	// Initializing the broadcast
+	dsn := bootstrap.DSNPg(c.DB.Username, c.DB.Password, c.DB.Name, c.DB.Host, c.DB.Port)
+
+	broadcastStorage, err := newBroadcastStorage(&c.Broadcast, &repository.DBConn{Connection: dbPool}, dsn)
+	if err != nil {
+		log.Println("Error initializing the broadcast:", err)
+
+		return
+	}
+
+	// Initializing the search
+	searchEngine, err := newSearchEngine(&c.Search, repository.NewSearchRepository(&repository.DBConn{Connection: dbPool}))
+	if err != nil {
+		log.Println("Error initializing the search:", err)
+
+		return
+	}
+
 	// Initializing the layers
 	storage := repository.NewRepository(dbPool)
+	storage.MailSender = mailSender
+	storage.MailRenderer = mailRenderer
+	storage.WebhookSender = webhook.NewHTTPSender(&http.Client{Timeout: c.Webhook.Timeout})
+	storage.Premoderation = entity.PremoderationRules{
+		MinRank:       c.Premoderation.MinRank,
+		MinAccountAge: c.Premoderation.MinAccountAge,
+		HoldLinks:     c.Premoderation.HoldLinks,
+	}
+	storage.PostEditRules = entity.PostEditRules{
+		EditWindow:  c.Post.EditWindow,
+		WikiMinRank: c.Post.WikiMinRank,
+	}
+	storage.TopicDuplicateGuard = entity.TopicDuplicateGuard{
+		MinSimilarity: c.Topic.DuplicateSimilarity,
+		Window:        c.Topic.DuplicateWindow,
+	}
+	storage.ContentFilters = contentFilters
+	storage.RateLimit = rateLimitStorage
+	storage.RateLimitRules = newRateLimitRules(&c.RateLimit)
+	storage.Search = searchEngine
+	storage.Broadcast = broadcastStorage
+	storage.OutboxRules = entity.OutboxRules{
+		BatchSize:   c.Outbox.BatchSize,
+		Lease:       c.Outbox.Lease,
+		MaxAttempts: c.Outbox.MaxAttempts,
+		BaseBackoff: c.Outbox.BaseBackoff,
+		MaxBackoff:  c.Outbox.MaxBackoff,
+	}
+	storage.EventErrors = logEventError
 	adapters := service.NewServices(storage)
+	subscribe(adapters)
 	interactors := usecase.NewAdapters(adapters)
 	middlewares := middleware.NewMiddlewares(adapters.User)
-	gqlHandler := resolvers.NewGQLHandler(interactors)
+	gqlHandler := resolvers.NewGQLHandler(interactors, middlewares.Auth.WebsocketInit)
+
+	notificationsHandler := sse.NewNotificationHandler(interactors.Live, c.Stream.HeartbeatInterval)
 
 	// Creating the server
 	srv := api.NewServer(
 		c.HTTPPort,
 		gqlHandler,
+		notificationsHandler,
 		middlewares,
 	)
 
+	// Running the background jobs until the shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go worker.NewBroadcastWorker(adapters.Event, c.Broadcast.PollInterval, c.Broadcast.Retention).Run(workerCtx)
+
+	go worker.NewOutboxWorker(interactors.Outbox, c.Outbox.PollInterval).Run(workerCtx)
+
+	go worker.NewDigestWorker(usecase.NewNotificationUC(adapters.Notification), c.Mail.DigestInterval).Run(workerCtx)
+
+	if c.Trash.Retention > 0 {
+		go worker.NewRetentionWorker(interactors.Topic, c.Trash.Retention, c.Trash.PurgeInterval).Run(workerCtx)
+	}
+
+	if c.Badge.BackfillInterval > 0 {
+		go worker.NewBadgeWorker(interactors.Badge, c.Badge.BackfillInterval).Run(workerCtx)
+	}
+
 	// Running the server and handling the possible error
 	go func() {
 		err := srv.Start()
@@ -100,6 +213,8 @@ func main() {
 
 	log.Println("Shutting down")
 
+	stopWorkers()
+
 	// Giving some time for a graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
@@ -109,4 +224,141 @@ func main() {
 	if err != nil {
 		log.Println("Error on server shutdown:", err)
 	}
+
+	// Letting the side effects of the last requests finish
+	adapters.Event.Wait()
+}
+
+// subscribe registers the subscribers of the domain events. The names identify the subscribers in the outbox,
+// so they must not be changed while there are messages pending.
+func subscribe(a *usecase.Adapters) {
+	// The counters change along with the Posts and Topics they count, in the same transaction
+	a.Event.Subscribe("counters", entity.EventDeliverySync,
+		usecase.NewCounterSubscriber(a.Post, a.Topic).Handle,
+		entity.CounterEventTypes...)
+
+	// The Notifications to the others are counted against the quota of the actor along with the action,
+	// so the action is refused rather than its Notifications dropped
+	notifications := usecase.NewNotificationSubscriber(a.Notification, a.Event)
+	a.Event.Subscribe("notification-quota", entity.EventDeliverySync, notifications.Limit,
+		entity.NotificationEventTypes...)
+	a.Event.Subscribe("notifications", entity.EventDeliveryOutbox, notifications.Handle,
+		entity.NotificationEventTypes...)
+
+	a.Event.Subscribe("badges", entity.EventDeliveryOutbox,
+		usecase.NewBadgeSubscriber(a.Badge, a.Event).Handle,
+		entity.EventTypePostCreated, entity.EventTypeTopicCreated, entity.EventTypeUserFollowed)
+	a.Event.Subscribe("ranks", entity.EventDeliveryOutbox,
+		usecase.NewRankSubscriber(a.User, a.Rank, a.Event).Handle,
+		entity.EventTypePostCreated)
+	a.Event.Subscribe("spam-filter", entity.EventDeliveryOutbox,
+		usecase.NewFilterSubscriber(a.Filter).Handle,
+		entity.EventTypePostApproved, entity.EventTypePostRejected, entity.EventTypePostRemoved)
+
+	// Every Webhook triggered by an Event is delivered and retried on its own, outside a transaction
+	webhooks := usecase.NewWebhookSubscriber(a.Webhook, a.Topic, a.Event)
+	a.Event.Subscribe("webhooks", entity.EventDeliveryOutbox, webhooks.Handle, entity.WebhookEventTypes...)
+	a.Event.Subscribe("webhook-deliveries", entity.EventDeliveryExternal, webhooks.Deliver,
+		entity.EventTypeWebhookTriggered)
+
+	// The live updates are pushed to the clients connected to every instance once the changes are committed
+	a.Event.Subscribe("live", entity.EventDeliveryBroadcast, a.Live.Push,
+		entity.EventTypePostCreated, entity.EventTypeTopicCreated, entity.EventTypeNotificationsAdded)
+	a.Event.Subscribe("rank-ladder", entity.EventDeliveryBroadcast, a.Rank.ForgetLadder,
+		entity.EventTypeRankLadderChanged)
+}
+
+// logEventError logs the error of a subscriber delivered asynchronously.
+func logEventError(sess entity.Session, e entity.Event, err error) {
+	log.Printf("Error handling the event %s of the session %s: %v", e.EventType(), sess.ID, err)
+}
+
+// newMailSender creates the mail sender chosen in the configuration.
+func newMailSender(c *bootstrap.MailConfig) (service.MailSender, error) {
+	switch c.Driver {
+	case "file":
+		return mail.NewFileSender(c.OutboxDir, c.From)
+	case "smtp":
+		return mail.NewSMTPSender(c.SMTPHost, c.SMTPPort, c.SMTPUsername, c.SMTPPassword, c.From), nil
+	}
+
+	return nil, fmt.Errorf("unknown mail driver %q", c.Driver)
+}
+
+// newContentFilters creates the built-in content filters enabled in the configuration, in the order they are run.
+// The spam classifier is always run, so it keeps learning and its scores are logged even without any thresholds.
+func newContentFilters(c *bootstrap.FilterConfig, spamStorage filter.SpamStorage) ([]service.ContentFilter, error) {
+	var filters []service.ContentFilter
+
+	if len(c.BlocklistReplace) > 0 || len(c.BlocklistFlag) > 0 || len(c.BlocklistReject) > 0 {
+		filters = append(filters, filter.NewBlocklist(c.BlocklistReplace, c.BlocklistFlag, c.BlocklistReject))
+	}
+
+	if c.MaxLinks > 0 {
+		verdict := entity.FilterVerdict(c.LinksVerdict)
+		if verdict != entity.FilterVerdictFlag && verdict != entity.FilterVerdictReject {
+			return nil, fmt.Errorf("unknown links verdict %q", c.LinksVerdict)
+		}
+
+		filters = append(filters, filter.NewLinkLimit(c.MaxLinks, verdict))
+	}
+
+	filters = append(filters, filter.NewSpamClassifier(spamStorage, c.SpamFlagScore, c.SpamRejectScore, c.SpamMinSamples))
+
+	return filters, nil
+}
+
+// newRateLimitStorage creates the token bucket storage chosen in the configuration.
+func newRateLimitStorage(c *bootstrap.RateLimitConfig, db *repository.DBConn) (service.RateLimitStorage, error) {
+	switch c.Backend {
+	case "memory":
+		return ratelimit.NewMemoryStore(), nil
+	case "postgres":
+		return repository.NewRateLimitRepository(db), nil
+	}
+
+	return nil, fmt.Errorf("unknown rate limit backend %q", c.Backend)
+}
+
+// newBroadcastStorage creates the storage of the Events delivered to every instance chosen in the configuration.
+func newBroadcastStorage(c *bootstrap.BroadcastConfig, db *repository.DBConn,
+	dsn string) (service.BroadcastStorage, error) {
+	switch c.Backend {
+	case "memory":
+		return broadcast.NewMemoryStore(), nil
+	case "postgres":
+		return repository.NewBroadcastRepository(db, dsn), nil
+	}
+
+	return nil, fmt.Errorf("unknown broadcast backend %q", c.Backend)
+}
+
+// newRateLimitRules builds the quotas of every action out of the configuration.
+func newRateLimitRules(c *bootstrap.RateLimitConfig) entity.RateLimitRules {
+	return entity.RateLimitRules{
+		Quotas: map[entity.RateLimitAction]entity.RateLimitQuota{
+			entity.RateLimitActionPost:         entity.RateLimitQuota(c.Post),
+			entity.RateLimitActionTopic:        entity.RateLimitQuota(c.Topic),
+			entity.RateLimitActionRegistration: entity.RateLimitQuota(c.Registration),
+			entity.RateLimitActionNotification: entity.RateLimitQuota(c.Notification),
+		},
+		LevelFactors: map[entity.UserLevel]float64{
+			entity.UserLevelMod:   c.ModFactor,
+			entity.UserLevelAdmin: c.AdminFactor,
+		},
+		TrustedRank:   c.TrustedRank,
+		TrustedFactor: c.TrustedFactor,
+	}
+}
+
+// newSearchEngine creates the search engine chosen in the configuration.
+func newSearchEngine(c *bootstrap.SearchConfig, repo *repository.SearchRepository) (service.SearchEngine, error) {
+	switch c.Backend {
+	case "postgres":
+		return repo, nil
+	case "inprocess":
+		return search.NewInProcess(repo), nil
+	}
+
+	return nil, fmt.Errorf("unknown search backend %q", c.Backend)
 }
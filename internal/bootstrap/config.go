@@ -2,7 +2,11 @@ package bootstrap
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -20,10 +24,175 @@ type DBConfig struct {
 	Password string `envconfig:"DB_PASSWORD"`
 }
 
+// MailConfig contains all the email configuration info.
+// Driver is either "file" (emails are written into OutboxDir) or "smtp".
+type MailConfig struct {
+	Driver         string        `envconfig:"MAIL_DRIVER" default:"file"`
+	From           string        `envconfig:"MAIL_FROM" default:"noreply@simplestforum.local"`
+	OutboxDir      string        `envconfig:"MAIL_OUTBOX_DIR" default:"outbox"`
+	SMTPHost       string        `envconfig:"MAIL_SMTP_HOST"`
+	SMTPPort       string        `envconfig:"MAIL_SMTP_PORT" default:"25"`
+	SMTPUsername   string        `envconfig:"MAIL_SMTP_USERNAME"`
+	SMTPPassword   string        `envconfig:"MAIL_SMTP_PASSWORD"`
+	DigestInterval time.Duration `envconfig:"MAIL_DIGEST_INTERVAL" default:"1m"`
+}
+
+// TrashConfig contains the trash bin retention info.
+// The deleted Topics and Posts older than Retention are removed for good, a zero Retention keeps them forever.
+type TrashConfig struct {
+	Retention     time.Duration `envconfig:"TRASH_RETENTION" default:"0"`
+	PurgeInterval time.Duration `envconfig:"TRASH_PURGE_INTERVAL" default:"1h"`
+}
+
+// BadgeConfig contains the settings of the job granting the Badges, a zero BackfillInterval disables it.
+// The Badges are still granted as the Users post, start topics and get followed.
+type BadgeConfig struct {
+	BackfillInterval time.Duration `envconfig:"BADGES_BACKFILL_INTERVAL" default:"1h"`
+}
+
+// OutboxConfig contains the settings of the delivery of the Events stored in the outbox.
+// A failed delivery is retried after a backoff doubling from BaseBackoff up to MaxBackoff, and dead-lettered
+// after MaxAttempts.
+type OutboxConfig struct {
+	PollInterval time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+	BatchSize    int64         `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+	Lease        time.Duration `envconfig:"OUTBOX_LEASE" default:"1m"`
+	MaxAttempts  int64         `envconfig:"OUTBOX_MAX_ATTEMPTS" default:"10"`
+	BaseBackoff  time.Duration `envconfig:"OUTBOX_BASE_BACKOFF" default:"10s"`
+	MaxBackoff   time.Duration `envconfig:"OUTBOX_MAX_BACKOFF" default:"1h"`
+}
+
+// WebhookConfig contains the settings of the requests sent to the Webhooks.
+// The failed deliveries are retried through the outbox.
+type WebhookConfig struct {
+	Timeout time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+}
+
+// PremoderationConfig contains the rules which hold new Posts until a moderator approves them.
+// A zero value disables the respective rule.
+type PremoderationConfig struct {
+	MinRank       int64         `envconfig:"PREMODERATION_MIN_RANK" default:"0"`
+	MinAccountAge time.Duration `envconfig:"PREMODERATION_MIN_ACCOUNT_AGE" default:"0"`
+	HoldLinks     bool          `envconfig:"PREMODERATION_HOLD_LINKS" default:"false"`
+}
+
+// PostConfig contains the rules of editing the Posts besides the moderators: the authors can edit their Posts within
+// EditWindow, a zero EditWindow doesn't limit it, and the Users of WikiMinRank and up can edit the wiki Posts.
+type PostConfig struct {
+	EditWindow  time.Duration `envconfig:"POST_EDIT_WINDOW" default:"0"`
+	WikiMinRank int64         `envconfig:"POST_WIKI_MIN_RANK" default:"3"`
+}
+
+// TopicConfig contains the duplicate guard settings: the new Topics whose names are at least DuplicateSimilarity
+// similar (from 0 to 1) to a Topic created in the same Section within DuplicateWindow are rejected.
+// A zero DuplicateSimilarity disables the guard.
+type TopicConfig struct {
+	DuplicateSimilarity float64       `envconfig:"TOPIC_DUPLICATE_SIMILARITY" default:"0"`
+	DuplicateWindow     time.Duration `envconfig:"TOPIC_DUPLICATE_WINDOW" default:"24h"`
+}
+
+// FilterConfig contains the settings of the built-in content filters.
+// The blocklists are comma-separated words, a zero MaxLinks or score threshold disables the respective check.
+type FilterConfig struct {
+	BlocklistReplace []string `envconfig:"FILTER_BLOCKLIST_REPLACE"`
+	BlocklistFlag    []string `envconfig:"FILTER_BLOCKLIST_FLAG"`
+	BlocklistReject  []string `envconfig:"FILTER_BLOCKLIST_REJECT"`
+	MaxLinks         int      `envconfig:"FILTER_MAX_LINKS" default:"0"`
+	LinksVerdict     string   `envconfig:"FILTER_LINKS_VERDICT" default:"FLAG"`
+	SpamFlagScore    float64  `envconfig:"FILTER_SPAM_FLAG_SCORE" default:"0.9"`
+	SpamRejectScore  float64  `envconfig:"FILTER_SPAM_REJECT_SCORE" default:"0"`
+	SpamMinSamples   int64    `envconfig:"FILTER_SPAM_MIN_SAMPLES" default:"20"`
+}
+
+// RateLimitQuota is a token bucket quota written as "<burst>/<period>", for example "5/1m" allows 5 actions at once
+// and 5 more every minute. An empty value lifts the limit.
+type RateLimitQuota struct {
+	Burst  int64
+	Period time.Duration
+}
+
+// Decode parses the quota out of an environment variable.
+func (q *RateLimitQuota) Decode(value string) error {
+	if value == "" {
+		*q = RateLimitQuota{}
+
+		return nil
+	}
+
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid quota %q, expected <burst>/<period>", value)
+	}
+
+	var err error
+
+	q.Burst, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid quota burst %q: %w", parts[0], err)
+	}
+
+	q.Period, err = time.ParseDuration(parts[1])
+	if err != nil {
+		return fmt.Errorf("invalid quota period %q: %w", parts[1], err)
+	}
+
+	return nil
+}
+
+// RateLimitConfig contains the quotas of the regular Users, the registrations are limited per client address.
+// The factors scale the quotas for the moderators, the admins and the Users of TrustedRank and up,
+// a zero factor lifts the limits. Backend is either "memory" (a single instance) or "postgres" (shared).
+type RateLimitConfig struct {
+	Backend       string         `envconfig:"RATE_LIMIT_BACKEND" default:"memory"`
+	Post          RateLimitQuota `envconfig:"RATE_LIMIT_POST" default:"10/1m"`
+	Topic         RateLimitQuota `envconfig:"RATE_LIMIT_TOPIC" default:"3/10m"`
+	Registration  RateLimitQuota `envconfig:"RATE_LIMIT_REGISTRATION" default:"3/1h"`
+	Notification  RateLimitQuota `envconfig:"RATE_LIMIT_NOTIFICATION" default:"30/1m"`
+	ModFactor     float64        `envconfig:"RATE_LIMIT_MOD_FACTOR" default:"0"`
+	AdminFactor   float64        `envconfig:"RATE_LIMIT_ADMIN_FACTOR" default:"0"`
+	TrustedRank   int64          `envconfig:"RATE_LIMIT_TRUSTED_RANK" default:"0"`
+	TrustedFactor float64        `envconfig:"RATE_LIMIT_TRUSTED_FACTOR" default:"2"`
+}
+
+// SearchConfig contains the search settings.
+// Backend is either "postgres" (the full-text index) or "inprocess" (the content is scanned on every search).
+type SearchConfig struct {
+	Backend string `envconfig:"SEARCH_BACKEND" default:"postgres"`
+}
+
+// BroadcastConfig contains the settings of the Events delivered to every instance, such as the live updates.
+// Backend is either "memory" (a single instance) or "postgres" (LISTEN/NOTIFY, shared by every instance).
+// The messages are looked for every PollInterval in case a notification was lost, and kept for Retention.
+type BroadcastConfig struct {
+	Backend      string        `envconfig:"BROADCAST_BACKEND" default:"memory"`
+	PollInterval time.Duration `envconfig:"BROADCAST_POLL_INTERVAL" default:"10s"`
+	Retention    time.Duration `envconfig:"BROADCAST_RETENTION" default:"1h"`
+}
+
+// StreamConfig contains the settings of the Server-Sent Events streams. A comment is sent every HeartbeatInterval,
+// so the idle streams aren't closed by the proxies, a zero HeartbeatInterval disables it.
+type StreamConfig struct {
+	HeartbeatInterval time.Duration `envconfig:"STREAM_HEARTBEAT_INTERVAL" default:"15s"`
+}
+
 // Config contains all the configuration info.
 type Config struct {
 	HTTPPort string `envconfig:"HTTP_PORT"`
 	DB       DBConfig
+	Mail     MailConfig
+	Trash    TrashConfig
+	Badge    BadgeConfig
+	Outbox   OutboxConfig
+	Webhook  WebhookConfig
+
+	Post          PostConfig
+	Topic         TopicConfig
+	Premoderation PremoderationConfig
+	Filter        FilterConfig
+	RateLimit     RateLimitConfig
+	Search        SearchConfig
+	Broadcast     BroadcastConfig
+	Stream        StreamConfig
 }
 
 // NewConfig loads configuration from the environment variables, optionally loading them from the file.
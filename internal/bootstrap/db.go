@@ -18,9 +18,9 @@ const (
 	reconnectInterval = 3 * time.Second
 )
 
-// NewDBConnPg creates a new postgres database connection instance.
-func NewDBConnPg(username, password, name, host, port string) (*dbr.Connection, error) {
-	dsn := fmt.Sprintf(
+// DSNPg returns the connection string of the postgres database.
+func DSNPg(username, password, name, host, port string) string {
+	return fmt.Sprintf(
 		"user=%s password=%s dbname=%s host=%s port=%s sslmode=disable",
 		username,
 		password,
@@ -28,8 +28,11 @@ func NewDBConnPg(username, password, name, host, port string) (*dbr.Connection,
 		host,
 		port,
 	)
+}
 
-	p, err := dbr.Open("postgres", dsn, nil)
+// NewDBConnPg creates a new postgres database connection instance.
+func NewDBConnPg(username, password, name, host, port string) (*dbr.Connection, error) {
+	p, err := dbr.Open("postgres", DSNPg(username, password, name, host, port), nil)
 	if err != nil {
 		return nil, err
 	}
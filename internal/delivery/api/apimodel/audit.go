@@ -0,0 +1,55 @@
+package apimodel
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionPostEdit      AuditAction = "POST_EDIT"
+	AuditActionPostDelete    AuditAction = "POST_DELETE"
+	AuditActionPostRestore   AuditAction = "POST_RESTORE"
+	AuditActionPostApprove   AuditAction = "POST_APPROVE"
+	AuditActionPostReject    AuditAction = "POST_REJECT"
+	AuditActionTopicEdit     AuditAction = "TOPIC_EDIT"
+	AuditActionTopicDelete   AuditAction = "TOPIC_DELETE"
+	AuditActionTopicRestore  AuditAction = "TOPIC_RESTORE"
+	AuditActionTopicMerge    AuditAction = "TOPIC_MERGE"
+	AuditActionTopicSplit    AuditAction = "TOPIC_SPLIT"
+	AuditActionUserEdit      AuditAction = "USER_EDIT"
+	AuditActionUserDelete    AuditAction = "USER_DELETE"
+	AuditActionSectionAdd    AuditAction = "SECTION_ADD"
+	AuditActionSectionEdit   AuditAction = "SECTION_EDIT"
+	AuditActionSectionDelete AuditAction = "SECTION_DELETE"
+)
+
+type AuditTargetType string
+
+const (
+	AuditTargetTypePost    AuditTargetType = "POST"
+	AuditTargetTypeTopic   AuditTargetType = "TOPIC"
+	AuditTargetTypeUser    AuditTargetType = "USER"
+	AuditTargetTypeSection AuditTargetType = "SECTION"
+)
+
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	ActorID    int64           `json:"actor_id"`
+	Actor      *User           `json:"actor"`
+	Action     AuditAction     `json:"action"`
+	TargetType AuditTargetType `json:"target_type"`
+	TargetID   int64           `json:"target_id"`
+	Before     *string         `json:"before"`
+	After      *string         `json:"after"`
+	SessionID  string          `json:"session_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type AuditFilters struct {
+	ActorIds    []int64           `json:"actor_ids"`
+	Actions     []AuditAction     `json:"actions"`
+	TargetTypes []AuditTargetType `json:"target_types"`
+	TargetIds   []int64           `json:"target_ids"`
+	SessionID   *string           `json:"session_id"`
+	From        *time.Time        `json:"from"`
+	To          *time.Time        `json:"to"`
+}
@@ -0,0 +1,43 @@
+package apimodel
+
+import "time"
+
+type BadgeRule string
+
+const (
+	BadgeRulePosts          BadgeRule = "POSTS"
+	BadgeRuleTopics         BadgeRule = "TOPICS"
+	BadgeRuleFollowers      BadgeRule = "FOLLOWERS"
+	BadgeRuleMembershipDays BadgeRule = "MEMBERSHIP_DAYS"
+)
+
+type Badge struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Rule        BadgeRule `json:"rule"`
+	Threshold   int64     `json:"threshold"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type UserBadge struct {
+	Badge     *Badge    `json:"badge"`
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+type AddBadgeInput struct {
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Rule        BadgeRule `json:"rule"`
+	Threshold   int64     `json:"threshold"`
+}
+
+type EditBadgeInput struct {
+	ID          int64   `json:"id"`
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Threshold   *int64  `json:"threshold"`
+}
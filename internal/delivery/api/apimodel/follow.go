@@ -0,0 +1,18 @@
+package apimodel
+
+import "time"
+
+type FeedItemKind string
+
+const (
+	FeedItemKindTopic FeedItemKind = "TOPIC"
+	FeedItemKindPost  FeedItemKind = "POST"
+)
+
+type FeedItem struct {
+	Kind      FeedItemKind `json:"kind"`
+	UserID    int64        `json:"user_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Topic     *Topic       `json:"topic"`
+	Post      *Post        `json:"post"`
+}
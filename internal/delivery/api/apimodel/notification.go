@@ -2,9 +2,58 @@ package apimodel
 
 import "time"
 
+// NotificationKind represents the type of a Notification.
+type NotificationKind string
+
+const (
+	NotificationKindGeneral            NotificationKind = "GENERAL"
+	NotificationKindWelcome            NotificationKind = "WELCOME"
+	NotificationKindRankAchieved       NotificationKind = "RANK_ACHIEVED"
+	NotificationKindBadgeGranted       NotificationKind = "BADGE_GRANTED"
+	NotificationKindAnswerAccepted     NotificationKind = "ANSWER_ACCEPTED"
+	NotificationKindNewPost            NotificationKind = "NEW_POST"
+	NotificationKindPostMoved          NotificationKind = "POST_MOVED"
+	NotificationKindPostReassigned     NotificationKind = "POST_REASSIGNED"
+	NotificationKindPostRemoved        NotificationKind = "POST_REMOVED"
+	NotificationKindPostRestored       NotificationKind = "POST_RESTORED"
+	NotificationKindPostApproved       NotificationKind = "POST_APPROVED"
+	NotificationKindPostRejected       NotificationKind = "POST_REJECTED"
+	NotificationKindTopicMoved         NotificationKind = "TOPIC_MOVED"
+	NotificationKindTopicReassigned    NotificationKind = "TOPIC_REASSIGNED"
+	NotificationKindTopicRemoved       NotificationKind = "TOPIC_REMOVED"
+	NotificationKindTopicRestored      NotificationKind = "TOPIC_RESTORED"
+	NotificationKindTopicMerged        NotificationKind = "TOPIC_MERGED"
+	NotificationKindTopicSplit         NotificationKind = "TOPIC_SPLIT"
+	NotificationKindLevelChanged       NotificationKind = "LEVEL_CHANGED"
+	NotificationKindRestrictionChanged NotificationKind = "RESTRICTION_CHANGED"
+)
+
+// NotificationEmailMode represents the way a Notification is delivered by email.
+type NotificationEmailMode string
+
+const (
+	NotificationEmailModeNone      NotificationEmailMode = "NONE"
+	NotificationEmailModeImmediate NotificationEmailMode = "IMMEDIATE"
+	NotificationEmailModeDaily     NotificationEmailMode = "DAILY"
+	NotificationEmailModeWeekly    NotificationEmailMode = "WEEKLY"
+)
+
 type Notification struct {
-	ID        int64     `json:"id"`
-	UserID    int64     `json:"user_id"`
-	Text      string    `json:"text"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int64            `json:"id"`
+	UserID    int64            `json:"user_id"`
+	Kind      NotificationKind `json:"kind"`
+	ActorID   *int64           `json:"actor_id"`
+	PostID    *int64           `json:"post_id"`
+	TopicID   *int64           `json:"topic_id"`
+	SectionID *int64           `json:"section_id"`
+	Text      string           `json:"text"`
+	Count     int64            `json:"count"`
+	ReadAt    *time.Time       `json:"read_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type NotificationPreference struct {
+	Kind  NotificationKind      `json:"kind"`
+	InApp bool                  `json:"in_app"`
+	Email NotificationEmailMode `json:"email"`
 }
@@ -0,0 +1,32 @@
+package apimodel
+
+import "time"
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "PENDING"
+	OutboxStatusDead    OutboxStatus = "DEAD"
+)
+
+type OutboxMessage struct {
+	ID            int64        `json:"id"`
+	Subscriber    string       `json:"subscriber"`
+	EventType     string       `json:"event_type"`
+	Payload       string       `json:"payload"`
+	SessionID     string       `json:"session_id"`
+	UserID        int64        `json:"user_id"`
+	Status        OutboxStatus `json:"status"`
+	Attempts      int64        `json:"attempts"`
+	LastError     *string      `json:"last_error"`
+	NextAttemptAt time.Time    `json:"next_attempt_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type OutboxFilters struct {
+	Ids          []int64        `json:"ids"`
+	Statuses     []OutboxStatus `json:"statuses"`
+	Subscribers  []string       `json:"subscribers"`
+	EventTypes   []string       `json:"event_types"`
+	AttemptsFrom *int64         `json:"attempts_from"`
+}
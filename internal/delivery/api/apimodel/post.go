@@ -10,25 +10,41 @@ type AddPostInput struct {
 type EditPostInput struct {
 	ID      int64   `json:"id"`
 	Text    *string `json:"text"`
+	Wiki    *bool   `json:"wiki"`
 	UserID  *int64  `json:"user_id"`
 	TopicID *int64  `json:"topic_id"`
 }
 
 type Post struct {
-	ID        int64     `json:"id"`
-	Text      string    `json:"text"`
-	UserID    int64     `json:"user_id"`
-	User      *User     `json:"user"`
-	TopicID   int64     `json:"topic_id"`
-	Topic     *Topic    `json:"topic"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        int64      `json:"id"`
+	Text      string     `json:"text"`
+	Status    PostStatus `json:"status"`
+	Wiki      bool       `json:"wiki"`
+	UserID    int64      `json:"user_id"`
+	User      *User      `json:"user"`
+	TopicID   int64      `json:"topic_id"`
+	Topic     *Topic     `json:"topic"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+
+	AuthorIgnored bool `json:"author_ignored"`
+
+	Contributors []*PostContributor `json:"contributors"`
+}
+
+type PostContributor struct {
+	UserID       int64     `json:"user_id"`
+	User         *User     `json:"user"`
+	CountEdits   int64     `json:"count_edits"`
+	LastEditedAt time.Time `json:"last_edited_at"`
 }
 
 type PostFilters struct {
-	Ids      []int64 `json:"ids"`
-	UserIds  []int64 `json:"user_ids"`
-	TopicIds []int64 `json:"topic_ids"`
+	Ids         []int64 `json:"ids"`
+	UserIds     []int64 `json:"user_ids"`
+	TopicIds    []int64 `json:"topic_ids"`
+	HideIgnored bool    `json:"hide_ignored"`
 }
 
 type PostSort struct {
@@ -36,6 +52,13 @@ type PostSort struct {
 	Order SortOrder  `json:"order"`
 }
 
+type PostStatus string
+
+const (
+	PostStatusPublished PostStatus = "PUBLISHED"
+	PostStatusPending   PostStatus = "PENDING"
+)
+
 type PostSortBy string
 
 const (
@@ -0,0 +1,72 @@
+package apimodel
+
+import "time"
+
+type ProfileVisibility string
+
+const (
+	ProfileVisibilityPublic  ProfileVisibility = "PUBLIC"
+	ProfileVisibilityMembers ProfileVisibility = "MEMBERS"
+	ProfileVisibilityPrivate ProfileVisibility = "PRIVATE"
+)
+
+type ProfileFieldType string
+
+const (
+	ProfileFieldTypeText   ProfileFieldType = "TEXT"
+	ProfileFieldTypeNumber ProfileFieldType = "NUMBER"
+	ProfileFieldTypeURL    ProfileFieldType = "URL"
+	ProfileFieldTypeDate   ProfileFieldType = "DATE"
+	ProfileFieldTypeSelect ProfileFieldType = "SELECT"
+)
+
+type ProfileField struct {
+	ID         int64             `json:"id"`
+	Name       string            `json:"name"`
+	Title      string            `json:"title"`
+	Type       ProfileFieldType  `json:"type"`
+	Required   bool              `json:"required"`
+	Visibility ProfileVisibility `json:"visibility"`
+	Pattern    *string           `json:"pattern"`
+	MaxLength  *int64            `json:"max_length"`
+	Options    []string          `json:"options"`
+	Position   int64             `json:"position"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+type ProfileFieldValue struct {
+	FieldID int64            `json:"field_id"`
+	Name    string           `json:"name"`
+	Title   string           `json:"title"`
+	Type    ProfileFieldType `json:"type"`
+	Value   string           `json:"value"`
+}
+
+type AddProfileFieldInput struct {
+	Name       string            `json:"name"`
+	Title      string            `json:"title"`
+	Type       ProfileFieldType  `json:"type"`
+	Required   bool              `json:"required"`
+	Visibility ProfileVisibility `json:"visibility"`
+	Pattern    *string           `json:"pattern"`
+	MaxLength  *int64            `json:"max_length"`
+	Options    []string          `json:"options"`
+	Position   *int64            `json:"position"`
+}
+
+type EditProfileFieldInput struct {
+	ID         int64              `json:"id"`
+	Title      *string            `json:"title"`
+	Required   *bool              `json:"required"`
+	Visibility *ProfileVisibility `json:"visibility"`
+	Pattern    *string            `json:"pattern"`
+	MaxLength  *int64             `json:"max_length"`
+	Options    []string           `json:"options"`
+	Position   *int64             `json:"position"`
+}
+
+type ProfileFieldValueInput struct {
+	FieldID int64  `json:"field_id"`
+	Value   string `json:"value"`
+}
@@ -0,0 +1,33 @@
+package apimodel
+
+import "time"
+
+type RankPerk string
+
+const (
+	RankPerkSignature RankPerk = "SIGNATURE"
+	RankPerkAvatar    RankPerk = "AVATAR"
+)
+
+type Rank struct {
+	ID        int64      `json:"id"`
+	Number    int64      `json:"number"`
+	MinPosts  int64      `json:"min_posts"`
+	Title     string     `json:"title"`
+	Perks     []RankPerk `json:"perks"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type AddRankInput struct {
+	MinPosts int64      `json:"min_posts"`
+	Title    string     `json:"title"`
+	Perks    []RankPerk `json:"perks"`
+}
+
+type EditRankInput struct {
+	ID       int64      `json:"id"`
+	MinPosts *int64     `json:"min_posts"`
+	Title    *string    `json:"title"`
+	Perks    []RankPerk `json:"perks"`
+}
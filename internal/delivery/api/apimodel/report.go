@@ -0,0 +1,71 @@
+package apimodel
+
+import "time"
+
+type ReportTargetType string
+
+const (
+	ReportTargetTypePost  ReportTargetType = "POST"
+	ReportTargetTypeTopic ReportTargetType = "TOPIC"
+	ReportTargetTypeUser  ReportTargetType = "USER"
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam     ReportReason = "SPAM"
+	ReportReasonAbuse    ReportReason = "ABUSE"
+	ReportReasonOffTopic ReportReason = "OFF_TOPIC"
+	ReportReasonIllegal  ReportReason = "ILLEGAL"
+	ReportReasonOther    ReportReason = "OTHER"
+)
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "OPEN"
+	ReportStatusResolved  ReportStatus = "RESOLVED"
+	ReportStatusDismissed ReportStatus = "DISMISSED"
+)
+
+type ReportAction string
+
+const (
+	ReportActionDeleteContent  ReportAction = "DELETE_CONTENT"
+	ReportActionRestrictAuthor ReportAction = "RESTRICT_AUTHOR"
+	ReportActionBanAuthor      ReportAction = "BAN_AUTHOR"
+)
+
+type Report struct {
+	ID             int64            `json:"id"`
+	ReporterID     int64            `json:"reporter_id"`
+	TargetType     ReportTargetType `json:"target_type"`
+	TargetID       int64            `json:"target_id"`
+	TargetUserID   int64            `json:"target_user_id"`
+	Reason         ReportReason     `json:"reason"`
+	Text           *string          `json:"text"`
+	Status         ReportStatus     `json:"status"`
+	AssigneeID     *int64           `json:"assignee_id"`
+	ResolutionNote *string          `json:"resolution_note"`
+	Action         *ReportAction    `json:"action"`
+	ResolvedByID   *int64           `json:"resolved_by_id"`
+	ResolvedAt     *time.Time       `json:"resolved_at"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+type ResolveReportInput struct {
+	ID     int64         `json:"id"`
+	Status ReportStatus  `json:"status"`
+	Note   *string       `json:"note"`
+	Action *ReportAction `json:"action"`
+}
+
+type ReportFilters struct {
+	Ids           []int64            `json:"ids"`
+	Statuses      []ReportStatus     `json:"statuses"`
+	TargetTypes   []ReportTargetType `json:"target_types"`
+	TargetUserIds []int64            `json:"target_user_ids"`
+	AssigneeIds   []int64            `json:"assignee_ids"`
+	ReporterIds   []int64            `json:"reporter_ids"`
+}
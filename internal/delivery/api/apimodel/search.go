@@ -0,0 +1,23 @@
+package apimodel
+
+import "time"
+
+type SearchHitKind string
+
+const (
+	SearchHitKindPost  SearchHitKind = "POST"
+	SearchHitKindTopic SearchHitKind = "TOPIC"
+)
+
+type SearchHit struct {
+	Kind      SearchHitKind `json:"kind"`
+	ID        int64         `json:"id"`
+	TopicID   int64         `json:"topic_id"`
+	SectionID int64         `json:"section_id"`
+	UserID    int64         `json:"user_id"`
+	Rank      float64       `json:"rank"`
+	Snippet   string        `json:"snippet"`
+	CreatedAt time.Time     `json:"created_at"`
+	Post      *Post         `json:"post"`
+	Topic     *Topic        `json:"topic"`
+}
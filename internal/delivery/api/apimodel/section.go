@@ -3,24 +3,27 @@ package apimodel
 import "time"
 
 type AddSectionInput struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	Mode        SectionMode `json:"mode"`
 }
 
 type EditSectionInput struct {
-	ID          int64   `json:"id"`
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
+	ID          int64        `json:"id"`
+	Name        *string      `json:"name"`
+	Description *string      `json:"description"`
+	Mode        *SectionMode `json:"mode"`
 }
 
 type Section struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
-	Description *string   `json:"description"`
-	CountTopics int64     `json:"count_topics"`
-	Topics      []*Topic  `json:"topics"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int64       `json:"id"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	Mode        SectionMode `json:"mode"`
+	CountTopics int64       `json:"count_topics"`
+	Topics      []*Topic    `json:"topics"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
 }
 
 type SectionFilters struct {
@@ -38,3 +41,10 @@ const (
 	SectionSortByCountTopics SectionSortBy = "COUNT_TOPICS"
 	SectionSortByCreatedAt   SectionSortBy = "CREATED_AT"
 )
+
+type SectionMode string
+
+const (
+	SectionModeDiscussion SectionMode = "DISCUSSION"
+	SectionModeQa         SectionMode = "QA"
+)
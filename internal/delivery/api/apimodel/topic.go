@@ -15,22 +15,33 @@ type EditTopicInput struct {
 }
 
 type Topic struct {
-	ID         int64     `json:"id"`
-	SectionID  int64     `json:"section_id"`
-	Section    *Section  `json:"section"`
-	Name       string    `json:"name"`
-	UserID     int64     `json:"user_id"`
-	User       *User     `json:"user"`
-	CountPosts int64     `json:"count_posts"`
-	Posts      []*Post   `json:"posts"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         int64      `json:"id"`
+	SectionID  int64      `json:"section_id"`
+	Section    *Section   `json:"section"`
+	Name       string     `json:"name"`
+	UserID     int64      `json:"user_id"`
+	User       *User      `json:"user"`
+	CountPosts int64      `json:"count_posts"`
+	Posts      []*Post    `json:"posts"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+
+	RedirectTopicID *int64 `json:"redirect_topic_id"`
+
+	AcceptedPostID *int64 `json:"accepted_post_id"`
+	AcceptedAnswer *Post  `json:"accepted_answer"`
+
+	UnreadCount     *int64 `json:"unread_count"`
+	FirstUnreadPost *Post  `json:"first_unread_post"`
 }
 
 type TopicFilters struct {
 	Ids        []int64 `json:"ids"`
 	UserIds    []int64 `json:"user_ids"`
 	SectionIds []int64 `json:"section_ids"`
+	UnreadOnly *bool   `json:"unread_only"`
+	Answered   *bool   `json:"answered"`
 }
 
 type TopicSort struct {
@@ -46,3 +57,14 @@ const (
 	TopicSortByCountPosts TopicSortBy = "COUNT_POSTS"
 	TopicSortByCreatedAt  TopicSortBy = "CREATED_AT"
 )
+
+// TrashPurgeResult contains the number of the Topics and Posts removed for good.
+type TrashPurgeResult struct {
+	Topics int64 `json:"topics"`
+	Posts  int64 `json:"posts"`
+}
+
+type SimilarTopic struct {
+	Topic      *Topic  `json:"topic"`
+	Similarity float64 `json:"similarity"`
+}
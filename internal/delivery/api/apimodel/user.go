@@ -22,19 +22,25 @@ const (
 
 // User is a general structure representing a User.
 type User struct {
-	ID          int64           `json:"id"`
-	Nickname    string          `json:"nickname"`
-	ShowInfo    bool            `json:"show_info"`
-	Rank        int64           `json:"rank"`
-	Level       UserLevel       `json:"level"`
-	Restriction UserRestriction `json:"restriction"`
-	UserInfo    *UserInfo       `json:"user_info"`
-	CountTopics int64           `json:"count_topics"`
-	CountPosts  int64           `json:"count_posts"`
-	Topics      []*Topic        `json:"topics"`
-	Posts       []*Post         `json:"posts"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID            int64                `json:"id"`
+	Nickname      string               `json:"nickname"`
+	ShowInfo      bool                 `json:"show_info"`
+	Rank          int64                `json:"rank"`
+	RankStep      *Rank                `json:"rank_step"`
+	Reputation    int64                `json:"reputation"`
+	Badges        []*UserBadge         `json:"badges"`
+	Level         UserLevel            `json:"level"`
+	Restriction   UserRestriction      `json:"restriction"`
+	UserInfo      *UserInfo            `json:"user_info"`
+	ProfileFields []*ProfileFieldValue `json:"profile_fields"`
+	CountTopics   int64                `json:"count_topics"`
+	CountPosts    int64                `json:"count_posts"`
+	Topics        []*Topic             `json:"topics"`
+	Posts         []*Post              `json:"posts"`
+	Followers     []*User              `json:"followers"`
+	Following     []*User              `json:"following"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
 }
 
 // AddUserInput is a structure which represents the input to create a new User.
@@ -46,6 +52,14 @@ type AddUserInput struct {
 	Email     *string `json:"email"`
 	FirstName *string `json:"first_name"`
 	LastName  *string `json:"last_name"`
+	Bio       *string `json:"bio"`
+	Signature *string `json:"signature"`
+
+	PhoneVisibility     *ProfileVisibility        `json:"phone_visibility"`
+	EmailVisibility     *ProfileVisibility        `json:"email_visibility"`
+	FirstNameVisibility *ProfileVisibility        `json:"first_name_visibility"`
+	LastNameVisibility  *ProfileVisibility        `json:"last_name_visibility"`
+	ProfileFields       []*ProfileFieldValueInput `json:"profile_fields"`
 }
 
 // EditUserInput is a structure which represents the input to edit an existing User.
@@ -63,14 +77,32 @@ type EditUserInput struct {
 	CountPosts  *int64           `json:"count_posts"`
 	Level       *UserLevel       `json:"level"`
 	Restriction *UserRestriction `json:"restriction"`
+	Bio         *string          `json:"bio"`
+	Signature   *string          `json:"signature"`
+
+	PhoneVisibility     *ProfileVisibility        `json:"phone_visibility"`
+	EmailVisibility     *ProfileVisibility        `json:"email_visibility"`
+	FirstNameVisibility *ProfileVisibility        `json:"first_name_visibility"`
+	LastNameVisibility  *ProfileVisibility        `json:"last_name_visibility"`
+	ProfileFields       []*ProfileFieldValueInput `json:"profile_fields"`
 }
 
 // UserInfo contains secondary information about a User.
+// The HTML versions of the bio and the signature are escaped and safe to embed into a page.
 type UserInfo struct {
-	Phone     *string `json:"phone"`
-	Email     *string `json:"email"`
-	FirstName *string `json:"first_name"`
-	LastName  *string `json:"last_name"`
+	Phone         *string `json:"phone"`
+	Email         *string `json:"email"`
+	FirstName     *string `json:"first_name"`
+	LastName      *string `json:"last_name"`
+	Bio           *string `json:"bio"`
+	BioHTML       *string `json:"bio_html"`
+	Signature     *string `json:"signature"`
+	SignatureHTML *string `json:"signature_html"`
+
+	PhoneVisibility     *ProfileVisibility `json:"phone_visibility"`
+	EmailVisibility     *ProfileVisibility `json:"email_visibility"`
+	FirstNameVisibility *ProfileVisibility `json:"first_name_visibility"`
+	LastNameVisibility  *ProfileVisibility `json:"last_name_visibility"`
 }
 
 type UserFilters struct {
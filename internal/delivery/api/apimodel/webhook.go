@@ -0,0 +1,51 @@
+package apimodel
+
+import "time"
+
+type Webhook struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	SectionIds []int64   `json:"section_ids"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID         int64     `json:"id"`
+	WebhookID  int64     `json:"webhook_id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Payload    string    `json:"payload"`
+	StatusCode *int64    `json:"status_code"`
+	Error      *string   `json:"error"`
+	DurationMs int64     `json:"duration_ms"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AddWebhookInput struct {
+	URL        string   `json:"url"`
+	Secret     *string  `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	SectionIds []int64  `json:"section_ids"`
+	Active     bool     `json:"active"`
+}
+
+type EditWebhookInput struct {
+	ID         int64    `json:"id"`
+	URL        *string  `json:"url"`
+	Secret     *string  `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	SectionIds []int64  `json:"section_ids"`
+	Active     *bool    `json:"active"`
+}
+
+type WebhookDeliveryFilters struct {
+	Ids        []int64  `json:"ids"`
+	WebhookIds []int64  `json:"webhook_ids"`
+	EventIds   []string `json:"event_ids"`
+	EventTypes []string `json:"event_types"`
+}
@@ -1,10 +1,14 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"simplestforum/internal/delivery"
+	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 	"simplestforum/internal/domain/usecase"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 )
 
 // Auth represents an Auth middleware.
@@ -50,3 +54,38 @@ func (m *Auth) Handler(next http.Handler) http.Handler {
 		},
 	)
 }
+
+// WebsocketInit checks the credentials sent in the init payload of a websocket connection, since the browsers can't
+// set the headers of the upgrade request. They are given in the Authorization key the same way as in the header.
+// Without them, the connection is served as a guest.
+func (m *Auth) WebsocketInit(ctx context.Context, payload transport.InitPayload) (context.Context, error) {
+	auth := payload.Authorization()
+	if auth == "" {
+		return ctx, nil
+	}
+
+	r := &http.Request{Header: http.Header{"Authorization": {auth}}}
+
+	nickname, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, domain.NewError(domain.ErrCodeNotAuthorized, "Malformed credentials")
+	}
+
+	sess := entity.GetSession(ctx)
+
+	user, err := m.userService.ByLoginAndPassword(sess, nickname, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Restriction.AtLeast(entity.UserRestrictionBanned) {
+		return nil, domain.NewError(domain.ErrCodeRestricted, "You are banned")
+	}
+
+	sess.UserID = user.ID
+	sess.Level = user.Level
+	sess.Restriction = user.Restriction
+	sess.Ctx = ctx
+
+	return entity.PutSession(ctx, sess), nil
+}
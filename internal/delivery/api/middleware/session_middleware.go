@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 	"simplestforum/internal/domain/entity"
 
@@ -20,8 +21,10 @@ func (m *Session) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			sess := entity.Session{
-				ID:  uuid.NewString(),
-				Ctx: r.Context(),
+				ID:         uuid.NewString(),
+				ClientAddr: clientAddr(r),
+				Ctx:        r.Context(),
+				Events:     &entity.EventQueue{},
 			}
 
 			r = r.WithContext(entity.PutSession(r.Context(), sess))
@@ -30,3 +33,13 @@ func (m *Session) Handler(next http.Handler) http.Handler {
 		},
 	)
 }
+
+// clientAddr returns the IP address of the client without the port.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -11,8 +11,9 @@ import (
 )
 
 const (
-	gqlEndpoint        = "/v1/public"
-	playgroundEndpoint = "/playground"
+	gqlEndpoint           = "/v1/public"
+	notificationsEndpoint = "/v1/notifications"
+	playgroundEndpoint    = "/playground"
 )
 
 // Server is a structure which contains everything needed for the REST server.
@@ -20,23 +21,32 @@ type Server struct {
 	srv    *http.Server
 	router *mux.Router
 
-	gqlHandler http.Handler
+	gqlHandler           http.Handler
+	notificationsHandler http.Handler
 
 	middleware *middleware.Middlewares
+
+	// streams is done once the server shuts down, ending the streaming requests
+	streams     context.Context
+	stopStreams context.CancelFunc
 }
 
 // NewServer instantiates a new Server object.
-func NewServer(port string, gh http.Handler, m *middleware.Middlewares) *Server {
+func NewServer(port string, gh, nh http.Handler, m *middleware.Middlewares) *Server {
 	r := mux.NewRouter()
+	streams, stopStreams := context.WithCancel(context.Background())
 
 	srv := Server{
 		srv: &http.Server{
 			Addr:    ":" + port,
 			Handler: r,
 		},
-		router:     r,
-		gqlHandler: gh,
-		middleware: m,
+		router:               r,
+		gqlHandler:           gh,
+		notificationsHandler: nh,
+		middleware:           m,
+		streams:              streams,
+		stopStreams:          stopStreams,
 	}
 
 	return &srv
@@ -47,6 +57,31 @@ func (srv *Server) setGraphQLRoutes() {
 	srv.router.Handle(gqlEndpoint, srv.gqlHandler)
 }
 
+// setStreamRoutes defines the Server-Sent Events endpoints.
+func (srv *Server) setStreamRoutes() {
+	srv.router.Handle(notificationsEndpoint, srv.stream(srv.notificationsHandler))
+}
+
+// stream ends the requests served by the handler once the server shuts down, since they never finish by themselves.
+func (srv *Server) stream(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+
+			go func() {
+				select {
+				case <-srv.streams.Done():
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		},
+	)
+}
+
 // setMiscRoutes defines miscellaneous helpful routes.
 func (srv *Server) setMiscRoutes() {
 	srv.router.HandleFunc(playgroundEndpoint, func(w http.ResponseWriter, _ *http.Request) {
@@ -60,6 +95,7 @@ func (srv *Server) setMiscRoutes() {
 func (srv *Server) Start() error {
 	srv.router.Use(srv.middleware.Handlers()...)
 	srv.setGraphQLRoutes()
+	srv.setStreamRoutes()
 	srv.setMiscRoutes()
 
 	// Preparing the GQL Playground
@@ -71,7 +107,9 @@ func (srv *Server) Start() error {
 	return srv.srv.ListenAndServe()
 }
 
-// Shutdown stops the server.
+// Shutdown stops the server. The streams are ended first, so the server doesn't wait for them until the deadline.
 func (srv *Server) Shutdown(ctx context.Context) error {
+	srv.stopStreams()
+
 	return srv.srv.Shutdown(ctx)
 }
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"context"
+	"simplestforum/internal/domain/entity"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// IsolateEvents gives every operation its own queue of the Events published, since the operations sent over
+// a websocket share the Session of the connection and run concurrently.
+func IsolateEvents(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	sess := entity.GetSession(ctx)
+	sess.Events = &entity.EventQueue{}
+
+	return next(entity.PutSession(ctx, sess))
+}
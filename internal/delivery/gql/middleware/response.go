@@ -14,6 +14,12 @@ const introspectionQuery = "IntrospectionQuery"
 // WrapResponse is a middleware function which wraps the response into the CustomResponse.
 func WrapResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
 	res := next(ctx)
+
+	// A subscription is over
+	if res == nil {
+		return nil
+	}
+
 	sess := entity.GetSession(ctx)
 
 	op := graphql.GetOperationContext(ctx)
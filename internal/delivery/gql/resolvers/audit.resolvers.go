@@ -0,0 +1,28 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// ShowAuditLog is the resolver for the showAuditLog field.
+func (r *queryResolver) ShowAuditLog(ctx context.Context, f *apimodel.AuditFilters, p *apimodel.Pagination) ([]*apimodel.AuditEntry, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	entries, err := r.Audit.All(sess, dto.AuditFiltersFromRest(f), dto.PaginationFromRest(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.AuditEntriesToRest(entries), nil
+}
@@ -0,0 +1,77 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// AddBadge is the resolver for the addBadge field.
+func (r *mutationResolver) AddBadge(ctx context.Context, f apimodel.AddBadgeInput) (*apimodel.Badge, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	badge, err := r.Badge.Add(sess, dto.BadgeAddFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.BadgeToRest(badge), nil
+}
+
+// EditBadge is the resolver for the editBadge field.
+func (r *mutationResolver) EditBadge(ctx context.Context, f apimodel.EditBadgeInput) (*apimodel.Badge, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	badge, err := r.Badge.Edit(sess, dto.BadgeEditFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.BadgeToRest(badge), nil
+}
+
+// DeleteBadge is the resolver for the deleteBadge field.
+func (r *mutationResolver) DeleteBadge(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Badge.Delete(sess, id)
+
+	return err == nil, err
+}
+
+// BackfillBadges is the resolver for the backfillBadges field.
+func (r *mutationResolver) BackfillBadges(ctx context.Context) (int64, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return 0, domain.ErrNotAuthorized
+	}
+
+	return r.Badge.Backfill(sess)
+}
+
+// ShowBadges is the resolver for the showBadges field.
+func (r *queryResolver) ShowBadges(ctx context.Context) ([]*apimodel.Badge, error) {
+	sess := entity.GetSession(ctx)
+
+	badges, err := r.Badge.All(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.BadgesToRest(badges), nil
+}
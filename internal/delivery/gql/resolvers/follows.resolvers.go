@@ -0,0 +1,76 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// Follow is the resolver for the follow field.
+func (r *mutationResolver) Follow(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Resolver.Follow.Follow(sess, id)
+
+	return err == nil, err
+}
+
+// Unfollow is the resolver for the unfollow field.
+func (r *mutationResolver) Unfollow(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Resolver.Follow.Unfollow(sess, id)
+
+	return err == nil, err
+}
+
+// Ignore is the resolver for the ignore field.
+func (r *mutationResolver) Ignore(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Resolver.Follow.Ignore(sess, id)
+
+	return err == nil, err
+}
+
+// Unignore is the resolver for the unignore field.
+func (r *mutationResolver) Unignore(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Resolver.Follow.Unignore(sess, id)
+
+	return err == nil, err
+}
+
+// ActivityFeed is the resolver for the activityFeed field.
+func (r *queryResolver) ActivityFeed(ctx context.Context, p *apimodel.Pagination) ([]*apimodel.FeedItem, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	items, err := r.Resolver.Follow.Feed(sess, dto.PaginationFromRest(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.FeedItemsToRest(items), nil
+}
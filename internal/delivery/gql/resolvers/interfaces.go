@@ -20,6 +20,16 @@ type TopicInteractor interface {
 	Delete(entity.Session, int64) error
 	ByID(entity.Session, int64) (*entity.Topic, error)
 	All(entity.Session, *entity.TopicFilters, *entity.Pagination, *entity.TopicSort) ([]*entity.Topic, error)
+	MarkTopicRead(entity.Session, int64) error
+	MarkSectionRead(entity.Session, int64) error
+	Merge(entity.Session, *entity.TopicMerge) (*entity.Topic, error)
+	Split(entity.Session, *entity.TopicSplit) (*entity.Topic, error)
+	AcceptAnswer(entity.Session, int64, int64) (*entity.Topic, error)
+	UnacceptAnswer(entity.Session, int64) (*entity.Topic, error)
+	Restore(entity.Session, int64) (*entity.Topic, error)
+	Purge(entity.Session, *entity.TrashPurge) (*entity.TrashPurgeResult, error)
+	AllDeleted(entity.Session, *entity.TopicFilters, *entity.Pagination, *entity.TopicSort) ([]*entity.Topic, error)
+	SuggestSimilar(entity.Session, string, *int64) ([]*entity.SimilarTopic, error)
 }
 
 // SectionInteractor is an abstract Section usecase.
@@ -38,10 +48,105 @@ type PostInteractor interface {
 	Delete(entity.Session, int64) error
 	ByID(entity.Session, int64) (*entity.Post, error)
 	All(entity.Session, *entity.PostFilters, *entity.Pagination, *entity.PostSort) ([]*entity.Post, error)
+	Restore(entity.Session, int64) (*entity.Post, error)
+	Approve(entity.Session, int64) (*entity.Post, error)
+	Reject(entity.Session, int64) error
+	AllPending(entity.Session, *entity.PostFilters, *entity.Pagination, *entity.PostSort) ([]*entity.Post, error)
+	AllDeleted(entity.Session, *entity.PostFilters, *entity.Pagination, *entity.PostSort) ([]*entity.Post, error)
 }
 
 // NotificationInteractor is an abstract Notification usecase.
 type NotificationInteractor interface {
 	Clear(entity.Session) error
+	MarkRead(entity.Session, []int64) error
 	All(entity.Session, *entity.Pagination) ([]*entity.Notification, error)
+	CountUnread(entity.Session) (int64, error)
+	Preferences(entity.Session) ([]*entity.NotificationPreference, error)
+	SetPreference(entity.Session, *entity.NotificationPreferenceSet) (*entity.NotificationPreference, error)
+}
+
+// WatchInteractor is an abstract Watch usecase.
+type WatchInteractor interface {
+	WatchTopic(entity.Session, int64) error
+	UnwatchTopic(entity.Session, int64) error
+	WatchSection(entity.Session, int64) error
+	UnwatchSection(entity.Session, int64) error
+}
+
+// ReportInteractor is an abstract Report usecase.
+type ReportInteractor interface {
+	Add(entity.Session, *entity.ReportAdd) (*entity.Report, error)
+	Assign(entity.Session, *entity.ReportAssign) (*entity.Report, error)
+	Resolve(entity.Session, *entity.ReportResolve) (*entity.Report, error)
+	All(entity.Session, *entity.ReportFilters, *entity.Pagination) ([]*entity.Report, error)
+}
+
+// SearchInteractor is an abstract search usecase.
+type SearchInteractor interface {
+	Search(entity.Session, *entity.SearchQuery, *entity.Pagination) ([]*entity.SearchHit, error)
+}
+
+// ProfileInteractor is an abstract custom profile field usecase.
+type ProfileInteractor interface {
+	AddField(entity.Session, *entity.ProfileFieldAdd) (*entity.ProfileField, error)
+	EditField(entity.Session, *entity.ProfileFieldEdit) (*entity.ProfileField, error)
+	DeleteField(entity.Session, int64) error
+	Fields(entity.Session) ([]*entity.ProfileField, error)
+}
+
+// FollowInteractor is an abstract usecase of the follow and ignore lists.
+type FollowInteractor interface {
+	Follow(entity.Session, int64) error
+	Unfollow(entity.Session, int64) error
+	Ignore(entity.Session, int64) error
+	Unignore(entity.Session, int64) error
+	Feed(entity.Session, *entity.Pagination) ([]*entity.FeedItem, error)
+}
+
+// RankInteractor is an abstract rank ladder usecase.
+type RankInteractor interface {
+	Add(entity.Session, *entity.RankAdd) (*entity.Rank, error)
+	Edit(entity.Session, *entity.RankEdit) (*entity.Rank, error)
+	Delete(entity.Session, int64) error
+	Ladder(entity.Session) (entity.RankLadder, error)
+	Recalculate(entity.Session) (int64, error)
+}
+
+// BadgeInteractor is an abstract Badge usecase.
+type BadgeInteractor interface {
+	Add(entity.Session, *entity.BadgeAdd) (*entity.Badge, error)
+	Edit(entity.Session, *entity.BadgeEdit) (*entity.Badge, error)
+	Delete(entity.Session, int64) error
+	All(entity.Session) ([]*entity.Badge, error)
+	Backfill(entity.Session) (int64, error)
+}
+
+// AuditInteractor is an abstract audit log usecase.
+type AuditInteractor interface {
+	All(entity.Session, *entity.AuditFilters, *entity.Pagination) ([]*entity.AuditEntry, error)
+}
+
+// OutboxInteractor is an abstract outbox usecase.
+type OutboxInteractor interface {
+	All(entity.Session, *entity.OutboxFilters, *entity.Pagination) ([]*entity.OutboxMessage, error)
+	Retry(entity.Session, int64) (*entity.OutboxMessage, error)
+	Dispatch(entity.Session) (*entity.OutboxDispatchResult, error)
+}
+
+// WebhookInteractor is an abstract Webhook usecase.
+type WebhookInteractor interface {
+	Add(entity.Session, *entity.WebhookAdd) (*entity.Webhook, error)
+	Edit(entity.Session, *entity.WebhookEdit) (*entity.Webhook, error)
+	Delete(entity.Session, int64) error
+	All(entity.Session) ([]*entity.Webhook, error)
+	Deliveries(entity.Session, *entity.WebhookDeliveryFilters, *entity.Pagination) ([]*entity.WebhookDelivery, error)
+	Redeliver(entity.Session, int64) (*entity.WebhookDelivery, error)
+}
+
+// LiveInteractor is an abstract live update usecase.
+type LiveInteractor interface {
+	PostAdded(entity.Session, int64) (<-chan *entity.Post, error)
+	TopicAdded(entity.Session, int64) (<-chan *entity.Topic, error)
+	NotificationReceived(entity.Session) (<-chan *entity.Notification, error)
+	NotificationReceivedAfter(entity.Session, int64) (<-chan *entity.Notification, error)
 }
@@ -24,6 +24,33 @@ func (r *mutationResolver) ClearNotifications(ctx context.Context) (bool, error)
 	return err == nil, err
 }
 
+// MarkNotificationsRead is the resolver for the markNotificationsRead field.
+func (r *mutationResolver) MarkNotificationsRead(ctx context.Context, ids []int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Notification.MarkRead(sess, ids)
+
+	return err == nil, err
+}
+
+// SetNotificationPreference is the resolver for the setNotificationPreference field.
+func (r *mutationResolver) SetNotificationPreference(ctx context.Context, kind apimodel.NotificationKind, inApp *bool, email *apimodel.NotificationEmailMode) (*apimodel.NotificationPreference, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	preference, err := r.Notification.SetPreference(sess, dto.NotificationPreferenceSetFromRest(kind, inApp, email))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NotificationPreferenceToRest(preference), nil
+}
+
 // ShowNotifications is the resolver for the showNotifications field.
 func (r *queryResolver) ShowNotifications(ctx context.Context, p *apimodel.Pagination) ([]*apimodel.Notification, error) {
 	sess := entity.GetSession(ctx)
@@ -38,3 +65,56 @@ func (r *queryResolver) ShowNotifications(ctx context.Context, p *apimodel.Pagin
 
 	return dto.NotificationsToRest(notifications), nil
 }
+
+// CountUnreadNotifications is the resolver for the countUnreadNotifications field.
+func (r *queryResolver) CountUnreadNotifications(ctx context.Context) (int64, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return 0, domain.ErrNotAuthorized
+	}
+
+	return r.Notification.CountUnread(sess)
+}
+
+// ShowNotificationPreferences is the resolver for the showNotificationPreferences field.
+func (r *queryResolver) ShowNotificationPreferences(ctx context.Context) ([]*apimodel.NotificationPreference, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	preferences, err := r.Notification.Preferences(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NotificationPreferencesToRest(preferences), nil
+}
+
+// NotificationReceived is the resolver for the notificationReceived field.
+func (r *subscriptionResolver) NotificationReceived(ctx context.Context) (<-chan *apimodel.Notification, error) {
+	// The stream lasts as long as the subscription rather than the connection
+	sess := entity.GetSession(ctx)
+	sess.Ctx = ctx
+
+	notifications, err := r.Live.NotificationReceived(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan *apimodel.Notification)
+
+	go func() {
+		defer close(res)
+
+		for notification := range notifications {
+			select {
+			case res <- dto.NotificationToRest(notification):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return res, nil
+}
@@ -0,0 +1,43 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// RetryOutboxMessage is the resolver for the retryOutboxMessage field.
+func (r *mutationResolver) RetryOutboxMessage(ctx context.Context, id int64) (*apimodel.OutboxMessage, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	message, err := r.Outbox.Retry(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.OutboxMessageToRest(message), nil
+}
+
+// ShowOutbox is the resolver for the showOutbox field.
+func (r *queryResolver) ShowOutbox(ctx context.Context, f *apimodel.OutboxFilters, p *apimodel.Pagination) ([]*apimodel.OutboxMessage, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	messages, err := r.Outbox.All(sess, dto.OutboxFiltersFromRest(f), dto.PaginationFromRest(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.OutboxMessagesToRest(messages), nil
+}
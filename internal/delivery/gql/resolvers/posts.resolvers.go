@@ -7,6 +7,7 @@ package resolvers
 import (
 	"context"
 	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/delivery/gql"
 	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 	"simplestforum/internal/dto"
@@ -54,6 +55,33 @@ func (r *mutationResolver) DeletePost(ctx context.Context, id int64) (bool, erro
 	return err == nil, err
 }
 
+// ApprovePost is the resolver for the approvePost field.
+func (r *mutationResolver) ApprovePost(ctx context.Context, id int64) (*apimodel.Post, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	post, err := r.Post.Approve(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostToRest(post), nil
+}
+
+// RejectPost is the resolver for the rejectPost field.
+func (r *mutationResolver) RejectPost(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Post.Reject(sess, id)
+
+	return err == nil, err
+}
+
 // ShowPost is the resolver for the showPost field.
 func (r *queryResolver) ShowPost(ctx context.Context, id int64) (*apimodel.Post, error) {
 	sess := entity.GetSession(ctx)
@@ -77,3 +105,51 @@ func (r *queryResolver) ShowPosts(ctx context.Context, f *apimodel.PostFilters,
 
 	return dto.PostsToRest(posts), nil
 }
+
+// ShowPendingPosts is the resolver for the showPendingPosts field.
+func (r *queryResolver) ShowPendingPosts(ctx context.Context, f *apimodel.PostFilters, p *apimodel.Pagination, s *apimodel.PostSort) ([]*apimodel.Post, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	posts, err := r.Post.AllPending(sess, dto.PostFiltersFromRest(f), dto.PaginationFromRest(p), dto.PostSortFromRest(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostsToRest(posts), nil
+}
+
+// PostAdded is the resolver for the postAdded field.
+func (r *subscriptionResolver) PostAdded(ctx context.Context, topicID int64) (<-chan *apimodel.Post, error) {
+	// The stream lasts as long as the subscription rather than the connection
+	sess := entity.GetSession(ctx)
+	sess.Ctx = ctx
+
+	posts, err := r.Live.PostAdded(sess, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan *apimodel.Post)
+
+	go func() {
+		defer close(res)
+
+		for post := range posts {
+			select {
+			case res <- dto.PostToRest(post):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+// Subscription returns gql.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() gql.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,67 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// AddProfileField is the resolver for the addProfileField field.
+func (r *mutationResolver) AddProfileField(ctx context.Context, f apimodel.AddProfileFieldInput) (*apimodel.ProfileField, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	field, err := r.Profile.AddField(sess, dto.ProfileFieldAddFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ProfileFieldToRest(field), nil
+}
+
+// EditProfileField is the resolver for the editProfileField field.
+func (r *mutationResolver) EditProfileField(ctx context.Context, f apimodel.EditProfileFieldInput) (*apimodel.ProfileField, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	field, err := r.Profile.EditField(sess, dto.ProfileFieldEditFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ProfileFieldToRest(field), nil
+}
+
+// DeleteProfileField is the resolver for the deleteProfileField field.
+func (r *mutationResolver) DeleteProfileField(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Profile.DeleteField(sess, id)
+
+	return err == nil, err
+}
+
+// ShowProfileFields is the resolver for the showProfileFields field.
+func (r *queryResolver) ShowProfileFields(ctx context.Context) ([]*apimodel.ProfileField, error) {
+	sess := entity.GetSession(ctx)
+
+	fields, err := r.Profile.Fields(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ProfileFieldsToRest(fields), nil
+}
@@ -0,0 +1,77 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// AddRank is the resolver for the addRank field.
+func (r *mutationResolver) AddRank(ctx context.Context, f apimodel.AddRankInput) (*apimodel.Rank, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	rank, err := r.Rank.Add(sess, dto.RankAddFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.RankToRest(rank), nil
+}
+
+// EditRank is the resolver for the editRank field.
+func (r *mutationResolver) EditRank(ctx context.Context, f apimodel.EditRankInput) (*apimodel.Rank, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	rank, err := r.Rank.Edit(sess, dto.RankEditFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.RankToRest(rank), nil
+}
+
+// DeleteRank is the resolver for the deleteRank field.
+func (r *mutationResolver) DeleteRank(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Rank.Delete(sess, id)
+
+	return err == nil, err
+}
+
+// RecalculateRanks is the resolver for the recalculateRanks field.
+func (r *mutationResolver) RecalculateRanks(ctx context.Context) (int64, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return 0, domain.ErrNotAuthorized
+	}
+
+	return r.Rank.Recalculate(sess)
+}
+
+// ShowRanks is the resolver for the showRanks field.
+func (r *queryResolver) ShowRanks(ctx context.Context) ([]*apimodel.Rank, error) {
+	sess := entity.GetSession(ctx)
+
+	ranks, err := r.Rank.Ladder(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.RanksToRest(ranks), nil
+}
@@ -0,0 +1,121 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// ReportPost is the resolver for the reportPost field.
+func (r *mutationResolver) ReportPost(ctx context.Context, id int64, reason apimodel.ReportReason, text *string) (*apimodel.Report, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	report, err := r.Report.Add(sess, &entity.ReportAdd{
+		TargetType: entity.ReportTargetTypePost,
+		TargetID:   id,
+		Reason:     entity.ReportReason(reason),
+		Text:       text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReportToRest(report), nil
+}
+
+// ReportTopic is the resolver for the reportTopic field.
+func (r *mutationResolver) ReportTopic(ctx context.Context, id int64, reason apimodel.ReportReason, text *string) (*apimodel.Report, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	report, err := r.Report.Add(sess, &entity.ReportAdd{
+		TargetType: entity.ReportTargetTypeTopic,
+		TargetID:   id,
+		Reason:     entity.ReportReason(reason),
+		Text:       text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReportToRest(report), nil
+}
+
+// ReportUser is the resolver for the reportUser field.
+func (r *mutationResolver) ReportUser(ctx context.Context, id int64, reason apimodel.ReportReason, text *string) (*apimodel.Report, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	report, err := r.Report.Add(sess, &entity.ReportAdd{
+		TargetType: entity.ReportTargetTypeUser,
+		TargetID:   id,
+		Reason:     entity.ReportReason(reason),
+		Text:       text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReportToRest(report), nil
+}
+
+// AssignReport is the resolver for the assignReport field.
+func (r *mutationResolver) AssignReport(ctx context.Context, id int64, assigneeID *int64) (*apimodel.Report, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	report, err := r.Report.Assign(sess, &entity.ReportAssign{
+		ID:         id,
+		AssigneeID: assigneeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReportToRest(report), nil
+}
+
+// ResolveReport is the resolver for the resolveReport field.
+func (r *mutationResolver) ResolveReport(ctx context.Context, rArg apimodel.ResolveReportInput) (*apimodel.Report, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	report, err := r.Report.Resolve(sess, dto.ReportResolveFromRest(&rArg))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReportToRest(report), nil
+}
+
+// ShowReports is the resolver for the showReports field.
+func (r *queryResolver) ShowReports(ctx context.Context, f *apimodel.ReportFilters, p *apimodel.Pagination) ([]*apimodel.Report, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	reports, err := r.Report.All(sess, dto.ReportFiltersFromRest(f), dto.PaginationFromRest(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReportsToRest(reports), nil
+}
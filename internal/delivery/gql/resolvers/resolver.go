@@ -7,10 +7,18 @@ import (
 	"simplestforum/internal/delivery/gql"
 	"simplestforum/internal/delivery/gql/directives"
 	"simplestforum/internal/delivery/gql/middleware"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
 )
 
+// websocketKeepAlive is the interval of the pings keeping the idle websocket connections open.
+const websocketKeepAlive = 10 * time.Second
+
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
@@ -22,13 +30,25 @@ type Interactors struct {
 	Section      SectionInteractor
 	Post         PostInteractor
 	Notification NotificationInteractor
+	Watch        WatchInteractor
+	Report       ReportInteractor
+	Search       SearchInteractor
+	Profile      ProfileInteractor
+	Follow       FollowInteractor
+	Rank         RankInteractor
+	Badge        BadgeInteractor
+	Audit        AuditInteractor
+	Outbox       OutboxInteractor
+	Webhook      WebhookInteractor
+	Live         LiveInteractor
 }
 
 type Resolver = Interactors
 
-// NewGQLHandler sets up the GraphQL schema, resolvers and directives.
-func NewGQLHandler(interactors *Interactors) http.Handler {
-	srv := handler.NewDefaultServer(
+// NewGQLHandler sets up the GraphQL schema, resolvers and directives. The subscriptions are served over
+// a websocket, whose connections are authenticated by wsInit.
+func NewGQLHandler(interactors *Interactors, wsInit transport.WebsocketInitFunc) http.Handler {
+	srv := handler.New(
 		gql.NewExecutableSchema(
 			gql.Config{
 				Resolvers: interactors,
@@ -41,7 +61,30 @@ func NewGQLHandler(interactors *Interactors) http.Handler {
 		),
 	)
 
+	srv.AddTransport(transport.Websocket{
+		// The API is open to any origin, just like the plain requests
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: func(*http.Request) bool {
+				return true
+			},
+		},
+		InitFunc:              wsInit,
+		KeepAlivePingInterval: websocketKeepAlive,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New(1000))
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New(100),
+	})
+
 	srv.SetRecoverFunc(middleware.Recover)
+	srv.AroundOperations(middleware.IsolateEvents)
 	srv.AroundResponses(middleware.WrapResponse)
 	srv.AroundFields(middleware.CollectFields)
 
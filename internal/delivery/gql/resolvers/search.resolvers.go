@@ -0,0 +1,31 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"time"
+)
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, query string, sectionIds []int64, userIds []int64, dateFrom *time.Time, dateTo *time.Time, p *apimodel.Pagination) ([]*apimodel.SearchHit, error) {
+	sess := entity.GetSession(ctx)
+
+	hits, err := r.Resolver.Search.Search(sess, &entity.SearchQuery{
+		Query:      query,
+		SectionIDs: sectionIds,
+		UserIDs:    userIds,
+		DateFrom:   dateFrom,
+		DateTo:     dateTo,
+	}, dto.PaginationFromRest(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.SearchHitsToRest(hits), nil
+}
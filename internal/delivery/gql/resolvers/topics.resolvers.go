@@ -54,6 +54,97 @@ func (r *mutationResolver) DeleteTopic(ctx context.Context, id int64) (bool, err
 	return err == nil, err
 }
 
+// MarkTopicRead is the resolver for the markTopicRead field.
+func (r *mutationResolver) MarkTopicRead(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Topic.MarkTopicRead(sess, id)
+
+	return err == nil, err
+}
+
+// MarkSectionRead is the resolver for the markSectionRead field.
+func (r *mutationResolver) MarkSectionRead(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Topic.MarkSectionRead(sess, id)
+
+	return err == nil, err
+}
+
+// MergeTopics is the resolver for the mergeTopics field.
+func (r *mutationResolver) MergeTopics(ctx context.Context, source int64, target int64) (*apimodel.Topic, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	topic, err := r.Topic.Merge(sess, &entity.TopicMerge{
+		SourceID: source,
+		TargetID: target,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TopicToRest(topic), nil
+}
+
+// SplitTopic is the resolver for the splitTopic field.
+func (r *mutationResolver) SplitTopic(ctx context.Context, postIds []int64, newName string, sectionID int64) (*apimodel.Topic, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	topic, err := r.Topic.Split(sess, &entity.TopicSplit{
+		PostIDs:   postIds,
+		Name:      newName,
+		SectionID: sectionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TopicToRest(topic), nil
+}
+
+// AcceptAnswer is the resolver for the acceptAnswer field.
+func (r *mutationResolver) AcceptAnswer(ctx context.Context, topicID int64, postID int64) (*apimodel.Topic, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	topic, err := r.Topic.AcceptAnswer(sess, topicID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TopicToRest(topic), nil
+}
+
+// UnacceptAnswer is the resolver for the unacceptAnswer field.
+func (r *mutationResolver) UnacceptAnswer(ctx context.Context, topicID int64) (*apimodel.Topic, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	topic, err := r.Topic.UnacceptAnswer(sess, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TopicToRest(topic), nil
+}
+
 // ShowTopic is the resolver for the showTopic field.
 func (r *queryResolver) ShowTopic(ctx context.Context, id int64) (*apimodel.Topic, error) {
 	sess := entity.GetSession(ctx)
@@ -77,3 +168,43 @@ func (r *queryResolver) ShowTopics(ctx context.Context, f *apimodel.TopicFilters
 
 	return dto.TopicsToRest(topics), nil
 }
+
+// SuggestSimilarTopics is the resolver for the suggestSimilarTopics field.
+func (r *queryResolver) SuggestSimilarTopics(ctx context.Context, name string, sectionID *int64) ([]*apimodel.SimilarTopic, error) {
+	sess := entity.GetSession(ctx)
+
+	topics, err := r.Topic.SuggestSimilar(sess, name, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.SimilarTopicsToRest(topics), nil
+}
+
+// TopicAdded is the resolver for the topicAdded field.
+func (r *subscriptionResolver) TopicAdded(ctx context.Context, sectionID int64) (<-chan *apimodel.Topic, error) {
+	// The stream lasts as long as the subscription rather than the connection
+	sess := entity.GetSession(ctx)
+	sess.Ctx = ctx
+
+	topics, err := r.Live.TopicAdded(sess, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan *apimodel.Topic)
+
+	go func() {
+		defer close(res)
+
+		for topic := range topics {
+			select {
+			case res <- dto.TopicToRest(topic):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return res, nil
+}
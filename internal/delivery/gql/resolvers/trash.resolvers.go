@@ -0,0 +1,91 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"time"
+)
+
+// RestoreTopic is the resolver for the restoreTopic field.
+func (r *mutationResolver) RestoreTopic(ctx context.Context, id int64) (*apimodel.Topic, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	topic, err := r.Topic.Restore(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TopicToRest(topic), nil
+}
+
+// RestorePost is the resolver for the restorePost field.
+func (r *mutationResolver) RestorePost(ctx context.Context, id int64) (*apimodel.Post, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	post, err := r.Post.Restore(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostToRest(post), nil
+}
+
+// PurgeTrash is the resolver for the purgeTrash field.
+func (r *mutationResolver) PurgeTrash(ctx context.Context, before time.Time) (*apimodel.TrashPurgeResult, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	res, err := r.Topic.Purge(sess, &entity.TrashPurge{
+		DeletedBefore: before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TrashPurgeResultToRest(res), nil
+}
+
+// ShowDeletedTopics is the resolver for the showDeletedTopics field.
+func (r *queryResolver) ShowDeletedTopics(ctx context.Context, f *apimodel.TopicFilters, p *apimodel.Pagination, s *apimodel.TopicSort) ([]*apimodel.Topic, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	topics, err := r.Topic.AllDeleted(sess, dto.TopicFiltersFromRest(f), dto.PaginationFromRest(p), dto.TopicSortFromRest(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TopicsToRest(topics), nil
+}
+
+// ShowDeletedPosts is the resolver for the showDeletedPosts field.
+func (r *queryResolver) ShowDeletedPosts(ctx context.Context, f *apimodel.PostFilters, p *apimodel.Pagination, s *apimodel.PostSort) ([]*apimodel.Post, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	posts, err := r.Post.AllDeleted(sess, dto.PostFiltersFromRest(f), dto.PaginationFromRest(p), dto.PostSortFromRest(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostsToRest(posts), nil
+}
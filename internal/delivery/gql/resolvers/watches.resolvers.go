@@ -0,0 +1,59 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// WatchTopic is the resolver for the watchTopic field.
+func (r *mutationResolver) WatchTopic(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Watch.WatchTopic(sess, id)
+
+	return err == nil, err
+}
+
+// UnwatchTopic is the resolver for the unwatchTopic field.
+func (r *mutationResolver) UnwatchTopic(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Watch.UnwatchTopic(sess, id)
+
+	return err == nil, err
+}
+
+// WatchSection is the resolver for the watchSection field.
+func (r *mutationResolver) WatchSection(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Watch.WatchSection(sess, id)
+
+	return err == nil, err
+}
+
+// UnwatchSection is the resolver for the unwatchSection field.
+func (r *mutationResolver) UnwatchSection(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Watch.UnwatchSection(sess, id)
+
+	return err == nil, err
+}
@@ -0,0 +1,100 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// AddWebhook is the resolver for the addWebhook field.
+func (r *mutationResolver) AddWebhook(ctx context.Context, f apimodel.AddWebhookInput) (*apimodel.Webhook, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	webhook, err := r.Webhook.Add(sess, dto.WebhookAddFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.WebhookToRest(webhook), nil
+}
+
+// EditWebhook is the resolver for the editWebhook field.
+func (r *mutationResolver) EditWebhook(ctx context.Context, f apimodel.EditWebhookInput) (*apimodel.Webhook, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	webhook, err := r.Webhook.Edit(sess, dto.WebhookEditFromRest(&f))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.WebhookToRest(webhook), nil
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id int64) (bool, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return false, domain.ErrNotAuthorized
+	}
+
+	err := r.Webhook.Delete(sess, id)
+
+	return err == nil, err
+}
+
+// RedeliverWebhook is the resolver for the redeliverWebhook field.
+func (r *mutationResolver) RedeliverWebhook(ctx context.Context, id int64) (*apimodel.WebhookDelivery, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	delivery, err := r.Webhook.Redeliver(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.WebhookDeliveryToRest(delivery), nil
+}
+
+// ShowWebhooks is the resolver for the showWebhooks field.
+func (r *queryResolver) ShowWebhooks(ctx context.Context) ([]*apimodel.Webhook, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	webhooks, err := r.Webhook.All(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.WebhooksToRest(webhooks), nil
+}
+
+// ShowWebhookDeliveries is the resolver for the showWebhookDeliveries field.
+func (r *queryResolver) ShowWebhookDeliveries(ctx context.Context, f *apimodel.WebhookDeliveryFilters, p *apimodel.Pagination) ([]*apimodel.WebhookDelivery, error) {
+	sess := entity.GetSession(ctx)
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	deliveries, err := r.Webhook.Deliveries(sess, dto.WebhookDeliveryFiltersFromRest(f), dto.PaginationFromRest(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.WebhookDeliveriesToRest(deliveries), nil
+}
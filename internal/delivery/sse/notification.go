@@ -0,0 +1,166 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"simplestforum/internal/delivery"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"strconv"
+	"time"
+)
+
+const (
+	// notificationEvent is the name of the events carrying the Notifications.
+	notificationEvent = "notification"
+
+	// retryInterval is how long the clients wait before reconnecting once the stream is over.
+	retryInterval = 3 * time.Second
+)
+
+// NotificationInteractor represents the live update usecase methods streaming the Notifications.
+type NotificationInteractor interface {
+	NotificationReceived(entity.Session) (<-chan *entity.Notification, error)
+	NotificationReceivedAfter(entity.Session, int64) (<-chan *entity.Notification, error)
+}
+
+// NotificationHandler streams the new Notifications of the current User as Server-Sent Events, for the clients
+// which can't use the websocket subscriptions. The ID of every event is the number of the last Notification change
+// sent, so a client reconnecting with the Last-Event-ID header receives the Notifications it missed first, the
+// grouped ones merged since included. A comment is sent every heartbeat interval, if it's positive, so the idle
+// stream isn't closed by the proxies.
+type NotificationHandler struct {
+	interactor NotificationInteractor
+	heartbeat  time.Duration
+}
+
+// NewNotificationHandler instantiates a NotificationHandler.
+func NewNotificationHandler(interactor NotificationInteractor, heartbeat time.Duration) *NotificationHandler {
+	return &NotificationHandler{
+		interactor: interactor,
+		heartbeat:  heartbeat,
+	}
+}
+
+// ServeHTTP streams the Notifications until the client disconnects or the request context is done.
+func (h *NotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		return
+	}
+
+	// The stream lasts as long as the request
+	ctx := r.Context()
+	sess := entity.GetSession(ctx)
+	sess.Ctx = ctx
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		_, _ = w.Write(delivery.BuildErrorResponse(sess, domain.NewError(domain.ErrCodeInternal,
+			"Streaming is not supported"), true))
+
+		return
+	}
+
+	lastID, err := lastEventID(r)
+	if err != nil {
+		_, _ = w.Write(delivery.BuildErrorResponse(sess, err, true))
+
+		return
+	}
+
+	var notifications <-chan *entity.Notification
+
+	if lastID != nil {
+		notifications, err = h.interactor.NotificationReceivedAfter(sess, *lastID)
+	} else {
+		notifications, err = h.interactor.NotificationReceived(sess)
+	}
+
+	if err != nil {
+		_, _ = w.Write(delivery.BuildErrorResponse(sess, err, true))
+
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+
+	_, err = fmt.Fprintf(w, "retry: %d\n\n", retryInterval.Milliseconds())
+	if err != nil {
+		return
+	}
+
+	flusher.Flush()
+
+	// A nil channel never fires, so the heartbeat is disabled
+	var heartbeat <-chan time.Time
+
+	if h.heartbeat > 0 {
+		ticker := time.NewTicker(h.heartbeat)
+		defer ticker.Stop()
+
+		heartbeat = ticker.C
+	}
+
+	var id int64
+	if lastID != nil {
+		id = *lastID
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+
+			// The number of a change never goes back, but the Notifications may be received out of order
+			if notification.Seq > id {
+				id = notification.Seq
+			}
+
+			err = writeEvent(w, id, notificationEvent, dto.NotificationToRest(notification))
+		case <-heartbeat:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		}
+
+		if err != nil {
+			return
+		}
+
+		flusher.Flush()
+	}
+}
+
+// lastEventID returns the ID of the last event the client received, if it's resuming the stream.
+func lastEventID(r *http.Request) (*int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return nil, domain.NewError(domain.ErrCodeValidation, "Invalid Last-Event-ID %q", value)
+	}
+
+	return &id, nil
+}
+
+// writeEvent writes a single event with the payload encoded as JSON, which never spans several lines.
+func writeEvent(w http.ResponseWriter, id int64, event string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, data)
+
+	return err
+}
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"simplestforum/internal/domain/entity"
+	"time"
+)
+
+// badgesSessionID identifies the Sessions of the background jobs in the errors.
+const badgesSessionID = "badges-worker"
+
+// BadgeBackfiller represents the Badge usecase methods needed to grant the Badges earned.
+type BadgeBackfiller interface {
+	Backfill(entity.Session) (int64, error)
+}
+
+// BadgeWorker periodically evaluates the rules of every Badge, granting the ones which are earned by time
+// or were defined after the Users earned them.
+type BadgeWorker struct {
+	backfiller BadgeBackfiller
+	interval   time.Duration
+}
+
+// NewBadgeWorker instantiates a BadgeWorker.
+func NewBadgeWorker(backfiller BadgeBackfiller, interval time.Duration) *BadgeWorker {
+	return &BadgeWorker{
+		backfiller: backfiller,
+		interval:   interval,
+	}
+}
+
+// Run grants the Badges on every tick until the context is cancelled.
+func (w *BadgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce grants every Badge earned, logging the result.
+func (w *BadgeWorker) RunOnce(ctx context.Context) {
+	// Backfilling is an admin-only operation
+	sess := entity.Session{
+		Ctx:   ctx,
+		ID:    badgesSessionID,
+		Level: entity.UserLevelAdmin,
+	}
+
+	granted, err := w.backfiller.Backfill(sess)
+	if err != nil {
+		log.Printf("Error granting the badges: %v", err)
+
+		return
+	}
+
+	if granted > 0 {
+		log.Printf("Granted %d badges", granted)
+	}
+}
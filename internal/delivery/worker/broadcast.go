@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"simplestforum/internal/domain/entity"
+	"time"
+)
+
+// broadcastSessionID identifies the Sessions of the background jobs in the errors.
+const broadcastSessionID = "broadcast-worker"
+
+// BroadcastReceiver represents the event bus methods needed to receive the Events broadcast to every instance.
+type BroadcastReceiver interface {
+	ListenBroadcasts(context.Context) <-chan struct{}
+	ReceiveBroadcasts(entity.Session) (*entity.BroadcastReceiveResult, error)
+	PruneBroadcasts(entity.Session, time.Time) (int64, error)
+}
+
+// BroadcastWorker delivers the Events broadcast by every instance to the subscribers of this one. It wakes up once
+// notified of the new messages, and looks for them on every tick as well, in case a notification was lost.
+// The messages older than the retention period are pruned.
+type BroadcastWorker struct {
+	receiver  BroadcastReceiver
+	interval  time.Duration
+	retention time.Duration
+}
+
+// NewBroadcastWorker instantiates a BroadcastWorker.
+func NewBroadcastWorker(receiver BroadcastReceiver, interval, retention time.Duration) *BroadcastWorker {
+	return &BroadcastWorker{
+		receiver:  receiver,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Run receives the new messages whenever notified and on every tick until the context is cancelled.
+func (w *BroadcastWorker) Run(ctx context.Context) {
+	notified := w.receiver.ListenBroadcasts(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// The first round marks where this instance starts receiving
+	w.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notified:
+			w.RunOnce(ctx)
+		case <-ticker.C:
+			w.RunOnce(ctx)
+			w.prune(ctx)
+		}
+	}
+}
+
+// RunOnce delivers the messages broadcast since the last round, logging the failures.
+func (w *BroadcastWorker) RunOnce(ctx context.Context) {
+	sess := entity.Session{
+		Ctx: ctx,
+		ID:  broadcastSessionID,
+	}
+
+	result, err := w.receiver.ReceiveBroadcasts(sess)
+	if err != nil {
+		log.Printf("Error receiving the broadcast messages: %v", err)
+	}
+
+	if result == nil {
+		return
+	}
+
+	if result.Failed > 0 {
+		log.Printf("Failed to handle %d broadcast messages", result.Failed)
+	}
+
+	if result.Missed > 0 {
+		log.Printf("Missed %d broadcast messages, they were pruned or never committed", result.Missed)
+	}
+}
+
+// prune removes the messages older than the retention period.
+func (w *BroadcastWorker) prune(ctx context.Context) {
+	sess := entity.Session{
+		Ctx: ctx,
+		ID:  broadcastSessionID,
+	}
+
+	_, err := w.receiver.PruneBroadcasts(sess, time.Now().Add(-w.retention))
+	if err != nil {
+		log.Printf("Error pruning the broadcast messages: %v", err)
+	}
+}
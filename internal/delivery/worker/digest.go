@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"simplestforum/internal/domain/entity"
+	"time"
+)
+
+// digestSessionID identifies the Sessions of the background jobs in the errors.
+const digestSessionID = "digest-worker"
+
+// NotificationMailer represents the Notification usecase methods needed to send emails.
+type NotificationMailer interface {
+	SendPendingEmails(entity.Session, entity.NotificationEmailMode) error
+}
+
+// DigestWorker periodically sends the queued notification emails: immediate ones on every run,
+// and daily or weekly digests once the oldest pending notification of a User is old enough.
+type DigestWorker struct {
+	mailer   NotificationMailer
+	interval time.Duration
+}
+
+// NewDigestWorker instantiates a DigestWorker.
+func NewDigestWorker(mailer NotificationMailer, interval time.Duration) *DigestWorker {
+	return &DigestWorker{
+		mailer:   mailer,
+		interval: interval,
+	}
+}
+
+// Run sends the pending emails on every tick until the context is cancelled.
+func (w *DigestWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce sends every email which is due, logging the errors.
+func (w *DigestWorker) RunOnce(ctx context.Context) {
+	modes := []entity.NotificationEmailMode{
+		entity.NotificationEmailModeImmediate,
+		entity.NotificationEmailModeDaily,
+		entity.NotificationEmailModeWeekly,
+	}
+
+	for _, mode := range modes {
+		sess := entity.Session{
+			Ctx: ctx,
+			ID:  digestSessionID,
+		}
+
+		err := w.mailer.SendPendingEmails(sess, mode)
+		if err != nil {
+			log.Printf("Error sending %s notification emails: %v", mode, err)
+		}
+	}
+}
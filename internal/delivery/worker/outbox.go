@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"simplestforum/internal/domain/entity"
+	"time"
+)
+
+// outboxSessionID identifies the Sessions of the background jobs in the errors.
+const outboxSessionID = "outbox-worker"
+
+// OutboxDispatcher represents the outbox usecase methods needed to deliver the stored Events.
+type OutboxDispatcher interface {
+	Dispatch(entity.Session) (*entity.OutboxDispatchResult, error)
+}
+
+// OutboxWorker periodically delivers the Events stored in the outbox to their subscribers.
+type OutboxWorker struct {
+	dispatcher OutboxDispatcher
+	interval   time.Duration
+}
+
+// NewOutboxWorker instantiates an OutboxWorker.
+func NewOutboxWorker(dispatcher OutboxDispatcher, interval time.Duration) *OutboxWorker {
+	return &OutboxWorker{
+		dispatcher: dispatcher,
+		interval:   interval,
+	}
+}
+
+// Run delivers the due messages on every tick until the context is cancelled.
+func (w *OutboxWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce delivers the due messages batch by batch until none are left, logging the failures.
+func (w *OutboxWorker) RunOnce(ctx context.Context) {
+	// Dispatching is an admin-only operation
+	sess := entity.Session{
+		Ctx:   ctx,
+		ID:    outboxSessionID,
+		Level: entity.UserLevelAdmin,
+	}
+
+	for ctx.Err() == nil {
+		result, err := w.dispatcher.Dispatch(sess)
+		if err != nil {
+			log.Printf("Error dispatching the outbox: %v", err)
+
+			return
+		}
+
+		if result.Retried > 0 || result.Dead > 0 {
+			log.Printf("Outbox deliveries failed: %d to be retried, %d dead-lettered", result.Retried, result.Dead)
+		}
+
+		if result.Delivered+result.Retried+result.Dead == 0 {
+			return
+		}
+	}
+}
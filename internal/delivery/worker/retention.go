@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"simplestforum/internal/domain/entity"
+	"time"
+)
+
+// retentionSessionID identifies the Sessions of the background jobs in the errors.
+const retentionSessionID = "retention-worker"
+
+// TrashPurger represents the Topic usecase methods needed to empty the trash bin.
+type TrashPurger interface {
+	Purge(entity.Session, *entity.TrashPurge) (*entity.TrashPurgeResult, error)
+}
+
+// RetentionWorker periodically removes the Topics and Posts which were deleted longer ago than the retention period.
+type RetentionWorker struct {
+	purger    TrashPurger
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewRetentionWorker instantiates a RetentionWorker.
+func NewRetentionWorker(purger TrashPurger, retention, interval time.Duration) *RetentionWorker {
+	return &RetentionWorker{
+		purger:    purger,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run purges the trash bin on every tick until the context is cancelled.
+func (w *RetentionWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce removes everything deleted before the retention period, logging the result.
+func (w *RetentionWorker) RunOnce(ctx context.Context) {
+	// Purging is an admin-only operation
+	sess := entity.Session{
+		Ctx:   ctx,
+		ID:    retentionSessionID,
+		Level: entity.UserLevelAdmin,
+	}
+
+	res, err := w.purger.Purge(sess, &entity.TrashPurge{
+		DeletedBefore: time.Now().Add(-w.retention),
+	})
+	if err != nil {
+		log.Printf("Error purging the trash bin: %v", err)
+
+		return
+	}
+
+	if res.Topics > 0 || res.Posts > 0 {
+		log.Printf("Purged %d topics and %d posts from the trash bin", res.Topics, res.Posts)
+	}
+}
@@ -0,0 +1,170 @@
+package entity
+
+import "time"
+
+// AuditAction represents a privileged action recorded in the audit log.
+type AuditAction string
+
+// AuditTargetType represents the kind of entity a privileged action is taken on.
+type AuditTargetType string
+
+const (
+	AuditActionPostEdit      AuditAction = "POST_EDIT"
+	AuditActionPostDelete    AuditAction = "POST_DELETE"
+	AuditActionPostRestore   AuditAction = "POST_RESTORE"
+	AuditActionPostApprove   AuditAction = "POST_APPROVE"
+	AuditActionPostReject    AuditAction = "POST_REJECT"
+	AuditActionTopicEdit     AuditAction = "TOPIC_EDIT"
+	AuditActionTopicDelete   AuditAction = "TOPIC_DELETE"
+	AuditActionTopicRestore  AuditAction = "TOPIC_RESTORE"
+	AuditActionTopicMerge    AuditAction = "TOPIC_MERGE"
+	AuditActionTopicSplit    AuditAction = "TOPIC_SPLIT"
+	AuditActionUserEdit      AuditAction = "USER_EDIT"
+	AuditActionUserDelete    AuditAction = "USER_DELETE"
+	AuditActionSectionAdd    AuditAction = "SECTION_ADD"
+	AuditActionSectionEdit   AuditAction = "SECTION_EDIT"
+	AuditActionSectionDelete AuditAction = "SECTION_DELETE"
+)
+
+const (
+	AuditTargetTypePost    AuditTargetType = "POST"
+	AuditTargetTypeTopic   AuditTargetType = "TOPIC"
+	AuditTargetTypeUser    AuditTargetType = "USER"
+	AuditTargetTypeSection AuditTargetType = "SECTION"
+)
+
+// AuditState is the state of the target of a privileged action, keyed by the names of the fields in the API.
+type AuditState map[string]interface{}
+
+// Auditable is implemented by the entities privileged actions are taken on.
+type Auditable interface {
+	AuditTarget() (AuditTargetType, int64)
+}
+
+// AuditEntry is a general structure representing a privileged action recorded in the audit log.
+// Before and After are JSON documents, nil if the target wasn't there before the action, as when it's added
+// or restored, or isn't after it, as when it's deleted.
+type AuditEntry struct {
+	ID         int64
+	ActorID    int64
+	Actor      *User
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetID   int64
+	Before     *string
+	After      *string
+	SessionID  string
+	CreatedAt  time.Time
+}
+
+// AuditEntryAdd is a structure used to record a privileged action, the actor and the session are set by the service.
+type AuditEntryAdd struct {
+	ActorID    int64
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetID   int64
+	Before     AuditState
+	After      AuditState
+	SessionID  string
+}
+
+// AuditFilters is a structure used to select the entries of the audit log.
+type AuditFilters struct {
+	ActorIDs    []int64
+	Actions     []AuditAction
+	TargetTypes []AuditTargetType
+	TargetIDs   []int64
+	SessionID   *string
+	From        *time.Time
+	To          *time.Time
+}
+
+// NewAuditEntry returns the entry recording the action taken on the target, which was in the before state
+// and is in the after state now.
+func NewAuditEntry(action AuditAction, target Auditable, before, after AuditState) *AuditEntryAdd {
+	targetType, targetID := target.AuditTarget()
+
+	return &AuditEntryAdd{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     before,
+		After:      after,
+	}
+}
+
+// AuditEntriesEntityIDs returns the IDs of the actors of the entries.
+func AuditEntriesEntityIDs(entries []*AuditEntry) []int64 {
+	actorIDs := make([]int64, len(entries))
+
+	for i, entry := range entries {
+		actorIDs[i] = entry.ActorID
+	}
+
+	return actorIDs
+}
+
+// AuditTarget returns the type and the ID of the Post.
+func (p *Post) AuditTarget() (AuditTargetType, int64) {
+	return AuditTargetTypePost, p.ID
+}
+
+// AuditState returns the state of the Post recorded in the audit log.
+func (p *Post) AuditState() AuditState {
+	return AuditState{
+		"text":       p.Text,
+		"status":     p.Status,
+		"wiki":       p.Wiki,
+		"user_id":    p.UserID,
+		"topic_id":   p.TopicID,
+		"deleted_at": p.DeletedAt,
+	}
+}
+
+// AuditTarget returns the type and the ID of the Topic.
+func (t *Topic) AuditTarget() (AuditTargetType, int64) {
+	return AuditTargetTypeTopic, t.ID
+}
+
+// AuditState returns the state of the Topic recorded in the audit log.
+func (t *Topic) AuditState() AuditState {
+	return AuditState{
+		"name":              t.Name,
+		"section_id":        t.SectionID,
+		"user_id":           t.UserID,
+		"count_posts":       t.CountPosts,
+		"redirect_topic_id": t.RedirectTopicID,
+		"deleted_at":        t.DeletedAt,
+	}
+}
+
+// AuditTarget returns the type and the ID of the User.
+func (u *User) AuditTarget() (AuditTargetType, int64) {
+	return AuditTargetTypeUser, u.ID
+}
+
+// AuditState returns the state of the User recorded in the audit log, the personal info is left out.
+func (u *User) AuditState() AuditState {
+	return AuditState{
+		"nickname":     u.Nickname,
+		"rank":         u.Rank,
+		"level":        u.Level,
+		"restriction":  u.Restriction,
+		"count_topics": u.CountTopics,
+		"count_posts":  u.CountPosts,
+	}
+}
+
+// AuditTarget returns the type and the ID of the Section.
+func (s *Section) AuditTarget() (AuditTargetType, int64) {
+	return AuditTargetTypeSection, s.ID
+}
+
+// AuditState returns the state of the Section recorded in the audit log.
+func (s *Section) AuditState() AuditState {
+	return AuditState{
+		"name":        s.Name,
+		"description": s.Description,
+		"mode":        s.Mode,
+	}
+}
@@ -0,0 +1,90 @@
+package entity
+
+import "time"
+
+// BadgeRule represents the kind of the achievement a Badge is granted for.
+type BadgeRule string
+
+const (
+	BadgeRulePosts          BadgeRule = "POSTS"
+	BadgeRuleTopics         BadgeRule = "TOPICS"
+	BadgeRuleFollowers      BadgeRule = "FOLLOWERS"
+	BadgeRuleMembershipDays BadgeRule = "MEMBERSHIP_DAYS"
+)
+
+// BadgeRules lists every known BadgeRule.
+var BadgeRules = []BadgeRule{
+	BadgeRulePosts,
+	BadgeRuleTopics,
+	BadgeRuleFollowers,
+	BadgeRuleMembershipDays,
+}
+
+// Badge is a general structure representing an achievement defined by the admins.
+// A User is granted the Badge once the value counted by the Rule reaches the Threshold, and keeps it afterwards.
+type Badge struct {
+	ID          int64
+	Name        string
+	Title       string
+	Description string
+	Rule        BadgeRule
+	Threshold   int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BadgeAdd is a structure used to define a new Badge.
+type BadgeAdd struct {
+	Name        string
+	Title       string
+	Description string
+	Rule        BadgeRule
+	Threshold   int64
+}
+
+// BadgeEdit is a structure used to edit an existing Badge. The rule is fixed, as the Badges granted depend on it.
+type BadgeEdit struct {
+	ID          int64
+	Title       *string
+	Description *string
+	Threshold   *int64
+}
+
+// UserBadge is a Badge granted to a User.
+type UserBadge struct {
+	UserID    int64
+	BadgeID   int64
+	GrantedAt time.Time
+
+	Badge *Badge
+}
+
+// BadgeGrant is a structure used to grant the Badges earned by the Users.
+// Only the Badges of the given Rules are checked, or all of them if none are given,
+// and only for the given Users, or for everyone if none are given.
+type BadgeGrant struct {
+	Rules   []BadgeRule
+	UserIDs []int64
+}
+
+// Valid checks if the rule is known.
+func (r BadgeRule) Valid() bool {
+	for _, rule := range BadgeRules {
+		if rule == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BadgesMap returns an id => Badge map extracted out of badges.
+func BadgesMap(badges []*Badge) map[int64]*Badge {
+	badgesMap := make(map[int64]*Badge, len(badges))
+
+	for _, badge := range badges {
+		badgesMap[badge.ID] = badge
+	}
+
+	return badgesMap
+}
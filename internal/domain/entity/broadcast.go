@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// BroadcastMessage is an Event delivered to a subscriber on every instance of the forum, this one included.
+// The messages are numbered in the order they are published, so an instance which missed some catches up on them.
+type BroadcastMessage struct {
+	Seq        int64
+	Subscriber string
+	EventType  EventType
+	Payload    string
+	SessionID  string
+	CreatedAt  time.Time
+}
+
+type BroadcastMessageAdd struct {
+	Subscriber string
+	EventType  EventType
+	Payload    string
+	SessionID  string
+}
+
+// BroadcastReceiveResult sums up a round of the broadcast messages received by an instance. Missed counts the numbers
+// skipped, which belong to the messages pruned before they were received or never committed.
+type BroadcastReceiveResult struct {
+	Received int64
+	Failed   int64
+	Missed   int64
+}
+
+// Session recreates the Session which published the Event, to track the errors of the subscriber.
+func (m *BroadcastMessage) Session(ctx context.Context) Session {
+	return Session{
+		Ctx: ctx,
+		ID:  m.SessionID,
+	}
+}
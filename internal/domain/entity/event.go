@@ -0,0 +1,588 @@
+package entity
+
+import "fmt"
+
+// NotificationEvent is anything which happened on the forum and should be reported to the Users involved.
+type NotificationEvent interface {
+	Event
+
+	Notifications() []*NotificationAdd
+}
+
+// UserRegisteredEvent happens when a new User signs up.
+type UserRegisteredEvent struct {
+	User *User
+}
+
+// EventType implements Event.
+func (e UserRegisteredEvent) EventType() EventType {
+	return EventTypeUserRegistered
+}
+
+// Notifications welcomes the new User.
+func (e UserRegisteredEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID: e.User.ID,
+		Kind:   NotificationKindWelcome,
+		Text:   "Welcome to the forum!",
+	}}
+}
+
+// UserFollowedEvent happens when a User follows another one.
+type UserFollowedEvent struct {
+	UserID         int64
+	FollowedUserID int64
+}
+
+// EventType implements Event.
+func (e UserFollowedEvent) EventType() EventType {
+	return EventTypeUserFollowed
+}
+
+// RankAchievedEvent happens when a User reaches a new rank.
+type RankAchievedEvent struct {
+	UserID int64
+	Rank   *Rank
+}
+
+// EventType implements Event.
+func (e RankAchievedEvent) EventType() EventType {
+	return EventTypeRankAchieved
+}
+
+// Notifications congratulates the User.
+func (e RankAchievedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID: e.UserID,
+		Kind:   NotificationKindRankAchieved,
+		Text:   fmt.Sprintf("You achieved the rank %s, congratulations!", e.Rank.Name()),
+	}}
+}
+
+// BadgeGrantedEvent happens when a User is granted a Badge.
+type BadgeGrantedEvent struct {
+	UserID int64
+	Badge  *Badge
+}
+
+// EventType implements Event.
+func (e BadgeGrantedEvent) EventType() EventType {
+	return EventTypeBadgeGranted
+}
+
+// Notifications congratulates the User.
+func (e BadgeGrantedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID: e.UserID,
+		Kind:   NotificationKindBadgeGranted,
+		Text:   fmt.Sprintf("You were granted the badge %s, congratulations!", e.Badge.Title),
+	}}
+}
+
+// PostCreatedEvent happens when a new Post is added. Post must contain its Topic and User.
+type PostCreatedEvent struct {
+	Post *Post
+}
+
+// EventType implements Event.
+func (e PostCreatedEvent) EventType() EventType {
+	return EventTypePostCreated
+}
+
+// WatchersNotification returns the template for the Notifications sent to the watchers of the Topic.
+// Notifications about the same Topic are grouped until read.
+func (e PostCreatedEvent) WatchersNotification() *WatchersNotificationAdd {
+	var (
+		groupKey  = fmt.Sprintf("%s:%d", NotificationKindNewPost, e.Post.TopicID)
+		groupText = e.Post.Topic.Name
+	)
+
+	return &WatchersNotificationAdd{
+		TopicID:   e.Post.TopicID,
+		SectionID: e.Post.Topic.SectionID,
+		AuthorID:  e.Post.UserID,
+		Notification: &NotificationAdd{
+			Kind:      NotificationKindNewPost,
+			ActorID:   &e.Post.UserID,
+			Text:      fmt.Sprintf("New post #%d in topic %s by %s", e.Post.ID, e.Post.Topic.Name, e.Post.User.Nickname),
+			GroupKey:  &groupKey,
+			GroupText: &groupText,
+			NotificationTarget: NotificationTarget{
+				PostID:    &e.Post.ID,
+				TopicID:   &e.Post.TopicID,
+				SectionID: &e.Post.Topic.SectionID,
+			},
+		},
+	}
+}
+
+// PostMovedEvent happens when a Post is moved to another Topic. Post must contain its new Topic.
+type PostMovedEvent struct {
+	ActorID int64
+	Post    *Post
+	From    *Topic
+}
+
+// EventType implements Event.
+func (e PostMovedEvent) EventType() EventType {
+	return EventTypePostMoved
+}
+
+// Notifications lets the author know where the Post is now.
+func (e PostMovedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Post.UserID,
+		Kind:    NotificationKindPostMoved,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your post #%d was moved from topic %s to topic %s", e.Post.ID, e.From.Name, e.Post.Topic.Name),
+		NotificationTarget: NotificationTarget{
+			PostID:  &e.Post.ID,
+			TopicID: &e.Post.TopicID,
+		},
+	}}
+}
+
+// PostReassignedEvent happens when a Post is assigned to another User. Post must contain its new User.
+type PostReassignedEvent struct {
+	ActorID int64
+	Post    *Post
+	From    *User
+}
+
+// EventType implements Event.
+func (e PostReassignedEvent) EventType() EventType {
+	return EventTypePostReassigned
+}
+
+// Notifications lets both the previous and the new author know.
+func (e PostReassignedEvent) Notifications() []*NotificationAdd {
+	target := NotificationTarget{
+		PostID:  &e.Post.ID,
+		TopicID: &e.Post.TopicID,
+	}
+
+	return []*NotificationAdd{
+		{
+			UserID:             e.From.ID,
+			Kind:               NotificationKindPostReassigned,
+			ActorID:            &e.ActorID,
+			Text:               fmt.Sprintf("Your post #%d was assigned to user %s", e.Post.ID, e.Post.User.Nickname),
+			NotificationTarget: target,
+		},
+		{
+			UserID:             e.Post.UserID,
+			Kind:               NotificationKindPostReassigned,
+			ActorID:            &e.ActorID,
+			Text:               fmt.Sprintf("Post #%d was assigned from user %s to you", e.Post.ID, e.From.Nickname),
+			NotificationTarget: target,
+		},
+	}
+}
+
+// PostRemovedEvent happens when a Post is deleted. Spam is set if the Post was reported and removed as spam.
+type PostRemovedEvent struct {
+	ActorID int64
+	Post    *Post
+	Spam    bool
+}
+
+// EventType implements Event.
+func (e PostRemovedEvent) EventType() EventType {
+	return EventTypePostRemoved
+}
+
+// Notifications lets the author know.
+func (e PostRemovedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Post.UserID,
+		Kind:    NotificationKindPostRemoved,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your post #%d was removed", e.Post.ID),
+		NotificationTarget: NotificationTarget{
+			PostID:  &e.Post.ID,
+			TopicID: &e.Post.TopicID,
+		},
+	}}
+}
+
+// PostRestoredEvent happens when a deleted Post is brought back.
+type PostRestoredEvent struct {
+	ActorID int64
+	Post    *Post
+}
+
+// EventType implements Event.
+func (e PostRestoredEvent) EventType() EventType {
+	return EventTypePostRestored
+}
+
+// Notifications lets the author know.
+func (e PostRestoredEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Post.UserID,
+		Kind:    NotificationKindPostRestored,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your post #%d was restored", e.Post.ID),
+		NotificationTarget: NotificationTarget{
+			PostID:  &e.Post.ID,
+			TopicID: &e.Post.TopicID,
+		},
+	}}
+}
+
+// PostApprovedEvent happens when a pending Post is published by a moderator.
+type PostApprovedEvent struct {
+	ActorID int64
+	Post    *Post
+}
+
+// EventType implements Event.
+func (e PostApprovedEvent) EventType() EventType {
+	return EventTypePostApproved
+}
+
+// Notifications lets the author know.
+func (e PostApprovedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Post.UserID,
+		Kind:    NotificationKindPostApproved,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your post #%d was approved", e.Post.ID),
+		NotificationTarget: NotificationTarget{
+			PostID:  &e.Post.ID,
+			TopicID: &e.Post.TopicID,
+		},
+	}}
+}
+
+// PostRejectedEvent happens when a pending Post is rejected by a moderator.
+type PostRejectedEvent struct {
+	ActorID int64
+	Post    *Post
+}
+
+// EventType implements Event.
+func (e PostRejectedEvent) EventType() EventType {
+	return EventTypePostRejected
+}
+
+// Notifications lets the author know.
+func (e PostRejectedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Post.UserID,
+		Kind:    NotificationKindPostRejected,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your post #%d was rejected", e.Post.ID),
+		NotificationTarget: NotificationTarget{
+			PostID:  &e.Post.ID,
+			TopicID: &e.Post.TopicID,
+		},
+	}}
+}
+
+// AnswerAcceptedEvent happens when a Post is accepted as the answer to the question asked in its Topic.
+type AnswerAcceptedEvent struct {
+	ActorID int64
+	Topic   *Topic
+	Post    *Post
+}
+
+// EventType implements Event.
+func (e AnswerAcceptedEvent) EventType() EventType {
+	return EventTypeAnswerAccepted
+}
+
+// Notifications lets the author of the answer know, unless they accepted it themselves.
+func (e AnswerAcceptedEvent) Notifications() []*NotificationAdd {
+	if e.Post.UserID == e.ActorID {
+		return nil
+	}
+
+	return []*NotificationAdd{{
+		UserID:  e.Post.UserID,
+		Kind:    NotificationKindAnswerAccepted,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your post #%d was accepted as the answer in topic %s", e.Post.ID, e.Topic.Name),
+		NotificationTarget: NotificationTarget{
+			PostID:  &e.Post.ID,
+			TopicID: &e.Topic.ID,
+		},
+	}}
+}
+
+// TopicCreatedEvent happens when a new Topic is added.
+type TopicCreatedEvent struct {
+	Topic *Topic
+}
+
+// EventType implements Event.
+func (e TopicCreatedEvent) EventType() EventType {
+	return EventTypeTopicCreated
+}
+
+// TopicMovedEvent happens when a Topic is moved to another Section. Topic must contain its new Section.
+type TopicMovedEvent struct {
+	ActorID int64
+	Topic   *Topic
+	From    *Section
+}
+
+// EventType implements Event.
+func (e TopicMovedEvent) EventType() EventType {
+	return EventTypeTopicMoved
+}
+
+// Notifications lets the author know where the Topic is now.
+func (e TopicMovedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Topic.UserID,
+		Kind:    NotificationKindTopicMoved,
+		ActorID: &e.ActorID,
+		Text: fmt.Sprintf("Your topic %s was moved from section %s to section %s", e.Topic.Name, e.From.Name,
+			e.Topic.Section.Name),
+		NotificationTarget: NotificationTarget{
+			TopicID:   &e.Topic.ID,
+			SectionID: &e.Topic.SectionID,
+		},
+	}}
+}
+
+// TopicReassignedEvent happens when a Topic is assigned to another User. Topic must contain its new User.
+type TopicReassignedEvent struct {
+	ActorID int64
+	Topic   *Topic
+	From    *User
+}
+
+// EventType implements Event.
+func (e TopicReassignedEvent) EventType() EventType {
+	return EventTypeTopicReassigned
+}
+
+// Notifications lets both the previous and the new author know.
+func (e TopicReassignedEvent) Notifications() []*NotificationAdd {
+	target := NotificationTarget{
+		TopicID:   &e.Topic.ID,
+		SectionID: &e.Topic.SectionID,
+	}
+
+	return []*NotificationAdd{
+		{
+			UserID:             e.From.ID,
+			Kind:               NotificationKindTopicReassigned,
+			ActorID:            &e.ActorID,
+			Text:               fmt.Sprintf("Your topic %s was assigned to user %s", e.Topic.Name, e.Topic.User.Nickname),
+			NotificationTarget: target,
+		},
+		{
+			UserID:             e.Topic.UserID,
+			Kind:               NotificationKindTopicReassigned,
+			ActorID:            &e.ActorID,
+			Text:               fmt.Sprintf("Topic %s was assigned from user %s to you", e.Topic.Name, e.From.Nickname),
+			NotificationTarget: target,
+		},
+	}
+}
+
+// TopicRemovedEvent happens when a Topic is deleted.
+type TopicRemovedEvent struct {
+	ActorID int64
+	Topic   *Topic
+}
+
+// EventType implements Event.
+func (e TopicRemovedEvent) EventType() EventType {
+	return EventTypeTopicRemoved
+}
+
+// Notifications lets the author know.
+func (e TopicRemovedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Topic.UserID,
+		Kind:    NotificationKindTopicRemoved,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your topic %s was removed", e.Topic.Name),
+		NotificationTarget: NotificationTarget{
+			TopicID:   &e.Topic.ID,
+			SectionID: &e.Topic.SectionID,
+		},
+	}}
+}
+
+// TopicRestoredEvent happens when a deleted Topic is brought back along with its Posts.
+type TopicRestoredEvent struct {
+	ActorID int64
+	Topic   *Topic
+}
+
+// EventType implements Event.
+func (e TopicRestoredEvent) EventType() EventType {
+	return EventTypeTopicRestored
+}
+
+// Notifications lets the author know.
+func (e TopicRestoredEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.Topic.UserID,
+		Kind:    NotificationKindTopicRestored,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your topic %s was restored", e.Topic.Name),
+		NotificationTarget: NotificationTarget{
+			TopicID:   &e.Topic.ID,
+			SectionID: &e.Topic.SectionID,
+		},
+	}}
+}
+
+// TopicsMergedEvent happens when every Post of a Topic is moved into another one.
+type TopicsMergedEvent struct {
+	ActorID    int64
+	Relocation *TopicRelocation
+}
+
+// EventType implements Event.
+func (e TopicsMergedEvent) EventType() EventType {
+	return EventTypeTopicsMerged
+}
+
+// Notifications lets every author of the moved Posts know, once per author.
+func (e TopicsMergedEvent) Notifications() []*NotificationAdd {
+	return e.Relocation.notifications(e.ActorID, NotificationKindTopicMerged, "as the topics were merged")
+}
+
+// TopicSplitEvent happens when a new Topic is created out of Posts of another one.
+type TopicSplitEvent struct {
+	ActorID    int64
+	Relocation *TopicRelocation
+}
+
+// EventType implements Event.
+func (e TopicSplitEvent) EventType() EventType {
+	return EventTypeTopicSplit
+}
+
+// Notifications lets every author of the moved Posts know, once per author.
+func (e TopicSplitEvent) Notifications() []*NotificationAdd {
+	return e.Relocation.notifications(e.ActorID, NotificationKindTopicSplit, "as the topic was split")
+}
+
+// notifications builds a single Notification per author of the moved Posts.
+func (r *TopicRelocation) notifications(actorID int64, kind NotificationKind, reason string) []*NotificationAdd {
+	notifications := make([]*NotificationAdd, len(r.Authors))
+
+	for i, author := range r.Authors {
+		subject := "Your post was"
+		if author.Count > 1 {
+			subject = fmt.Sprintf("Your %d posts were", author.Count)
+		}
+
+		notifications[i] = &NotificationAdd{
+			UserID:  author.UserID,
+			Kind:    kind,
+			ActorID: &actorID,
+			Text:    fmt.Sprintf("%s moved from topic %s to topic %s %s", subject, r.From.Name, r.To.Name, reason),
+			NotificationTarget: NotificationTarget{
+				TopicID:   &r.To.ID,
+				SectionID: &r.To.SectionID,
+			},
+		}
+	}
+
+	return notifications
+}
+
+// UserLevelChangedEvent happens when the privilege level of a User is changed.
+type UserLevelChangedEvent struct {
+	ActorID int64
+	User    *User
+}
+
+// EventType implements Event.
+func (e UserLevelChangedEvent) EventType() EventType {
+	return EventTypeUserLevelChanged
+}
+
+// Notifications lets the User know.
+func (e UserLevelChangedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.User.ID,
+		Kind:    NotificationKindLevelChanged,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your privilege level has been changed to %s", e.User.Level),
+	}}
+}
+
+// UserRestrictedEvent happens when the restriction level of a User is changed.
+type UserRestrictedEvent struct {
+	ActorID int64
+	User    *User
+}
+
+// EventType implements Event.
+func (e UserRestrictedEvent) EventType() EventType {
+	return EventTypeUserRestricted
+}
+
+// Notifications lets the User know.
+func (e UserRestrictedEvent) Notifications() []*NotificationAdd {
+	return []*NotificationAdd{{
+		UserID:  e.User.ID,
+		Kind:    NotificationKindRestrictionChanged,
+		ActorID: &e.ActorID,
+		Text:    fmt.Sprintf("Your restriction level has been changed to %s", e.User.Restriction),
+	}}
+}
+
+// UserRemovedEvent happens when a User is deleted along with their Topics.
+type UserRemovedEvent struct {
+	ActorID int64
+	User    *User
+}
+
+// EventType implements Event.
+func (e UserRemovedEvent) EventType() EventType {
+	return EventTypeUserRemoved
+}
+
+// SectionRemovedEvent happens when a Section is deleted along with its Topics.
+type SectionRemovedEvent struct {
+	ActorID int64
+	Section *Section
+}
+
+// EventType implements Event.
+func (e SectionRemovedEvent) EventType() EventType {
+	return EventTypeSectionRemoved
+}
+
+// WebhookTriggeredEvent happens when an Event passes the filters of a Webhook, which is to receive the payload.
+// It is published for every Webhook, so each one is retried on its own.
+type WebhookTriggeredEvent struct {
+	WebhookID int64
+	Payload   *WebhookPayload
+}
+
+// EventType implements Event.
+func (e WebhookTriggeredEvent) EventType() EventType {
+	return EventTypeWebhookTriggered
+}
+
+// NotificationsAddedEvent happens when Notifications are stored for the Users, so they can be pushed to them live.
+type NotificationsAddedEvent struct {
+	Notifications []*Notification
+}
+
+// EventType implements Event.
+func (e NotificationsAddedEvent) EventType() EventType {
+	return EventTypeNotificationsAdded
+}
+
+// RankLadderChangedEvent happens when a step of the rank ladder is added, changed or removed.
+type RankLadderChangedEvent struct {
+	RankID int64
+}
+
+// EventType implements Event.
+func (e RankLadderChangedEvent) EventType() EventType {
+	return EventTypeRankLadderChanged
+}
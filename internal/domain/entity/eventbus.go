@@ -0,0 +1,195 @@
+package entity
+
+// Event is anything which happened on the forum and may be of interest to the subscribers of the event bus.
+type Event interface {
+	EventType() EventType
+}
+
+// EventType identifies the kind of an Event the subscribers subscribe to.
+type EventType string
+
+// The known types of Events.
+const (
+	EventTypeUserRegistered     EventType = "USER_REGISTERED"
+	EventTypeUserFollowed       EventType = "USER_FOLLOWED"
+	EventTypeUserLevelChanged   EventType = "USER_LEVEL_CHANGED"
+	EventTypeUserRestricted     EventType = "USER_RESTRICTED"
+	EventTypeUserRemoved        EventType = "USER_REMOVED"
+	EventTypeRankAchieved       EventType = "RANK_ACHIEVED"
+	EventTypeBadgeGranted       EventType = "BADGE_GRANTED"
+	EventTypePostCreated        EventType = "POST_CREATED"
+	EventTypePostMoved          EventType = "POST_MOVED"
+	EventTypePostReassigned     EventType = "POST_REASSIGNED"
+	EventTypePostRemoved        EventType = "POST_REMOVED"
+	EventTypePostRestored       EventType = "POST_RESTORED"
+	EventTypePostApproved       EventType = "POST_APPROVED"
+	EventTypePostRejected       EventType = "POST_REJECTED"
+	EventTypeAnswerAccepted     EventType = "ANSWER_ACCEPTED"
+	EventTypeTopicCreated       EventType = "TOPIC_CREATED"
+	EventTypeTopicMoved         EventType = "TOPIC_MOVED"
+	EventTypeTopicReassigned    EventType = "TOPIC_REASSIGNED"
+	EventTypeTopicRemoved       EventType = "TOPIC_REMOVED"
+	EventTypeTopicRestored      EventType = "TOPIC_RESTORED"
+	EventTypeTopicsMerged       EventType = "TOPICS_MERGED"
+	EventTypeTopicSplit         EventType = "TOPIC_SPLIT"
+	EventTypeSectionRemoved     EventType = "SECTION_REMOVED"
+	EventTypeWebhookTriggered   EventType = "WEBHOOK_TRIGGERED"
+	EventTypeNotificationsAdded EventType = "NOTIFICATIONS_ADDED"
+	EventTypeRankLadderChanged  EventType = "RANK_LADDER_CHANGED"
+)
+
+// NotificationEventTypes lists the types of Events which the Users involved are notified about.
+var NotificationEventTypes = []EventType{
+	EventTypeUserRegistered,
+	EventTypeUserLevelChanged,
+	EventTypeUserRestricted,
+	EventTypeRankAchieved,
+	EventTypeBadgeGranted,
+	EventTypePostCreated,
+	EventTypePostMoved,
+	EventTypePostReassigned,
+	EventTypePostRemoved,
+	EventTypePostRestored,
+	EventTypePostApproved,
+	EventTypePostRejected,
+	EventTypeAnswerAccepted,
+	EventTypeTopicMoved,
+	EventTypeTopicReassigned,
+	EventTypeTopicRemoved,
+	EventTypeTopicRestored,
+	EventTypeTopicsMerged,
+	EventTypeTopicSplit,
+}
+
+// CounterEventTypes lists the types of Events which change the numbers of Posts and Topics stored
+// for the Users, Topics and Sections.
+var CounterEventTypes = []EventType{
+	EventTypeUserRemoved,
+	EventTypePostCreated,
+	EventTypePostMoved,
+	EventTypePostReassigned,
+	EventTypePostRemoved,
+	EventTypePostRestored,
+	EventTypePostRejected,
+	EventTypeTopicCreated,
+	EventTypeTopicMoved,
+	EventTypeTopicReassigned,
+	EventTypeTopicRemoved,
+	EventTypeTopicRestored,
+	EventTypeTopicsMerged,
+	EventTypeTopicSplit,
+	EventTypeSectionRemoved,
+}
+
+// EventPrototypes maps every type of Events to an empty Event of the type, to decode the Events stored in the outbox.
+var EventPrototypes = map[EventType]Event{
+	EventTypeUserRegistered:     UserRegisteredEvent{},
+	EventTypeUserFollowed:       UserFollowedEvent{},
+	EventTypeUserLevelChanged:   UserLevelChangedEvent{},
+	EventTypeUserRestricted:     UserRestrictedEvent{},
+	EventTypeUserRemoved:        UserRemovedEvent{},
+	EventTypeRankAchieved:       RankAchievedEvent{},
+	EventTypeBadgeGranted:       BadgeGrantedEvent{},
+	EventTypePostCreated:        PostCreatedEvent{},
+	EventTypePostMoved:          PostMovedEvent{},
+	EventTypePostReassigned:     PostReassignedEvent{},
+	EventTypePostRemoved:        PostRemovedEvent{},
+	EventTypePostRestored:       PostRestoredEvent{},
+	EventTypePostApproved:       PostApprovedEvent{},
+	EventTypePostRejected:       PostRejectedEvent{},
+	EventTypeAnswerAccepted:     AnswerAcceptedEvent{},
+	EventTypeTopicCreated:       TopicCreatedEvent{},
+	EventTypeTopicMoved:         TopicMovedEvent{},
+	EventTypeTopicReassigned:    TopicReassignedEvent{},
+	EventTypeTopicRemoved:       TopicRemovedEvent{},
+	EventTypeTopicRestored:      TopicRestoredEvent{},
+	EventTypeTopicsMerged:       TopicsMergedEvent{},
+	EventTypeTopicSplit:         TopicSplitEvent{},
+	EventTypeSectionRemoved:     SectionRemovedEvent{},
+	EventTypeWebhookTriggered:   WebhookTriggeredEvent{},
+	EventTypeNotificationsAdded: NotificationsAddedEvent{},
+	EventTypeRankLadderChanged:  RankLadderChangedEvent{},
+}
+
+// EventDelivery represents when a subscriber receives the Events.
+type EventDelivery int
+
+const (
+	// EventDeliverySync runs the subscriber right away, inside the transaction of the publisher.
+	// An error of the subscriber fails the publisher.
+	EventDeliverySync EventDelivery = iota
+	// EventDeliveryAsync runs the subscriber in the background once the transaction of the publisher is committed.
+	// The Events of a rolled back transaction are dropped, and an error of the subscriber is only reported.
+	EventDeliveryAsync
+	// EventDeliveryOutbox stores the Event in the outbox inside the transaction of the publisher, to be delivered
+	// by the dispatcher. A failed delivery is retried until it succeeds or the message is dead-lettered.
+	EventDeliveryOutbox
+	// EventDeliveryBroadcast runs the subscriber on every instance once the transaction of the publisher is committed.
+	// An error of the subscriber is only reported, and the Events are not retried.
+	EventDeliveryBroadcast
+	// EventDeliveryExternal stores the Event in the outbox like EventDeliveryOutbox, for the subscribers which reach
+	// outside the database, e.g. send requests. The subscriber is run outside a transaction, so whatever it stores
+	// is kept even if it fails, and the message is removed once it succeeds, so it is delivered at least once.
+	EventDeliveryExternal
+)
+
+// EventHandler processes an Event received by a subscriber.
+type EventHandler func(Session, Event) error
+
+// EventErrorHandler reports an error of a subscriber delivered asynchronously.
+type EventErrorHandler func(Session, Event, error)
+
+// EventQueue holds the asynchronous deliveries until the transactions of the Session are committed.
+// A nil EventQueue holds nothing, so the deliveries are started at once.
+type EventQueue struct {
+	depth   int
+	pending []func()
+}
+
+// Begin marks the start of a transaction, the returned mark is passed to Commit or Rollback.
+func (q *EventQueue) Begin() int {
+	if q == nil {
+		return 0
+	}
+
+	q.depth++
+
+	return len(q.pending)
+}
+
+// Defer postpones the delivery until the current transaction is committed.
+// It returns false if there is no transaction to wait for.
+func (q *EventQueue) Defer(delivery func()) bool {
+	if q == nil || q.depth == 0 {
+		return false
+	}
+
+	q.pending = append(q.pending, delivery)
+
+	return true
+}
+
+// Commit starts the deliveries postponed since the mark.
+func (q *EventQueue) Commit(mark int) {
+	if q == nil {
+		return
+	}
+
+	q.depth--
+	pending := append([]func(){}, q.pending[mark:]...)
+	q.pending = q.pending[:mark]
+
+	for _, delivery := range pending {
+		delivery()
+	}
+}
+
+// Rollback drops the deliveries postponed since the mark.
+func (q *EventQueue) Rollback(mark int) {
+	if q == nil {
+		return
+	}
+
+	q.depth--
+	q.pending = q.pending[:mark]
+}
@@ -0,0 +1,80 @@
+package entity
+
+// FilterContentKind represents the kind of content passed through the filters.
+type FilterContentKind string
+
+// FilterVerdict represents the outcome of a content filter.
+type FilterVerdict string
+
+const (
+	FilterContentKindPost  FilterContentKind = "POST"
+	FilterContentKindTopic FilterContentKind = "TOPIC"
+)
+
+const (
+	FilterVerdictAllow  FilterVerdict = "ALLOW"
+	FilterVerdictFlag   FilterVerdict = "FLAG"
+	FilterVerdictReject FilterVerdict = "REJECT"
+)
+
+// filterVerdictSeverity orders the verdicts from the mildest to the strictest.
+var filterVerdictSeverity = map[FilterVerdict]int{
+	FilterVerdictAllow:  0,
+	FilterVerdictFlag:   1,
+	FilterVerdictReject: 2,
+}
+
+// StricterThan returns true if the verdict is stricter than the other one.
+func (v FilterVerdict) StricterThan(other FilterVerdict) bool {
+	return filterVerdictSeverity[v] > filterVerdictSeverity[other]
+}
+
+// FilterContent is the content checked by the filters: the text of a Post or the name of a Topic.
+// The filters may rewrite the Text.
+type FilterContent struct {
+	Kind   FilterContentKind
+	UserID int64
+	Text   string
+}
+
+// FilterDecision is the outcome of a single filter. Score is set by the filters which rate the content.
+type FilterDecision struct {
+	Filter  string
+	Verdict FilterVerdict
+	Score   *float64
+	Reason  string
+}
+
+// FilterResult is the outcome of the whole filter chain: the strictest verdict, the possibly rewritten text,
+// and the decisions of every filter which was run.
+type FilterResult struct {
+	Verdict   FilterVerdict
+	Text      string
+	Decisions []*FilterDecision
+}
+
+// FilterDecisionLog is a structure used to record the decisions about a piece of content.
+type FilterDecisionLog struct {
+	Kind      FilterContentKind
+	UserID    int64
+	Decisions []*FilterDecision
+}
+
+// SpamSample is a structure used to train the spam classifier with a piece of content judged by a moderator.
+type SpamSample struct {
+	Text string
+	Spam bool
+}
+
+// SpamTokenCount contains the number of spam and ham samples a token was seen in.
+type SpamTokenCount struct {
+	Token string
+	Spam  int64
+	Ham   int64
+}
+
+// SpamTotals contains the number of spam and ham samples the classifier was trained with.
+type SpamTotals struct {
+	Spam int64
+	Ham  int64
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// FeedItemKind represents the kind of activity in a feed.
+type FeedItemKind string
+
+const (
+	FeedItemKindTopic FeedItemKind = "TOPIC"
+	FeedItemKindPost  FeedItemKind = "POST"
+)
+
+// UserFollow is a subscription of a User to the new Topics and Posts of another one.
+type UserFollow struct {
+	UserID         int64
+	FollowedUserID int64
+}
+
+// UserIgnore is a structure used to hide the Posts of another User and suppress the notifications they cause.
+type UserIgnore struct {
+	UserID        int64
+	IgnoredUserID int64
+}
+
+// UserFollowFilters select the follows by the followers (UserIDs) or by the followed Users.
+type UserFollowFilters struct {
+	UserIDs         []int64
+	FollowedUserIDs []int64
+}
+
+// FeedItem is a new Topic or Post of a followed User.
+type FeedItem struct {
+	Kind      FeedItemKind
+	UserID    int64
+	CreatedAt time.Time
+
+	Topic *Topic
+	Post  *Post
+}
@@ -11,5 +11,5 @@ type Transactioner interface {
 
 // Transactionable is something which abstracts a transaction to propagate it, i.e. service.
 type Transactionable interface {
-	DoTransaction(Session, func() error) error
+	DoTransaction(Session, func(Session) error) error
 }
@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+// Mail is a rendered email message.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// NotificationEmail is a Notification waiting to be sent by email.
+type NotificationEmail struct {
+	ID        int64
+	UserID    int64
+	Kind      NotificationKind
+	Mode      NotificationEmailMode
+	Text      string
+	CreatedAt time.Time
+}
+
+// NotificationDigest contains the pending NotificationEmails of a single User.
+type NotificationDigest struct {
+	Mode          NotificationEmailMode
+	UserID        int64
+	Nickname      string
+	Email         *string
+	Notifications []*NotificationEmail
+}
+
+// IDs returns the IDs of the NotificationEmails in the digest.
+func (d *NotificationDigest) IDs() []int64 {
+	ids := make([]int64, len(d.Notifications))
+
+	for i, notification := range d.Notifications {
+		ids[i] = notification.ID
+	}
+
+	return ids
+}
+
+// Only returns a copy of the digest containing just the NotificationEmails with the given IDs.
+func (d *NotificationDigest) Only(ids []int64) *NotificationDigest {
+	kept := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		kept[id] = true
+	}
+
+	digest := *d
+	digest.Notifications = nil
+
+	for _, notification := range d.Notifications {
+		if kept[notification.ID] {
+			digest.Notifications = append(digest.Notifications, notification)
+		}
+	}
+
+	return &digest
+}
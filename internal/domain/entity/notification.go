@@ -1,17 +1,172 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 )
 
+// NotificationEmailMode represents the way a Notification is delivered by email.
+type NotificationEmailMode string
+
+const (
+	NotificationEmailModeNone      NotificationEmailMode = "NONE"
+	NotificationEmailModeImmediate NotificationEmailMode = "IMMEDIATE"
+	NotificationEmailModeDaily     NotificationEmailMode = "DAILY"
+	NotificationEmailModeWeekly    NotificationEmailMode = "WEEKLY"
+)
+
+// NotificationKind represents the type of a Notification.
+type NotificationKind string
+
+const (
+	NotificationKindGeneral            NotificationKind = "GENERAL"
+	NotificationKindWelcome            NotificationKind = "WELCOME"
+	NotificationKindRankAchieved       NotificationKind = "RANK_ACHIEVED"
+	NotificationKindBadgeGranted       NotificationKind = "BADGE_GRANTED"
+	NotificationKindAnswerAccepted     NotificationKind = "ANSWER_ACCEPTED"
+	NotificationKindNewPost            NotificationKind = "NEW_POST"
+	NotificationKindPostMoved          NotificationKind = "POST_MOVED"
+	NotificationKindPostReassigned     NotificationKind = "POST_REASSIGNED"
+	NotificationKindPostRemoved        NotificationKind = "POST_REMOVED"
+	NotificationKindPostRestored       NotificationKind = "POST_RESTORED"
+	NotificationKindPostApproved       NotificationKind = "POST_APPROVED"
+	NotificationKindPostRejected       NotificationKind = "POST_REJECTED"
+	NotificationKindTopicMoved         NotificationKind = "TOPIC_MOVED"
+	NotificationKindTopicReassigned    NotificationKind = "TOPIC_REASSIGNED"
+	NotificationKindTopicRemoved       NotificationKind = "TOPIC_REMOVED"
+	NotificationKindTopicRestored      NotificationKind = "TOPIC_RESTORED"
+	NotificationKindTopicMerged        NotificationKind = "TOPIC_MERGED"
+	NotificationKindTopicSplit         NotificationKind = "TOPIC_SPLIT"
+	NotificationKindLevelChanged       NotificationKind = "LEVEL_CHANGED"
+	NotificationKindRestrictionChanged NotificationKind = "RESTRICTION_CHANGED"
+)
+
+// NotificationKinds lists every known NotificationKind.
+var NotificationKinds = []NotificationKind{
+	NotificationKindGeneral,
+	NotificationKindWelcome,
+	NotificationKindRankAchieved,
+	NotificationKindBadgeGranted,
+	NotificationKindAnswerAccepted,
+	NotificationKindNewPost,
+	NotificationKindPostMoved,
+	NotificationKindPostReassigned,
+	NotificationKindPostRemoved,
+	NotificationKindPostRestored,
+	NotificationKindPostApproved,
+	NotificationKindPostRejected,
+	NotificationKindTopicMoved,
+	NotificationKindTopicReassigned,
+	NotificationKindTopicRemoved,
+	NotificationKindTopicRestored,
+	NotificationKindTopicMerged,
+	NotificationKindTopicSplit,
+	NotificationKindLevelChanged,
+	NotificationKindRestrictionChanged,
+}
+
+// notificationGroupFormats describe the groups of Notifications of a kind, given their number and the group subject.
+var notificationGroupFormats = map[NotificationKind]string{
+	NotificationKindNewPost: "%d new replies in topic %s",
+}
+
+// NotificationGroupText describes a group of Notifications of the kind, or returns false if the kind isn't grouped.
+func NotificationGroupText(kind NotificationKind, count int64, subject string) (string, bool) {
+	format, ok := notificationGroupFormats[kind]
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf(format, count, subject), true
+}
+
+// NotificationTarget references the entity a Notification is about.
+type NotificationTarget struct {
+	PostID    *int64
+	TopicID   *int64
+	SectionID *int64
+}
+
+// Notification is a message for a User. Seq is the number of its last change, which grows every time a grouped
+// Notification is merged into it.
 type Notification struct {
 	ID        int64
+	Seq       int64
 	UserID    int64
+	Kind      NotificationKind
+	ActorID   *int64
 	Text      string
+	Count     int64
+	ReadAt    *time.Time
 	CreatedAt time.Time
+
+	NotificationTarget
 }
 
+// NotificationAdd is a structure used to create a new Notification.
+// If GroupKey is set, the Notification is merged into the unread one with the same key, and GroupText is the subject
+// of the group, such as the name of the Topic, described with the format of the kind.
 type NotificationAdd struct {
+	UserID    int64
+	Kind      NotificationKind
+	ActorID   *int64
+	Text      string
+	GroupKey  *string
+	GroupText *string
+
+	NotificationTarget
+}
+
+// NotificationMarkRead is a structure used to mark the Notifications of a User as read.
+// If IDs are empty, every Notification is marked.
+type NotificationMarkRead struct {
 	UserID int64
-	Text   string
+	IDs    []int64
+}
+
+// NotificationPreference describes how a User receives Notifications of a single kind.
+type NotificationPreference struct {
+	Kind  NotificationKind
+	InApp bool
+	Email NotificationEmailMode
+}
+
+// DefaultNotificationPreference returns the preference used for the kinds a User has not configured.
+func DefaultNotificationPreference(kind NotificationKind) *NotificationPreference {
+	return &NotificationPreference{
+		Kind:  kind,
+		InApp: true,
+		Email: NotificationEmailModeNone,
+	}
+}
+
+// NotificationPreferenceSet is a structure used to change the preference of a User for a single kind.
+type NotificationPreferenceSet struct {
+	UserID int64
+	Kind   NotificationKind
+	InApp  *bool
+	Email  *NotificationEmailMode
+}
+
+// NotificationPreferencesMap builds a map kind => NotificationPreference.
+func NotificationPreferencesMap(e []*NotificationPreference) map[NotificationKind]*NotificationPreference {
+	res := make(map[NotificationKind]*NotificationPreference, len(e))
+
+	for _, preference := range e {
+		res[preference.Kind] = preference
+	}
+
+	return res
+}
+
+// Period returns how long pending emails are collected before they are sent.
+func (m NotificationEmailMode) Period() time.Duration {
+	switch m {
+	case NotificationEmailModeDaily:
+		return 24 * time.Hour
+	case NotificationEmailModeWeekly:
+		return 7 * 24 * time.Hour
+	}
+
+	return 0
 }
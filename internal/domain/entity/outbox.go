@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxStatus represents the delivery state of an OutboxMessage.
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "PENDING"
+	OutboxStatusDead    OutboxStatus = "DEAD"
+)
+
+// OutboxMessage is an Event stored in the transaction which published it, waiting to be delivered to a subscriber.
+// The delivered messages are removed, the ones which failed every attempt are dead-lettered.
+type OutboxMessage struct {
+	ID            int64
+	Subscriber    string
+	EventType     EventType
+	Payload       string
+	SessionID     string
+	UserID        int64
+	UserLevel     UserLevel
+	Status        OutboxStatus
+	Attempts      int64
+	LastError     *string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+type OutboxMessageAdd struct {
+	Subscriber string
+	EventType  EventType
+	Payload    string
+	SessionID  string
+	UserID     int64
+	UserLevel  UserLevel
+}
+
+type OutboxMessageEdit struct {
+	ID            int64
+	Status        *OutboxStatus
+	Attempts      *int64
+	LastError     *string
+	NextAttemptAt *time.Time
+}
+
+type OutboxFilters struct {
+	IDs          []int64
+	Statuses     []OutboxStatus
+	Subscribers  []string
+	EventTypes   []EventType
+	AttemptsFrom *int64
+}
+
+// OutboxDispatchResult sums up a round of the outbox deliveries.
+type OutboxDispatchResult struct {
+	Delivered int64
+	Retried   int64
+	Dead      int64
+}
+
+// OutboxRules contains the settings of the outbox delivery. A message is claimed by a dispatcher for Lease,
+// and retried after a backoff doubling from BaseBackoff up to MaxBackoff until MaxAttempts are made.
+type OutboxRules struct {
+	BatchSize   int64
+	Lease       time.Duration
+	MaxAttempts int64
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Backoff returns the delay before the next attempt to deliver a message after the given number of failed attempts.
+func (r OutboxRules) Backoff(attempts int64) time.Duration {
+	backoff := r.BaseBackoff
+
+	for i := int64(1); i < attempts && backoff < r.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+
+	return backoff
+}
+
+// Session recreates the Session which published the Event, so the subscriber acts on behalf of the same User.
+func (m *OutboxMessage) Session(ctx context.Context) Session {
+	return Session{
+		Ctx:    ctx,
+		ID:     m.SessionID,
+		UserID: m.UserID,
+		Level:  m.UserLevel,
+	}
+}
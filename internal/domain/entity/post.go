@@ -1,32 +1,94 @@
 package entity
 
-import "time"
+import (
+	"regexp"
+	"time"
+)
+
+// PostStatus represents the moderation state of a Post.
+type PostStatus string
+
+const (
+	PostStatusPublished PostStatus = "PUBLISHED"
+	PostStatusPending   PostStatus = "PENDING"
+)
 
 // Post is a general structure representing a Post.
 type Post struct {
 	ID        int64
 	Text      string
+	Status    PostStatus
+	Wiki      bool
 	UserID    int64
 	User      *User
 	TopicID   int64
 	Topic     *Topic
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
+
+	// AuthorIgnored tells if the current User ignores the author, so that the Post is collapsed.
+	AuthorIgnored bool
+
+	// Contributors are the Users who have edited the text of the Post, the latest one first.
+	Contributors []*PostContributor
+}
+
+// VisibleTo checks if the Post can be seen by the User of the Session: deleted Posts are seen by no one,
+// and pending ones only by their authors and the moderators.
+func (p *Post) VisibleTo(sess Session) bool {
+	if p.DeletedAt != nil {
+		return false
+	}
+
+	return p.Status == PostStatusPublished || p.UserID == sess.UserID || sess.Level.AtLeast(UserLevelMod)
 }
 
+// PostAdd is a structure used to insert a new Post. Status is decided by the service,
+// the Posts flagged by the content filters are always held for approval.
 type PostAdd struct {
 	UserID  int64
 	TopicID int64
 	Text    string
+	Flagged bool
+	Status  PostStatus
 }
 
 type PostEdit struct {
 	ID      int64
 	Text    *string
+	Wiki    *bool
 	UserID  *int64
 	TopicID *int64
 }
 
+// PostContributor contains the edits a User has made to the text of a Post.
+type PostContributor struct {
+	PostID       int64
+	UserID       int64
+	User         *User
+	CountEdits   int64
+	LastEditedAt time.Time
+}
+
+// PostEditRules decide who can edit the text of a Post besides the moderators. The author can edit a regular Post
+// within EditWindow, a zero EditWindow doesn't limit it. A wiki Post can be edited by its author at any time,
+// and by anyone of WikiMinRank and up.
+type PostEditRules struct {
+	EditWindow  time.Duration
+	WikiMinRank int64
+}
+
+// Expired returns true if the author can no longer edit the Post.
+func (r PostEditRules) Expired(post *Post, now time.Time) bool {
+	return !post.Wiki && r.EditWindow > 0 && now.Sub(post.CreatedAt) > r.EditWindow
+}
+
+// WikiEditor returns true if the User can edit the wiki Posts of others.
+func (r PostEditRules) WikiEditor(user *User) bool {
+	return user.Rank >= r.WikiMinRank
+}
+
 type PlainPostByID struct {
 	ID         int64
 	FetchTopic bool
@@ -37,10 +99,81 @@ type PostFilters struct {
 	IDs      []int64
 	UserIDs  []int64
 	TopicIDs []int64
+
+	// Deleted selects the deleted Posts only.
+	Deleted bool
+
+	// Pending selects the pending Posts only, IncludePending selects both pending and published ones.
+	// Otherwise the pending Posts are hidden, except those written by PendingAuthorID.
+	Pending         bool
+	IncludePending  bool
+	PendingAuthorID int64
+
+	// HideIgnored hides the Posts of the Users ignored by the current User, ExcludeUserIDs are set by the service.
+	HideIgnored    bool
+	ExcludeUserIDs []int64
 }
 
 type PostDelete PostFilters
 
+// PostMove is a structure used to move Posts into another Topic: the ones with the given IDs,
+// or every Post of the FromTopicID Topic.
+type PostMove struct {
+	IDs         []int64
+	FromTopicID *int64
+	ToTopicID   int64
+}
+
+// PostCounters selects the Topics and Users whose numbers of Posts should be recomputed: the given ones and those
+// of the given Posts. The given Topics, the Topics of the given Sections and those started by the TopicUserIDs
+// are recomputed along with the authors of their Posts.
+type PostCounters struct {
+	PostIDs      []int64
+	TopicIDs     []int64
+	SectionIDs   []int64
+	UserIDs      []int64
+	TopicUserIDs []int64
+}
+
+// PostAuthorCount contains the number of Posts written by a User.
+type PostAuthorCount struct {
+	UserID int64
+	Count  int64
+}
+
+// linkPattern matches the links in the text of a Post.
+var linkPattern = regexp.MustCompile(`(?i)\b(https?://|www\.)\S+`)
+
+// PremoderationRules decide which new Posts are held until a moderator approves them.
+// A zero value disables the respective rule.
+type PremoderationRules struct {
+	MinRank       int64
+	MinAccountAge time.Duration
+	HoldLinks     bool
+}
+
+// Holds returns true if a Post with the given text written by the User should be held for approval.
+func (r PremoderationRules) Holds(user *User, text string, now time.Time) bool {
+	if user.Level.AtLeast(UserLevelMod) {
+		return false
+	}
+
+	if r.MinRank > 0 && user.Rank < r.MinRank {
+		return true
+	}
+
+	if r.MinAccountAge > 0 && now.Sub(user.CreatedAt) < r.MinAccountAge {
+		return true
+	}
+
+	return r.HoldLinks && CountLinks(text) > 0
+}
+
+// CountLinks returns the number of links in the text.
+func CountLinks(text string) int {
+	return len(linkPattern.FindAllStringIndex(text, -1))
+}
+
 type PostSort struct {
 	By    PostSortBy
 	Order SortOrder
@@ -67,6 +200,17 @@ func PostsEntityIDs(posts []*Post) ([]int64, []int64) {
 	return userIDs, topicIDs
 }
 
+// PostContributorsEntityIDs returns the IDs of the Users who have contributed to the Posts.
+func PostContributorsEntityIDs(contributors []*PostContributor) []int64 {
+	userIDs := make([]int64, len(contributors))
+
+	for i, contributor := range contributors {
+		userIDs[i] = contributor.UserID
+	}
+
+	return userIDs
+}
+
 // PostsMap returns an id => Post map extracted out of posts.
 func PostsMap(posts []*Post) map[int64]*Post {
 	postsMap := make(map[int64]*Post, len(posts))
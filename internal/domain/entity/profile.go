@@ -0,0 +1,216 @@
+package entity
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"simplestforum/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProfileVisibility represents who can see a profile field.
+type ProfileVisibility string
+
+// ProfileFieldType represents the kind of values a custom profile field accepts.
+type ProfileFieldType string
+
+const (
+	ProfileVisibilityPublic  ProfileVisibility = "PUBLIC"
+	ProfileVisibilityMembers ProfileVisibility = "MEMBERS"
+	ProfileVisibilityPrivate ProfileVisibility = "PRIVATE"
+)
+
+const (
+	ProfileFieldTypeText   ProfileFieldType = "TEXT"
+	ProfileFieldTypeNumber ProfileFieldType = "NUMBER"
+	ProfileFieldTypeURL    ProfileFieldType = "URL"
+	ProfileFieldTypeDate   ProfileFieldType = "DATE"
+	ProfileFieldTypeSelect ProfileFieldType = "SELECT"
+)
+
+// ProfileFieldValueMaxLength is the length limit of any custom profile field value.
+const ProfileFieldValueMaxLength = 1000
+
+// ProfileFieldDateLayout is the format of the DATE custom profile field values.
+const ProfileFieldDateLayout = "2006-01-02"
+
+// SignatureMaxLines is the number of lines a signature may have.
+const SignatureMaxLines = 4
+
+// profileLinkTrailer are the characters which end a sentence rather than a link.
+const profileLinkTrailer = ".,;:!?)"
+
+// VisibleTo checks if the viewer can see a field of the owner's profile.
+// The owners and the admins see every field.
+func (v ProfileVisibility) VisibleTo(sess Session, ownerID int64) bool {
+	if (sess.IsAuthorized() && sess.UserID == ownerID) || sess.Level.AtLeast(UserLevelAdmin) {
+		return true
+	}
+
+	switch v {
+	case ProfileVisibilityPublic:
+		return true
+	case ProfileVisibilityMembers:
+		return sess.IsAuthorized()
+	}
+
+	return false
+}
+
+// ProfileField is a general structure representing a custom profile field defined by the admins.
+// Pattern and Options restrict the values further, the Options are only used by the SELECT fields.
+type ProfileField struct {
+	ID         int64
+	Name       string
+	Title      string
+	Type       ProfileFieldType
+	Required   bool
+	Visibility ProfileVisibility
+	Pattern    *string
+	MaxLength  *int64
+	Options    []string
+	Position   int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ProfileFieldAdd is a structure used to define a new custom profile field.
+type ProfileFieldAdd struct {
+	Name       string
+	Title      string
+	Type       ProfileFieldType
+	Required   bool
+	Visibility ProfileVisibility
+	Pattern    *string
+	MaxLength  *int64
+	Options    []string
+	Position   int64
+}
+
+// ProfileFieldEdit is a structure used to edit an existing custom profile field.
+// The name and the type are fixed, as the values already given depend on them.
+// An empty Pattern, a zero MaxLength and empty Options remove the restriction.
+type ProfileFieldEdit struct {
+	ID         int64
+	Title      *string
+	Required   *bool
+	Visibility *ProfileVisibility
+	Pattern    *string
+	MaxLength  *int64
+	Options    []string
+	Position   *int64
+}
+
+// ProfileFieldValue is the value of a custom profile field given by a User.
+type ProfileFieldValue struct {
+	UserID  int64
+	FieldID int64
+	Value   string
+
+	Field *ProfileField
+}
+
+// ProfileFieldValueSet is a structure used to give a value to a custom profile field, an empty Value removes it.
+type ProfileFieldValueSet struct {
+	FieldID int64
+	Value   string
+}
+
+// Validate checks if the value is acceptable for the field.
+func (f *ProfileField) Validate(value string) error {
+	length := int64(utf8.RuneCountInString(value))
+	if length > ProfileFieldValueMaxLength || (f.MaxLength != nil && length > *f.MaxLength) {
+		return domain.NewError(domain.ErrCodeValidation, "The value of %s is too long", f.Title)
+	}
+
+	var valid bool
+
+	switch f.Type {
+	case ProfileFieldTypeText:
+		valid = true
+	case ProfileFieldTypeNumber:
+		_, err := strconv.ParseFloat(value, 64)
+		valid = err == nil
+	case ProfileFieldTypeURL:
+		u, err := url.ParseRequestURI(value)
+		valid = err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	case ProfileFieldTypeDate:
+		_, err := time.Parse(ProfileFieldDateLayout, value)
+		valid = err == nil
+	case ProfileFieldTypeSelect:
+		for _, option := range f.Options {
+			if option == value {
+				valid = true
+
+				break
+			}
+		}
+	}
+
+	if !valid && f.Type == ProfileFieldTypeSelect {
+		return domain.NewError(domain.ErrCodeValidation, "The value of %s is not one of the options", f.Title)
+	}
+
+	if !valid {
+		return domain.NewError(domain.ErrCodeValidation, "The value of %s is not a valid %s", f.Title,
+			strings.ToLower(string(f.Type)))
+	}
+
+	if f.Pattern != nil {
+		// The pattern is checked when the field is defined
+		matched, _ := regexp.MatchString(`^(?:`+*f.Pattern+`)$`, value)
+		if !matched {
+			return domain.NewError(domain.ErrCodeValidation, "The value of %s doesn't match the expected format", f.Title)
+		}
+	}
+
+	return nil
+}
+
+// RenderProfileText returns the text as HTML which is safe to embed into a page: the markup is escaped, the line breaks
+// are kept and the links are made clickable, without passing the reputation of the forum to them.
+func RenderProfileText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, loc := range linkPattern.FindAllStringIndex(text, -1) {
+		link := strings.TrimRight(text[loc[0]:loc[1]], profileLinkTrailer)
+		if link == "" {
+			continue
+		}
+
+		href := link
+		if !strings.HasPrefix(strings.ToLower(href), "http") {
+			href = "http://" + href
+		}
+
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		fmt.Fprintf(&b, `<a href="%s" rel="nofollow ugc noopener" target="_blank">%s</a>`, html.EscapeString(href),
+			html.EscapeString(link))
+
+		last = loc[0] + len(link)
+	}
+
+	b.WriteString(html.EscapeString(text[last:]))
+
+	return strings.ReplaceAll(b.String(), "\n", "<br>")
+}
+
+// ProfileFieldsMap returns an id => ProfileField map extracted out of fields.
+func ProfileFieldsMap(fields []*ProfileField) map[int64]*ProfileField {
+	fieldsMap := make(map[int64]*ProfileField, len(fields))
+
+	for _, field := range fields {
+		fieldsMap[field.ID] = field
+	}
+
+	return fieldsMap
+}
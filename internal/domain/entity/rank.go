@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// RankPerk represents something a User is allowed to do once the rank is reached.
+type RankPerk string
+
+const (
+	RankPerkSignature RankPerk = "SIGNATURE"
+	RankPerkAvatar    RankPerk = "AVATAR"
+)
+
+// Rank is a general structure representing a step of the rank ladder.
+// Number is the position of the step in the ladder, which is the rank of the Users on it.
+type Rank struct {
+	ID        int64
+	Number    int64
+	MinPosts  int64
+	Title     string
+	Perks     []RankPerk
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RankAdd is a structure used to add a new step to the rank ladder.
+type RankAdd struct {
+	MinPosts int64
+	Title    string
+	Perks    []RankPerk
+}
+
+// RankEdit is a structure used to edit an existing step of the rank ladder.
+type RankEdit struct {
+	ID       int64
+	MinPosts *int64
+	Title    *string
+	Perks    []RankPerk
+}
+
+// RankLadder is the list of Ranks ordered by the number of posts needed to reach them.
+type RankLadder []*Rank
+
+// HasPerk checks if the Rank grants the perk.
+func (r *Rank) HasPerk(perk RankPerk) bool {
+	for _, p := range r.Perks {
+		if p == perk {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Name returns the title of the Rank, or its number if it has no title.
+func (r *Rank) Name() string {
+	if r.Title != "" {
+		return r.Title
+	}
+
+	return strconv.FormatInt(r.Number, 10)
+}
+
+// Step returns the Rank of the given number. The ranks above the ladder, which only the admins can give,
+// get its top step; nil is returned if the ladder is empty.
+func (l RankLadder) Step(rank int64) *Rank {
+	switch {
+	case len(l) == 0 || rank < 1:
+		return nil
+	case rank > int64(len(l)):
+		return l[len(l)-1]
+	}
+
+	return l[rank-1]
+}
+
+// Copy returns a copy of the ladder whose steps can be changed freely.
+func (l RankLadder) Copy() RankLadder {
+	ladder := make(RankLadder, len(l))
+
+	for i, rank := range l {
+		step := *rank
+		ladder[i] = &step
+	}
+
+	return ladder
+}
+
+// ReachedAt returns the Rank a User reaches with exactly the given number of posts, or nil if there is none.
+// The first step is never reached, as every User starts on it.
+func (l RankLadder) ReachedAt(countPosts int64) *Rank {
+	for i, rank := range l {
+		if i > 0 && rank.MinPosts == countPosts {
+			return rank
+		}
+	}
+
+	return nil
+}
+
+// Allows checks if the Users of the given rank have the perk. Without a ladder there is nothing to restrict.
+func (l RankLadder) Allows(rank int64, perk RankPerk) bool {
+	step := l.Step(rank)
+
+	return step == nil || step.HasPerk(perk)
+}
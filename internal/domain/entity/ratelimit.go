@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// RateLimitAction represents an action with its own quota.
+type RateLimitAction string
+
+const (
+	RateLimitActionPost         RateLimitAction = "POST"
+	RateLimitActionTopic        RateLimitAction = "TOPIC"
+	RateLimitActionRegistration RateLimitAction = "REGISTRATION"
+	RateLimitActionNotification RateLimitAction = "NOTIFICATION"
+)
+
+// RateLimitQuota is a token bucket which holds up to Burst tokens and is refilled with Burst tokens every Period.
+// A zero Burst lifts the limit.
+type RateLimitQuota struct {
+	Burst  int64
+	Period time.Duration
+}
+
+// Unlimited returns true if the quota lifts the limit.
+func (q RateLimitQuota) Unlimited() bool {
+	return q.Burst <= 0 || q.Period <= 0
+}
+
+// Scale returns the quota with both the burst and the refill rate multiplied by the factor.
+// A zero factor lifts the limit.
+func (q RateLimitQuota) Scale(factor float64) RateLimitQuota {
+	if factor <= 0 {
+		return RateLimitQuota{}
+	}
+
+	q.Burst = int64(math.Ceil(float64(q.Burst) * factor))
+
+	return q
+}
+
+// Take refills the bucket up to now and spends one token of it. The nil bucket is a full one.
+// If no token is left, the bucket is returned unchanged along with the time until the next token.
+func (q RateLimitQuota) Take(b *RateLimitBucket, now time.Time) (*RateLimitBucket, time.Duration) {
+	tokens := float64(q.Burst)
+
+	if b != nil {
+		elapsed := now.Sub(b.UpdatedAt)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+
+		tokens = math.Min(tokens, b.Tokens+float64(q.Burst)*elapsed.Seconds()/q.Period.Seconds())
+	}
+
+	if tokens < 1 {
+		wait := (1 - tokens) * q.Period.Seconds() / float64(q.Burst)
+
+		return b, time.Duration(math.Ceil(wait * float64(time.Second)))
+	}
+
+	return &RateLimitBucket{
+		Tokens:    tokens - 1,
+		UpdatedAt: now,
+	}, 0
+}
+
+// Full returns true if the bucket has been refilled completely by now.
+func (q RateLimitQuota) Full(b *RateLimitBucket, now time.Time) bool {
+	return b.Tokens+float64(q.Burst)*now.Sub(b.UpdatedAt).Seconds()/q.Period.Seconds() >= float64(q.Burst)
+}
+
+// RateLimitBucket is the state of a token bucket.
+type RateLimitBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// RateLimitTake is a structure used to spend a token of the bucket with the given Key.
+type RateLimitTake struct {
+	Key   string
+	Quota RateLimitQuota
+	Now   time.Time
+}
+
+// RateLimitRules decide the quota of every action. The Quotas are those of the regular Users and the guests,
+// LevelFactors scale them for the Users of the given levels, and TrustedFactor for the Users of TrustedRank and up.
+// A zero TrustedRank disables the rank rule.
+type RateLimitRules struct {
+	Quotas        map[RateLimitAction]RateLimitQuota
+	LevelFactors  map[UserLevel]float64
+	TrustedRank   int64
+	TrustedFactor float64
+}
+
+// Quota returns the quota of the action for a User of the given level and rank.
+func (r RateLimitRules) Quota(action RateLimitAction, level UserLevel, rank int64) RateLimitQuota {
+	quota := r.Quotas[action]
+
+	if factor, ok := r.LevelFactors[level]; ok {
+		quota = quota.Scale(factor)
+	}
+
+	if r.TrustedRank > 0 && rank >= r.TrustedRank {
+		quota = quota.Scale(r.TrustedFactor)
+	}
+
+	return quota
+}
+
+// RateLimitUserKey returns the bucket key of the action performed by a User.
+func RateLimitUserKey(action RateLimitAction, userID int64) string {
+	return string(action) + ":user:" + strconv.FormatInt(userID, 10)
+}
+
+// RateLimitClientKey returns the bucket key of the action performed from a client address.
+func RateLimitClientKey(action RateLimitAction, addr string) string {
+	return string(action) + ":client:" + addr
+}
@@ -0,0 +1,96 @@
+package entity
+
+import "time"
+
+// ReportTargetType represents the kind of content a Report is about.
+type ReportTargetType string
+
+// ReportReason represents the category of a Report.
+type ReportReason string
+
+// ReportStatus represents the state of a Report in the moderation queue.
+type ReportStatus string
+
+// ReportAction represents the measure taken when a Report is resolved.
+type ReportAction string
+
+const (
+	ReportTargetTypePost  ReportTargetType = "POST"
+	ReportTargetTypeTopic ReportTargetType = "TOPIC"
+	ReportTargetTypeUser  ReportTargetType = "USER"
+)
+
+const (
+	ReportReasonSpam     ReportReason = "SPAM"
+	ReportReasonAbuse    ReportReason = "ABUSE"
+	ReportReasonOffTopic ReportReason = "OFF_TOPIC"
+	ReportReasonIllegal  ReportReason = "ILLEGAL"
+	ReportReasonOther    ReportReason = "OTHER"
+)
+
+const (
+	ReportStatusOpen      ReportStatus = "OPEN"
+	ReportStatusResolved  ReportStatus = "RESOLVED"
+	ReportStatusDismissed ReportStatus = "DISMISSED"
+)
+
+const (
+	ReportActionDeleteContent  ReportAction = "DELETE_CONTENT"
+	ReportActionRestrictAuthor ReportAction = "RESTRICT_AUTHOR"
+	ReportActionBanAuthor      ReportAction = "BAN_AUTHOR"
+)
+
+// Report is a general structure representing a complaint about a Post, a Topic or a User.
+// TargetUserID is the author of the reported content, or the reported User themselves.
+type Report struct {
+	ID             int64
+	ReporterID     int64
+	TargetType     ReportTargetType
+	TargetID       int64
+	TargetUserID   int64
+	Reason         ReportReason
+	Text           *string
+	Status         ReportStatus
+	AssigneeID     *int64
+	ResolutionNote *string
+	Action         *ReportAction
+	ResolvedByID   *int64
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// ReportAdd is a structure used to file a new Report.
+// A User has at most one open Report per target, reporting it again updates the reason and the text.
+type ReportAdd struct {
+	ReporterID   int64
+	TargetType   ReportTargetType
+	TargetID     int64
+	TargetUserID int64
+	Reason       ReportReason
+	Text         *string
+}
+
+// ReportAssign is a structure used to assign an open Report to a moderator, or to unassign it if AssigneeID is nil.
+type ReportAssign struct {
+	ID         int64
+	AssigneeID *int64
+}
+
+// ReportResolve is a structure used to close an open Report, optionally applying an action to the reported content.
+type ReportResolve struct {
+	ID             int64
+	Status         ReportStatus
+	ResolutionNote *string
+	Action         *ReportAction
+	ResolvedByID   int64
+}
+
+type ReportFilters struct {
+	IDs           []int64
+	Statuses      []ReportStatus
+	TargetTypes   []ReportTargetType
+	TargetUserIDs []int64
+	AssigneeIDs   []int64
+	ReporterIDs   []int64
+}
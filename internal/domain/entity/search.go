@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// SearchHitKind represents the kind of content found.
+type SearchHitKind string
+
+const (
+	SearchHitKindPost  SearchHitKind = "POST"
+	SearchHitKindTopic SearchHitKind = "TOPIC"
+)
+
+// SearchHighlightStart and SearchHighlightStop surround the matched words in the snippets.
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightStop  = "</mark>"
+)
+
+// SearchQuery is a structure used to search the published Posts and the Topic names.
+// Only the deleted content and the merged Topics are never found.
+type SearchQuery struct {
+	Query      string
+	SectionIDs []int64
+	UserIDs    []int64
+	DateFrom   *time.Time
+	DateTo     *time.Time
+}
+
+// SearchHit is a single search result, ID is that of the Post or the Topic depending on the Kind.
+// The Snippet is a fragment of the text escaped as HTML with the matched words highlighted.
+type SearchHit struct {
+	Kind      SearchHitKind
+	ID        int64
+	TopicID   int64
+	SectionID int64
+	UserID    int64
+	Rank      float64
+	Snippet   string
+	CreatedAt time.Time
+
+	Post  *Post
+	Topic *Topic
+}
+
+// SearchDocument is a piece of searchable content as seen by the in-process search.
+type SearchDocument struct {
+	Kind      SearchHitKind
+	ID        int64
+	TopicID   int64
+	SectionID int64
+	UserID    int64
+	Text      string
+	CreatedAt time.Time
+}
+
+// SearchHitsEntityIDs returns the Ids of the posts and topics found as slices.
+func SearchHitsEntityIDs(hits []*SearchHit) ([]int64, []int64) {
+	var postIDs []int64
+
+	topicIDs := make([]int64, len(hits))
+
+	for i, hit := range hits {
+		if hit.Kind == SearchHitKindPost {
+			postIDs = append(postIDs, hit.ID)
+		}
+
+		topicIDs[i] = hit.TopicID
+	}
+
+	return postIDs, topicIDs
+}
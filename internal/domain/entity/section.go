@@ -2,26 +2,39 @@ package entity
 
 import "time"
 
+// SectionMode represents the kind of the discussions held in a Section.
+type SectionMode string
+
+const (
+	SectionModeDiscussion SectionMode = "DISCUSSION"
+	SectionModeQA         SectionMode = "QA"
+)
+
 // Section is a general structure representing a Section.
+// In a QA Section the Topics are questions, and one Post of each can be accepted as the answer.
 type Section struct {
 	ID          int64
 	Name        string
 	Description *string
+	Mode        SectionMode
 	CountTopics int64
 	Topics      []*Topic
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   *time.Time
 }
 
 type SectionAdd struct {
 	Name        string
 	Description *string
+	Mode        SectionMode
 }
 
 type SectionEdit struct {
 	ID          int64
 	Name        *string
 	Description *string
+	Mode        *SectionMode
 }
 
 type SectionFilters struct {
@@ -18,11 +18,13 @@ type Session struct {
 	Ctx context.Context //nolint:containedctx
 
 	ID          string
+	ClientAddr  string
 	UserID      int64
 	Level       UserLevel
 	Restriction UserRestriction
 
 	Transaction AbstractTransaction
+	Events      *EventQueue
 
 	RequestedFields RequestFields
 }
@@ -14,6 +14,15 @@ type Topic struct {
 	Posts      []*Post
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	DeletedAt  *time.Time
+
+	RedirectTopicID *int64
+
+	AcceptedPostID *int64
+	AcceptedAnswer *Post
+
+	UnreadCount     *int64
+	FirstUnreadPost *Post
 }
 
 type TopicAdd struct {
@@ -39,10 +48,154 @@ type TopicFilters struct {
 	IDs        []int64
 	UserIDs    []int64
 	SectionIDs []int64
+	UnreadOnly bool
+
+	// Answered selects the questions with an accepted answer if true, and the ones without it if false.
+	Answered *bool
+
+	// Deleted selects the deleted Topics only, IncludeDeleted selects both deleted and existing ones.
+	Deleted        bool
+	IncludeDeleted bool
 }
 
 type TopicDelete TopicFilters
 
+// SimilarTopicsLimit is the number of the similar Topics suggested.
+const SimilarTopicsLimit = 5
+
+// TopicSimilarFilters select the Topics similar to the Name. The Topics are matched by their names and,
+// unless NameOnly is set, by their first Posts. MinSimilarity ranges from 0 to 1.
+type TopicSimilarFilters struct {
+	Name          string
+	SectionID     *int64
+	CreatedAfter  *time.Time
+	NameOnly      bool
+	MinSimilarity float64
+	Limit         int64
+}
+
+// SimilarTopic is a Topic along with its similarity to the searched name, from 0 to 1.
+type SimilarTopic struct {
+	TopicID    int64
+	Topic      *Topic
+	Similarity float64
+}
+
+// TopicDuplicateGuard rejects the new Topics whose names are at least MinSimilarity similar to the name of a Topic
+// created in the same Section within the Window. A zero MinSimilarity disables the guard.
+type TopicDuplicateGuard struct {
+	MinSimilarity float64
+	Window        time.Duration
+}
+
+// Enabled returns true if the guard rejects the duplicates.
+func (g TopicDuplicateGuard) Enabled() bool {
+	return g.MinSimilarity > 0 && g.Window > 0
+}
+
+// TopicCounters selects the Topics whose numbers of Posts, and the Sections and Users whose numbers of Topics should
+// be recomputed: the given ones and those of the given Topics. The authors of the Topics in the given Sections and
+// the Sections of the Topics of the given Users are recomputed as well.
+type TopicCounters struct {
+	TopicIDs   []int64
+	SectionIDs []int64
+	UserIDs    []int64
+}
+
+// TrashPurge is a structure used to remove the Topics and Posts which were deleted before the given time for good.
+type TrashPurge struct {
+	DeletedBefore time.Time
+}
+
+// TrashPurgeResult contains the number of the Topics and Posts removed for good.
+type TrashPurgeResult struct {
+	Topics int64
+	Posts  int64
+}
+
+// TopicMerge is a structure used to move every Post of the source Topic into the target one.
+// The source Topic is kept as a stub which redirects to the target.
+type TopicMerge struct {
+	SourceID int64
+	TargetID int64
+}
+
+// TopicSplit is a structure used to create a new Topic out of the selected Posts of a single Topic.
+type TopicSplit struct {
+	PostIDs   []int64
+	Name      string
+	SectionID int64
+}
+
+// TopicRelocation describes the Posts moved from one Topic into another by a merge or a split.
+type TopicRelocation struct {
+	From    *Topic
+	To      *Topic
+	Authors []*PostAuthorCount
+}
+
+// AcceptedAnswerReputation is the reputation the author of an accepted answer gets.
+const AcceptedAnswerReputation int64 = 15
+
+// TopicAnswerAccept is a structure used to accept a Post as the answer to the question asked in a Topic,
+// a nil PostID withdraws the accepted answer.
+type TopicAnswerAccept struct {
+	TopicID int64
+	PostID  *int64
+}
+
+// TopicAnswerChange describes the change of the accepted answer of a Topic. Previous and Accepted are nil if there
+// was no accepted answer before or there is none now, respectively.
+type TopicAnswerChange struct {
+	Topic    *Topic
+	Previous *Post
+	Accepted *Post
+}
+
+// TopicAnswerWithdrawal describes an accepted answer withdrawn because its Post was deleted.
+type TopicAnswerWithdrawal struct {
+	TopicID      int64
+	TopicUserID  int64
+	AnswerUserID int64
+}
+
+// PutAcceptedAnswerFirst moves the accepted answer to the beginning of the Posts, adding it if it isn't there.
+func (t *Topic) PutAcceptedAnswerFirst(answer *Post) {
+	if t.AcceptedPostID == nil {
+		return
+	}
+
+	posts := make([]*Post, 0, len(t.Posts)+1)
+
+	for _, post := range t.Posts {
+		if post.ID == *t.AcceptedPostID {
+			answer = post
+		} else {
+			posts = append(posts, post)
+		}
+	}
+
+	if answer == nil {
+		return
+	}
+
+	t.Posts = append([]*Post{answer}, posts...)
+}
+
+// TopicRead contains the reading progress of the current User in a Topic.
+type TopicRead struct {
+	TopicID           int64
+	UnreadCount       int64
+	FirstUnreadPostID *int64
+}
+
+// TopicMarkRead is a structure used to mark every Post in the given Topics and Sections as read.
+type TopicMarkRead struct {
+	UserID     int64
+	TopicIDs   []int64
+	SectionIDs []int64
+}
+
 type TopicSort struct {
 	By    TopicSortBy
 	Order SortOrder
@@ -72,6 +225,17 @@ func TopicsEntityIDs(topics []*Topic) ([]int64, []int64, []int64) {
 	return ids, userIDs, sectionIDs
 }
 
+// TopicReadsMap returns a topic id => TopicRead map extracted out of reads.
+func TopicReadsMap(reads []*TopicRead) map[int64]*TopicRead {
+	readsMap := make(map[int64]*TopicRead, len(reads))
+
+	for _, read := range reads {
+		readsMap[read.TopicID] = read
+	}
+
+	return readsMap
+}
+
 // TopicsMap returns an id => Topic map extracted out of topics.
 func TopicsMap(topics []*Topic) map[int64]*Topic {
 	topicsMap := make(map[int64]*Topic, len(topics))
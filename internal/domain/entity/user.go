@@ -20,8 +20,6 @@ const (
 	UserRestrictionReadOnly UserRestriction = "READONLY"
 )
 
-const PostsPerRank int64 = 50
-
 // UserAdd is a structure used to insert a new User.
 type UserAdd struct {
 	Nickname string
@@ -29,6 +27,8 @@ type UserAdd struct {
 	ShowInfo bool
 
 	*UserInfo
+
+	ProfileFields []*ProfileFieldValueSet
 }
 
 // UserEdit is a structure used to edit an existing User.
@@ -44,14 +44,73 @@ type UserEdit struct {
 	Restriction *UserRestriction
 
 	*UserInfo
+
+	ProfileFields []*ProfileFieldValueSet
 }
 
 // UserInfo contains secondary information about a User.
+// The bio and the signature are always public, the other fields are shown according to their own visibility.
 type UserInfo struct {
 	Phone     *string
 	Email     *string
 	FirstName *string
 	LastName  *string
+	Bio       *string
+	Signature *string
+
+	PhoneVisibility     *ProfileVisibility
+	EmailVisibility     *ProfileVisibility
+	FirstNameVisibility *ProfileVisibility
+	LastNameVisibility  *ProfileVisibility
+}
+
+// DefaultVisibility sets the visibility of the fields which were not given one, the shown info becomes public and
+// the hidden one private.
+func (i *UserInfo) DefaultVisibility(showInfo bool) {
+	visibility := ProfileVisibilityPrivate
+	if showInfo {
+		visibility = ProfileVisibilityPublic
+	}
+
+	for _, v := range []**ProfileVisibility{
+		&i.PhoneVisibility, &i.EmailVisibility, &i.FirstNameVisibility, &i.LastNameVisibility,
+	} {
+		if *v == nil {
+			fieldVisibility := visibility
+			*v = &fieldVisibility
+		}
+	}
+}
+
+// HideFrom removes the fields of the owner's info which the viewer is not supposed to see.
+func (i *UserInfo) HideFrom(sess Session, ownerID int64) {
+	visible := func(v *ProfileVisibility) bool {
+		return v != nil && v.VisibleTo(sess, ownerID)
+	}
+
+	if !visible(i.PhoneVisibility) {
+		i.Phone = nil
+	}
+
+	if !visible(i.EmailVisibility) {
+		i.Email = nil
+	}
+
+	if !visible(i.FirstNameVisibility) {
+		i.FirstName = nil
+	}
+
+	if !visible(i.LastNameVisibility) {
+		i.LastName = nil
+	}
+
+	// Only the ones who see everything need to know the settings
+	if !ProfileVisibilityPrivate.VisibleTo(sess, ownerID) {
+		i.PhoneVisibility = nil
+		i.EmailVisibility = nil
+		i.FirstNameVisibility = nil
+		i.LastNameVisibility = nil
+	}
 }
 
 // User is a general structure representing a User.
@@ -60,20 +119,23 @@ type User struct {
 	Nickname    string
 	ShowInfo    bool
 	Rank        int64
+	Reputation  int64
 	Level       UserLevel
 	Restriction UserRestriction
 	CountTopics int64
 	CountPosts  int64
 	Topics      []*Topic
 	Posts       []*Post
+	Followers   []*User
+	Following   []*User
+	RankStep    *Rank
+	Badges      []*UserBadge
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 
 	*UserInfo
-}
 
-func (u *User) PostsUntilNextRank() int64 {
-	return u.Rank*PostsPerRank - u.CountPosts
+	ProfileFields []*ProfileFieldValue
 }
 
 type UserFilters struct {
@@ -0,0 +1,22 @@
+package entity
+
+// TopicWatch represents a subscription of a User to the new Posts in a Topic.
+type TopicWatch struct {
+	UserID  int64
+	TopicID int64
+}
+
+// SectionWatch represents a subscription of a User to the new Posts in a Section.
+type SectionWatch struct {
+	UserID    int64
+	SectionID int64
+}
+
+// WatchersNotificationAdd is a structure used to notify every watcher of a Topic or its Section at once.
+// Notification serves as a template, its UserID is ignored.
+type WatchersNotificationAdd struct {
+	TopicID      int64
+	SectionID    int64
+	AuthorID     int64
+	Notification *NotificationAdd
+}
@@ -0,0 +1,300 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// WebhookSignaturePrefix precedes the hex-encoded HMAC-SHA256 of the body in the signature of a Webhook request.
+const WebhookSignaturePrefix = "sha256="
+
+// WebhookEventTypes lists the types of Events which can be delivered to the Webhooks.
+var WebhookEventTypes = []EventType{
+	EventTypeUserRegistered,
+	EventTypeUserFollowed,
+	EventTypeUserLevelChanged,
+	EventTypeUserRestricted,
+	EventTypeRankAchieved,
+	EventTypeBadgeGranted,
+	EventTypePostCreated,
+	EventTypePostMoved,
+	EventTypePostReassigned,
+	EventTypePostRemoved,
+	EventTypePostRestored,
+	EventTypePostApproved,
+	EventTypePostRejected,
+	EventTypeAnswerAccepted,
+	EventTypeTopicCreated,
+	EventTypeTopicMoved,
+	EventTypeTopicReassigned,
+	EventTypeTopicRemoved,
+	EventTypeTopicRestored,
+	EventTypeTopicsMerged,
+	EventTypeTopicSplit,
+}
+
+// Webhook is an HTTP endpoint registered by the admins to receive the Events of the forum.
+// The Events are filtered by their types and Sections, an empty filter lets every Event through.
+// The Events which don't belong to a Section are only delivered to the Webhooks not filtered by Section.
+type Webhook struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	SectionIDs []int64
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookAdd is a structure used to register a new Webhook. A random Secret is generated if none is given.
+type WebhookAdd struct {
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	SectionIDs []int64
+	Active     bool
+}
+
+// WebhookEdit is a structure used to edit an existing Webhook. The nil filters are kept, the empty ones are cleared.
+type WebhookEdit struct {
+	ID         int64
+	URL        *string
+	Secret     *string
+	EventTypes []EventType
+	SectionIDs []int64
+	Active     *bool
+}
+
+// WebhookPayload is the body sent to the Webhooks for an Event. ID is unique to the Event, so the receivers
+// can tell the retries and the redeliveries apart from the new Events.
+type WebhookPayload struct {
+	ID        string
+	EventType EventType
+	Body      string
+}
+
+// WebhookDelivery is an attempt to deliver an Event to a Webhook, kept in the delivery log.
+// StatusCode is nil if no response was received, Error is set if the delivery failed.
+type WebhookDelivery struct {
+	ID         int64
+	WebhookID  int64
+	EventID    string
+	EventType  EventType
+	Payload    string
+	StatusCode *int64
+	Error      *string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+// WebhookDeliveryAdd is a structure used to log a new WebhookDelivery.
+type WebhookDeliveryAdd struct {
+	WebhookID  int64
+	EventID    string
+	EventType  EventType
+	Payload    string
+	StatusCode *int64
+	Error      *string
+	Duration   time.Duration
+}
+
+type WebhookDeliveryFilters struct {
+	IDs        []int64
+	WebhookIDs []int64
+	EventIDs   []string
+	EventTypes []EventType
+}
+
+// WebhookRequest is a signed request delivering a payload to a Webhook.
+type WebhookRequest struct {
+	URL       string
+	Signature string
+	Payload   *WebhookPayload
+}
+
+// Valid checks if the Events of the type can be delivered to the Webhooks.
+func (t EventType) Valid() bool {
+	for _, eventType := range WebhookEventTypes {
+		if eventType == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches checks if the Event of the type, which belongs to the Section if any, passes the filters of the Webhook.
+func (w *Webhook) Matches(eventType EventType, sectionID *int64) bool {
+	if len(w.EventTypes) > 0 {
+		matches := false
+
+		for _, t := range w.EventTypes {
+			if t == eventType {
+				matches = true
+
+				break
+			}
+		}
+
+		if !matches {
+			return false
+		}
+	}
+
+	if len(w.SectionIDs) == 0 {
+		return true
+	}
+
+	if sectionID == nil {
+		return false
+	}
+
+	for _, id := range w.SectionIDs {
+		if id == *sectionID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sign returns the signature of the body made with the secret of the Webhook, so the receiver can check
+// the request was sent by the forum.
+func (w *Webhook) Sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(body))
+
+	return WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Succeeded tells if the Webhook accepted the delivery.
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.Error == nil
+}
+
+// EventLocation returns the Topic and the Post the Event is about, if any. The Topic is nil if the Post is
+// carried without it, so it must be fetched by the TopicID of the Post.
+func EventLocation(e Event) (topic *Topic, post *Post) {
+	switch e := e.(type) {
+	case PostCreatedEvent:
+		post = e.Post
+	case PostMovedEvent:
+		post = e.Post
+	case PostReassignedEvent:
+		post = e.Post
+	case PostRemovedEvent:
+		post = e.Post
+	case PostRestoredEvent:
+		post = e.Post
+	case PostApprovedEvent:
+		post = e.Post
+	case PostRejectedEvent:
+		post = e.Post
+	case AnswerAcceptedEvent:
+		return e.Topic, e.Post
+	case TopicCreatedEvent:
+		return e.Topic, nil
+	case TopicMovedEvent:
+		return e.Topic, nil
+	case TopicReassignedEvent:
+		return e.Topic, nil
+	case TopicRemovedEvent:
+		return e.Topic, nil
+	case TopicRestoredEvent:
+		return e.Topic, nil
+	case TopicsMergedEvent:
+		return e.Relocation.To, nil
+	case TopicSplitEvent:
+		return e.Relocation.To, nil
+	}
+
+	if post != nil {
+		topic = post.Topic
+	}
+
+	return topic, post
+}
+
+// HidePrivateInfo removes the info which isn't public from the Users carried by the Event, as it is about to leave
+// the forum. The Users are left as a guest would see them.
+func HidePrivateInfo(e Event) {
+	switch e := e.(type) {
+	case UserRegisteredEvent:
+		hideUser(e.User)
+	case UserLevelChangedEvent:
+		hideUser(e.User)
+	case UserRestrictedEvent:
+		hideUser(e.User)
+	case PostCreatedEvent:
+		hidePost(e.Post)
+	case PostMovedEvent:
+		hidePost(e.Post)
+		hideTopic(e.From)
+	case PostReassignedEvent:
+		hidePost(e.Post)
+		hideUser(e.From)
+	case PostRemovedEvent:
+		hidePost(e.Post)
+	case PostRestoredEvent:
+		hidePost(e.Post)
+	case PostApprovedEvent:
+		hidePost(e.Post)
+	case PostRejectedEvent:
+		hidePost(e.Post)
+	case AnswerAcceptedEvent:
+		hideTopic(e.Topic)
+		hidePost(e.Post)
+	case TopicCreatedEvent:
+		hideTopic(e.Topic)
+	case TopicMovedEvent:
+		hideTopic(e.Topic)
+	case TopicReassignedEvent:
+		hideTopic(e.Topic)
+		hideUser(e.From)
+	case TopicRemovedEvent:
+		hideTopic(e.Topic)
+	case TopicRestoredEvent:
+		hideTopic(e.Topic)
+	case TopicsMergedEvent:
+		hideTopic(e.Relocation.From)
+		hideTopic(e.Relocation.To)
+	case TopicSplitEvent:
+		hideTopic(e.Relocation.From)
+		hideTopic(e.Relocation.To)
+	}
+}
+
+// hidePost hides the private info of the author of the Post and of its Topic.
+func hidePost(p *Post) {
+	if p == nil {
+		return
+	}
+
+	hideUser(p.User)
+	hideTopic(p.Topic)
+}
+
+// hideTopic hides the private info of the author of the Topic.
+func hideTopic(t *Topic) {
+	if t == nil {
+		return
+	}
+
+	hideUser(t.User)
+}
+
+// hideUser hides the private info of the User from a guest.
+func hideUser(u *User) {
+	if u == nil {
+		return
+	}
+
+	if u.UserInfo != nil {
+		u.UserInfo.HideFrom(Session{}, u.ID)
+	}
+
+	u.ProfileFields = nil
+}
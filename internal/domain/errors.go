@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/gocraft/dbr"
 	"github.com/lib/pq"
@@ -24,6 +25,7 @@ const (
 	ErrCodeAuthorized                            // 9
 	ErrCodeInvalidCredentials                    // 10
 	ErrCodeRestricted                            // 11
+	ErrCodeRateLimited                           // 12
 )
 
 var (
@@ -38,14 +40,17 @@ var (
 	ErrAuthorized         = &Error{Code: ErrCodeAuthorized, ErrorMessage: "You're already logged in"}
 	ErrInvalidCredentials = &Error{Code: ErrCodeInvalidCredentials}
 	ErrRestricted         = &Error{Code: ErrCodeRestricted, ErrorMessage: "You are restricted from doing it"}
+	ErrRateLimited        = &Error{Code: ErrCodeRateLimited}
 )
 
 // Error stores the information about an error.
+// RetryAfter is the number of seconds to wait before retrying, it is only set for the rate limited requests.
 type Error struct {
 	UUID         string  `json:"uuid"`
 	UserID       int64   `json:"user_id"`
 	Code         ErrCode `json:"code"`
 	ErrorMessage string  `json:"error_message"`
+	RetryAfter   int64   `json:"retry_after,omitempty"`
 	parent       error
 }
 
@@ -88,6 +93,16 @@ func NewError(code ErrCode, errorMessageFormat string, params ...interface{}) *E
 	return err
 }
 
+// NewRateLimitError creates an Error telling to retry after the given time, rounded up to a second.
+func NewRateLimitError(retryAfter time.Duration) *Error {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+
+	err := NewError(ErrCodeRateLimited, "Too many requests, try again in %d seconds", seconds)
+	err.RetryAfter = seconds
+
+	return err
+}
+
 // NewDBErrorWrap wraps the most common database produced errors.
 func NewDBErrorWrap(err error) error {
 	var opError *net.OpError
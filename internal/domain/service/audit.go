@@ -0,0 +1,94 @@
+package service
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/usecase"
+)
+
+// AuditService represents an audit log service.
+type AuditService struct {
+	repo AuditStorage
+
+	userAdapter usecase.UserAdapter
+
+	Service
+}
+
+// NewAuditService instantiates an AuditService.
+func NewAuditService(repo AuditStorage) *AuditService {
+	return &AuditService{
+		repo: repo,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+func (a *AuditService) AttachAdapters(userAdapter usecase.UserAdapter) {
+	a.userAdapter = userAdapter
+}
+
+// Record appends a privileged action of the current User to the audit log, along with the session it was taken in.
+func (a *AuditService) Record(sess entity.Session, e *entity.AuditEntryAdd) error {
+	e.ActorID = sess.UserID
+	e.SessionID = sess.ID
+
+	return a.repo.Insert(sess, e)
+}
+
+// All returns the entries of the audit log, the latest first.
+func (a *AuditService) All(sess entity.Session, f *entity.AuditFilters, p *entity.Pagination) ([]*entity.AuditEntry, error) {
+	// If pagination was not set, use default
+	if p == nil {
+		p = entity.DefaultPagination
+	}
+
+	var entries []*entity.AuditEntry
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		entries, err = a.repo.SelectAll(sess, f, p)
+		if err != nil {
+			return err
+		}
+
+		// If none were found, it's safe to return
+		if len(entries) == 0 {
+			return domain.NewError(domain.ErrCodeNotFound, "Audit log entries not found")
+		}
+
+		requestedFields := sess.RequestedFields
+
+		// If we wish to fetch the actors
+		if requestedFields.ContainsAny("actor") {
+			var users []*entity.User
+
+			// Recursively change the requested fields to those for users
+			sess.RequestedFields = requestedFields["actor"]
+
+			users, err = a.userAdapter.All(sess, &entity.UserFilters{
+				IDs: entity.AuditEntriesEntityIDs(entries),
+			}, nil, nil)
+
+			// Put the initial requested fields back
+			sess.RequestedFields = requestedFields
+
+			if err != nil {
+				return err
+			}
+
+			usersMap := entity.UsersMap(users)
+
+			for _, entry := range entries {
+				entry.Actor = usersMap[entry.ActorID]
+			}
+		}
+
+		return nil
+	})
+
+	return entries, err
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// badgeNamePattern matches the valid names of the Badges.
+var badgeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// BadgeService represents a Badge service.
+type BadgeService struct {
+	repo BadgeStorage
+
+	Service
+}
+
+// NewBadgeService instantiates a BadgeService.
+func NewBadgeService(repo BadgeStorage) *BadgeService {
+	return &BadgeService{
+		repo: repo,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+// Add defines a new Badge. It is granted to the Users who have already earned it as the rules are evaluated next.
+func (a *BadgeService) Add(sess entity.Session, e *entity.BadgeAdd) (int64, error) {
+	if !badgeNamePattern.MatchString(e.Name) {
+		return 0, domain.NewError(domain.ErrCodeValidation,
+			"The name of a badge must consist of lowercase letters, digits and underscores")
+	}
+
+	if !e.Rule.Valid() {
+		return 0, domain.NewError(domain.ErrCodeValidation, "Unknown badge rule %s", e.Rule)
+	}
+
+	err := a.validateThreshold(e.Threshold)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+
+	err = a.DoTransaction(sess, func(sess entity.Session) error {
+		// Check if the name is already taken
+		badges, err := a.repo.SelectAll(sess, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, badge := range badges {
+			if badge.Name == e.Name {
+				return domain.NewError(domain.ErrCodeAlreadyExists, "Badge %s already exists", e.Name)
+			}
+		}
+
+		id, err = a.repo.Insert(sess, e)
+
+		return err
+	})
+
+	return id, err
+}
+
+// Edit modifies an existing Badge. The Badges already granted are kept even if the new threshold isn't reached.
+func (a *BadgeService) Edit(sess entity.Session, e *entity.BadgeEdit) error {
+	if e.Threshold != nil {
+		err := a.validateThreshold(*e.Threshold)
+		if err != nil {
+			return err
+		}
+	}
+
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		_, err := a.ByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		return a.repo.Update(sess, e)
+	})
+}
+
+// Delete removes a Badge, taking it away from everyone it was granted to.
+func (a *BadgeService) Delete(sess entity.Session, id int64) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		_, err := a.ByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		return a.repo.Delete(sess, id)
+	})
+}
+
+// All returns every Badge defined.
+func (a *BadgeService) All(sess entity.Session) ([]*entity.Badge, error) {
+	return a.repo.SelectAll(sess, nil)
+}
+
+// ByID returns a Badge by its ID.
+func (a *BadgeService) ByID(sess entity.Session, id int64) (*entity.Badge, error) {
+	badge, err := a.repo.SelectByID(sess, id)
+	if err != nil {
+		var domainErr *domain.Error
+
+		if errors.As(err, &domainErr) && domainErr.Is(domain.ErrNotFound) {
+			domainErr.SetErrorMessage("Badge with ID %d not found", id)
+		}
+
+		return nil, err
+	}
+
+	return badge, nil
+}
+
+// UserBadges returns the Badges granted to the Users.
+func (a *BadgeService) UserBadges(sess entity.Session, userIDs []int64) ([]*entity.UserBadge, error) {
+	var userBadges []*entity.UserBadge
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		badges, err := a.repo.SelectAll(sess, nil)
+		if err != nil || len(badges) == 0 {
+			return err
+		}
+
+		userBadges, err = a.repo.SelectUserBadges(sess, userIDs)
+		if err != nil {
+			return err
+		}
+
+		badgesMap := entity.BadgesMap(badges)
+
+		for _, userBadge := range userBadges {
+			userBadge.Badge = badgesMap[userBadge.BadgeID]
+		}
+
+		return nil
+	})
+
+	return userBadges, err
+}
+
+// Grant evaluates the rules of the Badges and grants them to the Users who have earned them.
+// Every Badge is granted once, only the new grants are returned.
+func (a *BadgeService) Grant(sess entity.Session, e *entity.BadgeGrant) ([]*entity.UserBadge, error) {
+	var granted []*entity.UserBadge
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		badges, err := a.repo.SelectAll(sess, e.Rules)
+		if err != nil {
+			return err
+		}
+
+		for _, badge := range badges {
+			userIDs, err := a.repo.GrantEarned(sess, badge, e.UserIDs)
+			if err != nil {
+				return err
+			}
+
+			for _, userID := range userIDs {
+				granted = append(granted, &entity.UserBadge{
+					UserID:  userID,
+					BadgeID: badge.ID,
+					Badge:   badge,
+				})
+			}
+		}
+
+		return nil
+	})
+
+	return granted, err
+}
+
+// validateThreshold checks if a Badge can be earned with the threshold.
+func (a *BadgeService) validateThreshold(threshold int64) error {
+	if threshold < 1 {
+		return domain.NewError(domain.ErrCodeValidation, "The threshold of a badge must be positive")
+	}
+
+	return nil
+}
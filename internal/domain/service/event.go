@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"sync"
+	"time"
+)
+
+// broadcastBatchSize is the number of the broadcast messages read at once.
+const broadcastBatchSize = 100
+
+// eventSubscription is a subscriber of a type of Events.
+type eventSubscription struct {
+	name     string
+	delivery entity.EventDelivery
+	handler  entity.EventHandler
+}
+
+// EventService represents the in-process event bus delivering the domain Events to their subscribers,
+// the dispatcher of the Events stored in the outbox, and the receiver of the Events broadcast to every instance.
+type EventService struct {
+	Service
+	repo        OutboxStorage
+	broadcaster BroadcastStorage
+
+	rules         entity.OutboxRules
+	subscriptions map[entity.EventType][]*eventSubscription
+	subscribers   map[string]*eventSubscription
+	errorHandler  entity.EventErrorHandler
+	running       sync.WaitGroup
+
+	// receiving guards the number of the last broadcast message received, which is unknown until the first round
+	receiving   sync.Mutex
+	receivedSeq int64
+	receivedAny bool
+}
+
+// NewEventService instantiates an EventService. The errors of the asynchronous and broadcast subscribers are passed
+// to errorHandler, if any.
+func NewEventService(repo OutboxStorage, broadcaster BroadcastStorage, rules entity.OutboxRules,
+	errorHandler entity.EventErrorHandler) *EventService {
+	return &EventService{
+		Service:       Service{repo},
+		repo:          repo,
+		broadcaster:   broadcaster,
+		rules:         rules,
+		subscriptions: make(map[entity.EventType][]*eventSubscription),
+		subscribers:   make(map[string]*eventSubscription),
+		errorHandler:  errorHandler,
+	}
+}
+
+// Subscribe registers the handler for the given types of Events under a unique name, which identifies
+// the subscriber in the outbox. The subscribers are registered at bootstrap, before any Event is published.
+func (a *EventService) Subscribe(name string, delivery entity.EventDelivery, handler entity.EventHandler,
+	types ...entity.EventType) {
+	subscription := &eventSubscription{
+		name:     name,
+		delivery: delivery,
+		handler:  handler,
+	}
+
+	a.subscribers[name] = subscription
+
+	for _, t := range types {
+		a.subscriptions[t] = append(a.subscriptions[t], subscription)
+	}
+}
+
+// Publish delivers the Event to its subscribers in the order they were registered. The synchronous subscribers
+// are run right away and their first error is returned, the asynchronous ones are started once the transaction
+// of the Session is committed, or at once outside a transaction. For the outbox subscribers, the Event is stored
+// with the same Session, so it's kept only if the transaction is committed. For the broadcast subscribers, the Event
+// is broadcast to every instance in the background once the transaction is committed.
+func (a *EventService) Publish(sess entity.Session, e entity.Event) error {
+	var payload *string
+
+	// The Event is encoded once for every outbox and broadcast subscriber
+	encode := func() (string, error) {
+		if payload == nil {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return "", domain.NewErrorWrap(err, domain.ErrCodeInternal, "Cannot encode the event %s", e.EventType())
+			}
+
+			encoded := string(data)
+			payload = &encoded
+		}
+
+		return *payload, nil
+	}
+
+	for _, s := range a.subscriptions[e.EventType()] {
+		switch s.delivery {
+		case entity.EventDeliverySync:
+			err := s.handler(sess, e)
+			if err != nil {
+				return err
+			}
+		case entity.EventDeliveryAsync:
+			handler := s.handler
+			deliver := func() {
+				a.deliverAsync(sess, e, handler)
+			}
+
+			if !sess.Events.Defer(deliver) {
+				deliver()
+			}
+		case entity.EventDeliveryOutbox, entity.EventDeliveryExternal:
+			encoded, err := encode()
+			if err != nil {
+				return err
+			}
+
+			err = a.repo.Insert(sess, &entity.OutboxMessageAdd{
+				Subscriber: s.name,
+				EventType:  e.EventType(),
+				Payload:    encoded,
+				SessionID:  sess.ID,
+				UserID:     sess.UserID,
+				UserLevel:  sess.Level,
+			})
+			if err != nil {
+				return err
+			}
+		case entity.EventDeliveryBroadcast:
+			encoded, err := encode()
+			if err != nil {
+				return err
+			}
+
+			message := &entity.BroadcastMessageAdd{
+				Subscriber: s.name,
+				EventType:  e.EventType(),
+				Payload:    encoded,
+				SessionID:  sess.ID,
+			}
+			deliver := func() {
+				a.deliverAsync(sess, e, func(sess entity.Session, _ entity.Event) error {
+					return a.broadcaster.Insert(sess, message)
+				})
+			}
+
+			if !sess.Events.Defer(deliver) {
+				deliver()
+			}
+		}
+	}
+
+	return nil
+}
+
+// Wait blocks until every asynchronous delivery in progress is finished.
+func (a *EventService) Wait() {
+	a.running.Wait()
+}
+
+// Dispatch delivers a batch of the due outbox messages to their subscribers. A message is removed in the same
+// transaction as its subscriber runs in, so a delivered message is never delivered again, unless the subscriber
+// reaches outside the database. A failed one is retried after a backoff, or dead-lettered once it runs out
+// of attempts.
+func (a *EventService) Dispatch(sess entity.Session) (*entity.OutboxDispatchResult, error) {
+	messages, err := a.repo.Claim(sess, a.rules.BatchSize, a.rules.Lease)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Events published by the subscribers are delivered asynchronously once their transactions are committed
+	if sess.Events == nil {
+		sess.Events = &entity.EventQueue{}
+	}
+
+	result := &entity.OutboxDispatchResult{}
+
+	for _, message := range messages {
+		var deliveryErr error
+
+		deliveryErr, err = a.dispatch(sess, message)
+		if err != nil {
+			return result, err
+		}
+
+		if deliveryErr == nil {
+			result.Delivered++
+
+			continue
+		}
+
+		var (
+			attempts  = message.Attempts + 1
+			lastError = deliveryErr.Error()
+			edit      = &entity.OutboxMessageEdit{
+				ID:        message.ID,
+				Attempts:  &attempts,
+				LastError: &lastError,
+			}
+		)
+
+		if attempts >= a.rules.MaxAttempts {
+			status := entity.OutboxStatusDead
+			edit.Status = &status
+			result.Dead++
+		} else {
+			nextAttemptAt := time.Now().Add(a.rules.Backoff(attempts))
+			edit.NextAttemptAt = &nextAttemptAt
+			result.Retried++
+		}
+
+		err = a.repo.Update(sess, edit)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// dispatch delivers a single outbox message and removes it if it's delivered. The error of the subscriber is
+// returned apart from the error of the removal, which leaves the message claimed until the lease is over.
+func (a *EventService) dispatch(sess entity.Session, message *entity.OutboxMessage) (deliveryErr, err error) {
+	// The requests aren't made while a transaction is held, and the subscriber keeps track of them on its own
+	subscription, ok := a.subscribers[message.Subscriber]
+	if ok && subscription.delivery == entity.EventDeliveryExternal {
+		deliveryErr = a.deliverOutbox(sess, message)
+		if deliveryErr != nil {
+			return deliveryErr, nil
+		}
+
+		return nil, a.repo.Delete(sess, message.ID)
+	}
+
+	err = a.DoTransaction(sess, func(sess entity.Session) error {
+		deliveryErr = a.deliverOutbox(sess, message)
+		if deliveryErr != nil {
+			return deliveryErr
+		}
+
+		return a.repo.Delete(sess, message.ID)
+	})
+	if deliveryErr != nil {
+		return deliveryErr, nil
+	}
+
+	return nil, err
+}
+
+// Retry puts a dead-lettered or a failing outbox message back into the queue to be delivered right away,
+// with its attempts counted anew.
+func (a *EventService) Retry(sess entity.Session, id int64) error {
+	var (
+		status        = entity.OutboxStatusPending
+		attempts      int64
+		nextAttemptAt = time.Now()
+	)
+
+	_, err := a.OutboxMessageByID(sess, id)
+	if err != nil {
+		return err
+	}
+
+	return a.repo.Update(sess, &entity.OutboxMessageEdit{
+		ID:            id,
+		Status:        &status,
+		Attempts:      &attempts,
+		NextAttemptAt: &nextAttemptAt,
+	})
+}
+
+// OutboxMessages returns the outbox messages matching the filters.
+func (a *EventService) OutboxMessages(sess entity.Session, f *entity.OutboxFilters, p *entity.Pagination) ([]*entity.OutboxMessage, error) {
+	// If pagination was not set, use default
+	if p == nil {
+		p = entity.DefaultPagination
+	}
+
+	messages, err := a.repo.SelectAll(sess, f, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return messages, nil
+}
+
+// OutboxMessageByID returns an outbox message by its ID.
+func (a *EventService) OutboxMessageByID(sess entity.Session, id int64) (*entity.OutboxMessage, error) {
+	messages, err := a.repo.SelectAll(sess, &entity.OutboxFilters{
+		IDs: []int64{id},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) == 0 {
+		return nil, domain.NewError(domain.ErrCodeNotFound, "Outbox message with ID %d not found", id)
+	}
+
+	return messages[0], nil
+}
+
+// ListenBroadcasts returns a channel signalled once new messages may have been broadcast, until the context is done.
+func (a *EventService) ListenBroadcasts(ctx context.Context) <-chan struct{} {
+	return a.broadcaster.Listen(ctx)
+}
+
+// ReceiveBroadcasts delivers the messages broadcast since the last ones received to their subscribers on this
+// instance, in the order they were published. The first round only skips the messages broadcast before.
+// An error of a subscriber doesn't stop the delivery, it's passed to the error handler.
+func (a *EventService) ReceiveBroadcasts(sess entity.Session) (*entity.BroadcastReceiveResult, error) {
+	a.receiving.Lock()
+	defer a.receiving.Unlock()
+
+	result := &entity.BroadcastReceiveResult{}
+
+	if !a.receivedAny {
+		seq, err := a.broadcaster.SelectLastSeq(sess)
+		if err != nil {
+			return nil, err
+		}
+
+		a.receivedSeq = seq
+		a.receivedAny = true
+
+		return result, nil
+	}
+
+	for {
+		messages, err := a.broadcaster.SelectAfter(sess, a.receivedSeq, broadcastBatchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for _, message := range messages {
+			result.Missed += message.Seq - a.receivedSeq - 1
+			a.receivedSeq = message.Seq
+
+			if a.deliverBroadcast(sess.Ctx, message) {
+				result.Received++
+			} else {
+				result.Failed++
+			}
+		}
+
+		if len(messages) < broadcastBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// PruneBroadcasts removes the broadcast messages older than the given time and returns their number.
+func (a *EventService) PruneBroadcasts(sess entity.Session, before time.Time) (int64, error) {
+	return a.broadcaster.DeleteBefore(sess, before)
+}
+
+// deliverAsync runs the handler in the background. The Session is detached from the request and its transaction,
+// so the Events published by the handler are delivered on their own.
+func (a *EventService) deliverAsync(sess entity.Session, e entity.Event, handler entity.EventHandler) {
+	sess.Ctx = context.Background()
+	sess.Transaction = nil
+	sess.Events = nil
+	sess.RequestedFields = nil
+
+	a.running.Add(1)
+
+	go func() {
+		defer a.running.Done()
+
+		err := handler(sess, e)
+		if err != nil && a.errorHandler != nil {
+			a.errorHandler(sess, e, err)
+		}
+	}()
+}
+
+// deliverOutbox decodes the Event of the outbox message and runs its subscriber on behalf of the publisher,
+// in the transaction of the Session.
+func (a *EventService) deliverOutbox(sess entity.Session, message *entity.OutboxMessage) error {
+	subscription, ok := a.subscribers[message.Subscriber]
+	if !ok {
+		return domain.NewError(domain.ErrCodeInternal, "Unknown subscriber %s", message.Subscriber)
+	}
+
+	event, err := a.decode(message.EventType, message.Payload)
+	if err != nil {
+		return err
+	}
+
+	publisherSess := message.Session(sess.Ctx)
+	publisherSess.Transaction = sess.Transaction
+	publisherSess.Events = sess.Events
+
+	return subscription.handler(publisherSess, event)
+}
+
+// deliverBroadcast decodes the Event of the broadcast message and runs its subscriber, reporting the error if any.
+// The messages of the subscribers and Events unknown to this instance, such as those of a newer version, are skipped.
+func (a *EventService) deliverBroadcast(ctx context.Context, message *entity.BroadcastMessage) bool {
+	subscription, ok := a.subscribers[message.Subscriber]
+	if !ok || subscription.delivery != entity.EventDeliveryBroadcast {
+		return true
+	}
+
+	prototype, ok := entity.EventPrototypes[message.EventType]
+	if !ok {
+		return true
+	}
+
+	sess := message.Session(ctx)
+
+	event, err := a.decode(message.EventType, message.Payload)
+	if err == nil {
+		err = subscription.handler(sess, event)
+	} else {
+		event = prototype
+	}
+
+	if err != nil {
+		if a.errorHandler != nil {
+			a.errorHandler(sess, event, err)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// decode decodes the Event of the type out of its payload.
+func (a *EventService) decode(eventType entity.EventType, payload string) (entity.Event, error) {
+	prototype, ok := entity.EventPrototypes[eventType]
+	if !ok {
+		return nil, domain.NewError(domain.ErrCodeInternal, "Unknown event type %s", eventType)
+	}
+
+	// Decoding into a new value of the same type as the prototype
+	event := reflect.New(reflect.TypeOf(prototype))
+
+	err := json.Unmarshal([]byte(payload), event.Interface())
+	if err != nil {
+		return nil, domain.NewErrorWrap(err, domain.ErrCodeInternal, "Cannot decode the event %s", eventType)
+	}
+
+	return event.Elem().Interface().(entity.Event), nil
+}
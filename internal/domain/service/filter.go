@@ -0,0 +1,86 @@
+package service
+
+import (
+	"simplestforum/internal/domain/entity"
+)
+
+// FilterService represents a content filter service which runs the content through a chain of ContentFilters.
+type FilterService struct {
+	repo FilterStorage
+
+	filters []ContentFilter
+
+	Service
+}
+
+// NewFilterService instantiates a FilterService with the filters run in the given order.
+func NewFilterService(repo FilterStorage, filters []ContentFilter) *FilterService {
+	return &FilterService{
+		repo:    repo,
+		filters: filters,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+// Check runs the content through every filter until one of them rejects it, and logs the decisions.
+// The text of the content is not modified, the rewritten one is returned in the result.
+func (a *FilterService) Check(sess entity.Session, e *entity.FilterContent) (*entity.FilterResult, error) {
+	content := *e
+
+	res := &entity.FilterResult{
+		Verdict: entity.FilterVerdictAllow,
+	}
+
+	for _, filter := range a.filters {
+		decision, err := filter.Check(sess, &content)
+		if err != nil {
+			return nil, err
+		}
+
+		decision.Filter = filter.Name()
+		res.Decisions = append(res.Decisions, decision)
+
+		if decision.Verdict.StricterThan(res.Verdict) {
+			res.Verdict = decision.Verdict
+		}
+
+		// There is no point in checking rejected content any further
+		if res.Verdict == entity.FilterVerdictReject {
+			break
+		}
+	}
+
+	res.Text = content.Text
+
+	if len(res.Decisions) == 0 {
+		return res, nil
+	}
+
+	err := a.repo.InsertDecisions(sess, &entity.FilterDecisionLog{
+		Kind:      e.Kind,
+		UserID:    e.UserID,
+		Decisions: res.Decisions,
+	})
+
+	return res, err
+}
+
+// Train passes a piece of content judged by a moderator to every filter which learns from such decisions.
+func (a *FilterService) Train(sess entity.Session, e *entity.SpamSample) error {
+	for _, filter := range a.filters {
+		trainer, ok := filter.(ContentFilterTrainer)
+		if !ok {
+			continue
+		}
+
+		err := trainer.Train(sess, e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"errors"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/usecase"
+	"sort"
+)
+
+// FollowService represents a service of the follow and ignore lists between Users.
+type FollowService struct {
+	repo FollowStorage
+
+	userAdapter  usecase.UserAdapter
+	topicAdapter usecase.TopicAdapter
+	postAdapter  usecase.PostAdapter
+
+	Service
+}
+
+// NewFollowService instantiates a FollowService.
+func NewFollowService(repo FollowStorage) *FollowService {
+	return &FollowService{
+		repo: repo,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+func (a *FollowService) AttachAdapters(userAdapter usecase.UserAdapter, topicAdapter usecase.TopicAdapter,
+	postAdapter usecase.PostAdapter) {
+	a.userAdapter = userAdapter
+	a.topicAdapter = topicAdapter
+	a.postAdapter = postAdapter
+}
+
+// Follow subscribes a User to the new Topics and Posts of another one.
+func (a *FollowService) Follow(sess entity.Session, e *entity.UserFollow) error {
+	if e.UserID == e.FollowedUserID {
+		return domain.NewError(domain.ErrCodeValidation, "You can't follow yourself")
+	}
+
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		// Checking if the user exists
+		err := a.userAdapter.ExistsByID(sess, e.FollowedUserID)
+		if err != nil {
+			return err
+		}
+
+		ignores, err := a.Ignores(sess, e.UserID, e.FollowedUserID)
+		if err != nil {
+			return err
+		}
+
+		if ignores {
+			return domain.NewError(domain.ErrCodeValidation, "You can't follow a user you ignore")
+		}
+
+		return a.repo.InsertFollow(sess, e)
+	})
+}
+
+// Unfollow unsubscribes a User from another one.
+func (a *FollowService) Unfollow(sess entity.Session, e *entity.UserFollow) error {
+	return a.repo.DeleteFollow(sess, e)
+}
+
+// Ignore adds a User to the ignore list of another one, unfollowing them.
+// The moderators can't be ignored, as they have to be heard.
+func (a *FollowService) Ignore(sess entity.Session, e *entity.UserIgnore) error {
+	if e.UserID == e.IgnoredUserID {
+		return domain.NewError(domain.ErrCodeValidation, "You can't ignore yourself")
+	}
+
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		user, err := a.userAdapter.PlainByID(sess, e.IgnoredUserID)
+		if err != nil {
+			return err
+		}
+
+		if user.Level.AtLeast(entity.UserLevelMod) {
+			return domain.NewError(domain.ErrCodeValidation, "You can't ignore a moderator")
+		}
+
+		err = a.repo.DeleteFollow(sess, &entity.UserFollow{
+			UserID:         e.UserID,
+			FollowedUserID: e.IgnoredUserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return a.repo.InsertIgnore(sess, e)
+	})
+}
+
+// Unignore removes a User from the ignore list of another one.
+func (a *FollowService) Unignore(sess entity.Session, e *entity.UserIgnore) error {
+	return a.repo.DeleteIgnore(sess, e)
+}
+
+// Follows returns the follows matching the filters.
+func (a *FollowService) Follows(sess entity.Session, f *entity.UserFollowFilters) ([]*entity.UserFollow, error) {
+	return a.repo.SelectFollows(sess, f)
+}
+
+// IgnoredIDs returns the IDs of the Users the User ignores.
+func (a *FollowService) IgnoredIDs(sess entity.Session, userID int64) ([]int64, error) {
+	return a.repo.SelectIgnoredIDs(sess, userID)
+}
+
+// Ignores checks if the User ignores another one.
+func (a *FollowService) Ignores(sess entity.Session, userID, ignoredUserID int64) (bool, error) {
+	ids, err := a.IgnoredIDs(sess, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range ids {
+		if id == ignoredUserID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Feed returns the new Topics and Posts of the Users followed by the User, the newest first.
+func (a *FollowService) Feed(sess entity.Session, userID int64, p *entity.Pagination) ([]*entity.FeedItem, error) {
+	// If pagination was not set, use default
+	if p == nil {
+		p = entity.DefaultPagination
+	}
+
+	follows, err := a.repo.SelectFollows(sess, &entity.UserFollowFilters{
+		UserIDs: []int64{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	followedIDs := make([]int64, len(follows))
+
+	for i, follow := range follows {
+		followedIDs[i] = follow.FollowedUserID
+	}
+
+	if len(followedIDs) == 0 {
+		return nil, domain.NewError(domain.ErrCodeNotFound, "You don't follow anyone")
+	}
+
+	// The items of the page may be either Topics or Posts, so everything up to the end of the page is fetched
+	var (
+		window = &entity.Pagination{Limit: p.Page * p.Limit, Page: entity.DefaultPage}
+		items  []*entity.FeedItem
+	)
+
+	requestedFields := sess.RequestedFields
+
+	// Recursively change the requested fields to those for topics
+	sess.RequestedFields = requestedFields["topic"]
+
+	topics, err := a.topicAdapter.All(sess, &entity.TopicFilters{
+		UserIDs: followedIDs,
+	}, window, &entity.TopicSort{
+		By:    entity.TopicSortByCreatedAt,
+		Order: entity.SortOrderDesc,
+	})
+
+	// Put the initial requested fields back
+	sess.RequestedFields = requestedFields
+
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	for _, topic := range topics {
+		items = append(items, &entity.FeedItem{
+			Kind:      entity.FeedItemKindTopic,
+			UserID:    topic.UserID,
+			CreatedAt: topic.CreatedAt,
+			Topic:     topic,
+		})
+	}
+
+	// Recursively change the requested fields to those for posts
+	sess.RequestedFields = requestedFields["post"]
+
+	posts, err := a.postAdapter.All(sess, &entity.PostFilters{
+		UserIDs: followedIDs,
+	}, window, &entity.PostSort{
+		By:    entity.PostSortByCreatedAt,
+		Order: entity.SortOrderDesc,
+	})
+
+	// Put the initial requested fields back
+	sess.RequestedFields = requestedFields
+
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	for _, post := range posts {
+		items = append(items, &entity.FeedItem{
+			Kind:      entity.FeedItemKindPost,
+			UserID:    post.UserID,
+			CreatedAt: post.CreatedAt,
+			Post:      post,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.After(items[j].CreatedAt)
+	})
+
+	// Cutting the page out of the items
+	from := (p.Page - 1) * p.Limit
+	if from >= int64(len(items)) {
+		return nil, domain.NewError(domain.ErrCodeNotFound, "Activity not found")
+	}
+
+	to := from + p.Limit
+	if to > int64(len(items)) {
+		to = int64(len(items))
+	}
+
+	return items[from:to], nil
+}
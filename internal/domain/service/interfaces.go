@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"simplestforum/internal/domain/entity"
+	"time"
 )
 
 // UserStorage is an interface which declares methods to interact with any User storage.
@@ -18,6 +20,7 @@ type UserStorage interface {
 	InsertInfo(entity.Session, *entity.UserInfo, int64) error
 	UpdateInfo(entity.Session, *entity.UserInfo, int64) error
 	SelectByNicknameWithPassword(entity.Session, string) (*entity.User, string, error)
+	UpdateReputation(entity.Session, int64, int64) error
 }
 
 // SectionStorage is an interface which declares methods to interact with any Section storage.
@@ -42,6 +45,18 @@ type TopicStorage interface {
 	SelectAll(entity.Session, *entity.TopicFilters, *entity.Pagination, *entity.TopicSort) ([]*entity.Topic, error)
 
 	IDsToDelete(entity.Session, *entity.TopicDelete) ([]int64, error)
+
+	UpdateRedirect(entity.Session, int64, int64) error
+	UpdateAcceptedPost(entity.Session, int64, *int64) error
+	WithdrawAcceptedPosts(entity.Session, []int64) ([]*entity.TopicAnswerWithdrawal, error)
+	UpdateCounters(entity.Session, *entity.TopicCounters) error
+	Restore(entity.Session, int64) error
+	Purge(entity.Session, time.Time) (int64, error)
+
+	UpsertReads(entity.Session, *entity.TopicMarkRead) error
+	SelectReads(entity.Session, int64, []int64) ([]*entity.TopicRead, error)
+
+	SelectSimilar(entity.Session, *entity.TopicSimilarFilters) ([]*entity.SimilarTopic, error)
 }
 
 // PostStorage is an interface which declares methods to interact with any Post storage.
@@ -55,6 +70,17 @@ type PostStorage interface {
 	SelectAll(entity.Session, *entity.PostFilters, *entity.Pagination, *entity.PostSort) ([]*entity.Post, error)
 
 	IDsToDelete(entity.Session, *entity.PostDelete) ([]int64, error)
+
+	UpdateTopic(entity.Session, *entity.PostMove) error
+	SelectAuthorCounts(entity.Session, *entity.PostFilters) ([]*entity.PostAuthorCount, error)
+	UpdateCounters(entity.Session, *entity.PostCounters) error
+	Restore(entity.Session, ...int64) error
+	RestoreByTopic(entity.Session, int64, time.Time) error
+	Purge(entity.Session, time.Time) (int64, error)
+	UpdateStatus(entity.Session, int64, entity.PostStatus) error
+
+	InsertEdit(entity.Session, int64, int64) error
+	SelectContributors(entity.Session, []int64) ([]*entity.PostContributor, error)
 }
 
 // NotificationStorage is an interface which declares methods to interact with any Notification storage.
@@ -62,6 +88,186 @@ type NotificationStorage interface {
 	entity.Transactioner
 
 	Insert(entity.Session, *entity.NotificationAdd) (*entity.Notification, error)
+	InsertForWatchers(entity.Session, *entity.WatchersNotificationAdd) ([]*entity.Notification, error)
+	UpdateRead(entity.Session, *entity.NotificationMarkRead) error
 	DeleteByUserID(entity.Session, int64) error
 	SelectAllByUserID(entity.Session, *entity.Pagination, int64) ([]*entity.Notification, error)
+	SelectAfterByUserID(entity.Session, int64, int64, int64) ([]*entity.Notification, error)
+	CountUnreadByUserID(entity.Session, int64) (int64, error)
+
+	SelectPreferences(entity.Session, int64) ([]*entity.NotificationPreference, error)
+	UpsertPreference(entity.Session, *entity.NotificationPreferenceSet) error
+	InsertEmail(entity.Session, *entity.NotificationAdd, entity.NotificationEmailMode) error
+	SelectPendingEmails(entity.Session, entity.NotificationEmailMode, time.Time) ([]*entity.NotificationDigest, error)
+	ClaimEmails(entity.Session, []int64) ([]int64, error)
+	ReleaseEmails(entity.Session, []int64) error
+}
+
+// MailSender is an interface which declares methods to deliver emails.
+type MailSender interface {
+	Send(context.Context, *entity.Mail) error
+}
+
+// MailRenderer is an interface which declares methods to render emails.
+type MailRenderer interface {
+	RenderDigest(*entity.NotificationDigest) (*entity.Mail, error)
+}
+
+// ContentFilter is an interface which declares a check run on the content before it is saved.
+// Check may rewrite the text of the content.
+type ContentFilter interface {
+	Name() string
+	Check(entity.Session, *entity.FilterContent) (*entity.FilterDecision, error)
+}
+
+// ContentFilterTrainer is an interface implemented by the ContentFilters which learn from moderator decisions.
+type ContentFilterTrainer interface {
+	Train(entity.Session, *entity.SpamSample) error
+}
+
+// FilterStorage is an interface which declares methods to interact with any content filter storage.
+type FilterStorage interface {
+	entity.Transactioner
+
+	InsertDecisions(entity.Session, *entity.FilterDecisionLog) error
+}
+
+// RateLimitStorage is an interface which declares methods to interact with any token bucket storage.
+type RateLimitStorage interface {
+	// Take spends a token of the bucket and returns zero, or the time until the next token if none is left.
+	Take(entity.Session, *entity.RateLimitTake) (time.Duration, error)
+}
+
+// ProfileStorage is an interface which declares methods to interact with any custom profile field storage.
+type ProfileStorage interface {
+	entity.Transactioner
+
+	InsertField(entity.Session, *entity.ProfileFieldAdd) (int64, error)
+	UpdateField(entity.Session, *entity.ProfileFieldEdit) error
+	DeleteField(entity.Session, int64) error
+	SelectFieldByID(entity.Session, int64) (*entity.ProfileField, error)
+	SelectFields(entity.Session) ([]*entity.ProfileField, error)
+
+	UpsertValues(entity.Session, []*entity.ProfileFieldValue) error
+	DeleteValues(entity.Session, int64, []int64) error
+	SelectValues(entity.Session, []int64) ([]*entity.ProfileFieldValue, error)
+}
+
+// FollowStorage is an interface which declares methods to interact with any storage of the follow and ignore lists.
+type FollowStorage interface {
+	entity.Transactioner
+
+	InsertFollow(entity.Session, *entity.UserFollow) error
+	DeleteFollow(entity.Session, *entity.UserFollow) error
+	SelectFollows(entity.Session, *entity.UserFollowFilters) ([]*entity.UserFollow, error)
+
+	InsertIgnore(entity.Session, *entity.UserIgnore) error
+	DeleteIgnore(entity.Session, *entity.UserIgnore) error
+	SelectIgnoredIDs(entity.Session, int64) ([]int64, error)
+}
+
+// SearchEngine is an interface which declares methods to search the content.
+type SearchEngine interface {
+	Search(entity.Session, *entity.SearchQuery, *entity.Pagination) ([]*entity.SearchHit, error)
+}
+
+// WatchStorage is an interface which declares methods to interact with any Watch storage.
+type WatchStorage interface {
+	entity.Transactioner
+
+	InsertTopicWatch(entity.Session, *entity.TopicWatch) error
+	DeleteTopicWatch(entity.Session, *entity.TopicWatch) error
+	InsertSectionWatch(entity.Session, *entity.SectionWatch) error
+	DeleteSectionWatch(entity.Session, *entity.SectionWatch) error
+}
+
+// ReportStorage is an interface which declares methods to interact with any Report storage.
+type ReportStorage interface {
+	entity.Transactioner
+
+	Upsert(entity.Session, *entity.ReportAdd) (int64, error)
+	UpdateAssignee(entity.Session, *entity.ReportAssign) error
+	Resolve(entity.Session, *entity.ReportResolve) error
+	SelectByID(entity.Session, int64) (*entity.Report, error)
+	SelectAll(entity.Session, *entity.ReportFilters, *entity.Pagination) ([]*entity.Report, error)
+}
+
+// RankStorage is an interface which declares methods to interact with any rank ladder storage.
+type RankStorage interface {
+	entity.Transactioner
+
+	Insert(entity.Session, *entity.RankAdd) (int64, error)
+	Update(entity.Session, *entity.RankEdit) error
+	Delete(entity.Session, int64) error
+	SelectAll(entity.Session) ([]*entity.Rank, error)
+
+	// UpdateUserRanks recomputes the ranks of the Users, of everyone if none are given, and returns the number changed.
+	// Only the ranks up to the given one are recomputed, the ones above the ladder are given by the admins.
+	UpdateUserRanks(entity.Session, []int64, int64) (int64, error)
+}
+
+// BadgeStorage is an interface which declares methods to interact with any Badge storage.
+type BadgeStorage interface {
+	entity.Transactioner
+
+	Insert(entity.Session, *entity.BadgeAdd) (int64, error)
+	Update(entity.Session, *entity.BadgeEdit) error
+	Delete(entity.Session, int64) error
+	SelectByID(entity.Session, int64) (*entity.Badge, error)
+	SelectAll(entity.Session, []entity.BadgeRule) ([]*entity.Badge, error)
+
+	SelectUserBadges(entity.Session, []int64) ([]*entity.UserBadge, error)
+	GrantEarned(entity.Session, *entity.Badge, []int64) ([]int64, error)
+}
+
+// AuditStorage is an interface which declares methods to interact with any audit log storage.
+type AuditStorage interface {
+	entity.Transactioner
+
+	Insert(entity.Session, *entity.AuditEntryAdd) error
+	SelectAll(entity.Session, *entity.AuditFilters, *entity.Pagination) ([]*entity.AuditEntry, error)
+}
+
+// OutboxStorage is an interface which declares methods to interact with any outbox storage.
+type OutboxStorage interface {
+	entity.Transactioner
+
+	Insert(entity.Session, *entity.OutboxMessageAdd) error
+	Claim(entity.Session, int64, time.Duration) ([]*entity.OutboxMessage, error)
+	Update(entity.Session, *entity.OutboxMessageEdit) error
+	Delete(entity.Session, int64) error
+	SelectAll(entity.Session, *entity.OutboxFilters, *entity.Pagination) ([]*entity.OutboxMessage, error)
+}
+
+// BroadcastStorage is an interface which declares methods to interact with any storage of the broadcast messages,
+// shared by every instance which is to receive them.
+type BroadcastStorage interface {
+	// Insert stores a message under the next number. The messages are committed in the order of their numbers.
+	Insert(entity.Session, *entity.BroadcastMessageAdd) error
+	SelectAfter(entity.Session, int64, int64) ([]*entity.BroadcastMessage, error)
+	SelectLastSeq(entity.Session) (int64, error)
+	DeleteBefore(entity.Session, time.Time) (int64, error)
+
+	// Listen returns a channel signalled once new messages may have been stored, until the context is done.
+	Listen(context.Context) <-chan struct{}
+}
+
+// WebhookStorage is an interface which declares methods to interact with any Webhook storage.
+type WebhookStorage interface {
+	entity.Transactioner
+
+	Insert(entity.Session, *entity.WebhookAdd) (int64, error)
+	Update(entity.Session, *entity.WebhookEdit) error
+	Delete(entity.Session, int64) error
+	SelectByID(entity.Session, int64) (*entity.Webhook, error)
+	SelectAll(entity.Session, bool) ([]*entity.Webhook, error)
+
+	InsertDelivery(entity.Session, *entity.WebhookDeliveryAdd) (*entity.WebhookDelivery, error)
+	SelectDeliveries(entity.Session, *entity.WebhookDeliveryFilters, *entity.Pagination) ([]*entity.WebhookDelivery, error)
+}
+
+// WebhookSender is an interface which declares methods to send the requests to the Webhooks.
+// The status code of the response is returned, the error is only about the request not being answered.
+type WebhookSender interface {
+	Send(context.Context, *entity.WebhookRequest) (int64, error)
 }
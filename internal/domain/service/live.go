@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"simplestforum/internal/domain/entity"
+	"sync"
+)
+
+// liveBufferSize is the number of Events a listener may fall behind before the next ones are dropped for it.
+const liveBufferSize = 16
+
+// liveListener is a client listening to some types of Events.
+type liveListener struct {
+	types  map[entity.EventType]struct{}
+	events chan entity.Event
+}
+
+// LiveService represents the hub pushing the Events to the clients connected to this instance.
+// It receives the Events published on every instance through the broadcast.
+type LiveService struct {
+	mu        sync.RWMutex
+	listeners map[*liveListener]struct{}
+}
+
+// NewLiveService instantiates a LiveService.
+func NewLiveService() *LiveService {
+	return &LiveService{
+		listeners: make(map[*liveListener]struct{}),
+	}
+}
+
+// Listen returns a channel receiving the Events of the given types broadcast from now on.
+// The channel is closed once the context is done.
+func (a *LiveService) Listen(ctx context.Context, types ...entity.EventType) <-chan entity.Event {
+	listener := &liveListener{
+		types:  make(map[entity.EventType]struct{}, len(types)),
+		events: make(chan entity.Event, liveBufferSize),
+	}
+
+	for _, t := range types {
+		listener.types[t] = struct{}{}
+	}
+
+	a.mu.Lock()
+	a.listeners[listener] = struct{}{}
+	a.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		a.mu.Lock()
+		delete(a.listeners, listener)
+		close(listener.events)
+		a.mu.Unlock()
+	}()
+
+	return listener.events
+}
+
+// Push passes the Event to every listener of its type. It never blocks, so a listener which falls behind
+// misses the Event.
+func (a *LiveService) Push(_ entity.Session, e entity.Event) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for listener := range a.listeners {
+		if _, ok := listener.types[e.EventType()]; !ok {
+			continue
+		}
+
+		select {
+		case listener.events <- e:
+		default:
+		}
+	}
+
+	return nil
+}
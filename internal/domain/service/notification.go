@@ -2,28 +2,135 @@ package service
 
 import (
 	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/usecase"
+	"time"
 )
 
 // NotificationService represents a Section service.
 type NotificationService struct {
 	repo NotificationStorage
 
+	mailSender   MailSender
+	mailRenderer MailRenderer
+
+	rateLimitAdapter usecase.RateLimitAdapter
+	followAdapter    usecase.FollowAdapter
+
 	Service
 }
 
 // NewNotificationService instantiates a NotificationService.
-func NewNotificationService(repo NotificationStorage) *NotificationService {
+func NewNotificationService(repo NotificationStorage, mailSender MailSender, mailRenderer MailRenderer) *NotificationService {
 	return &NotificationService{
 		repo: repo,
 
+		mailSender:   mailSender,
+		mailRenderer: mailRenderer,
+
 		Service: Service{
 			repo,
 		},
 	}
 }
 
+func (a *NotificationService) AttachAdapters(rateLimitAdapter usecase.RateLimitAdapter, followAdapter usecase.FollowAdapter) {
+	a.rateLimitAdapter = rateLimitAdapter
+	a.followAdapter = followAdapter
+}
+
+// Add delivers a Notification through the channels chosen by its recipient.
+// The returned Notification is nil if the recipient has disabled in-app Notifications of its kind.
 func (a *NotificationService) Add(sess entity.Session, e *entity.NotificationAdd) (*entity.Notification, error) {
-	return a.repo.Insert(sess, e)
+	var notification *entity.Notification
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		preference, err := a.preference(sess, e.UserID, e.Kind)
+		if err != nil {
+			return err
+		}
+
+		// Creating the in-app notification
+		if preference.InApp {
+			notification, err = a.repo.Insert(sess, e)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Queueing the email
+		if preference.Email != entity.NotificationEmailModeNone {
+			return a.repo.InsertEmail(sess, e, preference.Email)
+		}
+
+		return nil
+	})
+
+	return notification, err
+}
+
+// AddForWatchers delivers a Notification to every watcher of the Topic and its Section.
+// The in-app Notifications created are returned.
+func (a *NotificationService) AddForWatchers(sess entity.Session, e *entity.WatchersNotificationAdd) ([]*entity.Notification, error) {
+	return a.repo.InsertForWatchers(sess, e)
+}
+
+// Limit spends a token of the Notification quota of the current User for every Notification the event sends
+// to the others. It's run along with the action, so the action is refused once the quota is exhausted.
+func (a *NotificationService) Limit(sess entity.Session, e entity.NotificationEvent) error {
+	for _, notification := range e.Notifications() {
+		if notification.ActorID == nil || *notification.ActorID != sess.UserID || notification.UserID == sess.UserID {
+			continue
+		}
+
+		err := a.rateLimitAdapter.Take(sess, entity.RateLimitActionNotification)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Notify delivers every Notification produced by the event and returns the in-app ones created.
+func (a *NotificationService) Notify(sess entity.Session, e entity.NotificationEvent) ([]*entity.Notification, error) {
+	var added []*entity.Notification
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		for _, notification := range e.Notifications() {
+			// The recipients don't hear from the Users they ignore, unless it is the moderation
+			if notification.ActorID != nil && *notification.ActorID != notification.UserID &&
+				!sess.Level.AtLeast(entity.UserLevelMod) {
+				ignores, err := a.followAdapter.Ignores(sess, notification.UserID, *notification.ActorID)
+				if err != nil {
+					return err
+				}
+
+				if ignores {
+					continue
+				}
+			}
+
+			created, err := a.Add(sess, notification)
+			if err != nil {
+				return err
+			}
+
+			if created != nil {
+				added = append(added, created)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+func (a *NotificationService) MarkRead(sess entity.Session, e *entity.NotificationMarkRead) error {
+	return a.repo.UpdateRead(sess, e)
 }
 
 func (a *NotificationService) Clear(sess entity.Session, userID int64) error {
@@ -33,3 +140,123 @@ func (a *NotificationService) Clear(sess entity.Session, userID int64) error {
 func (a *NotificationService) All(sess entity.Session, userID int64, p *entity.Pagination) ([]*entity.Notification, error) {
 	return a.repo.SelectAllByUserID(sess, p, userID)
 }
+
+// After returns up to limit Notifications of a User created or merged into after the change with the given number,
+// the oldest change first.
+func (a *NotificationService) After(sess entity.Session, userID, afterSeq, limit int64) ([]*entity.Notification, error) {
+	return a.repo.SelectAfterByUserID(sess, userID, afterSeq, limit)
+}
+
+func (a *NotificationService) CountUnread(sess entity.Session, userID int64) (int64, error) {
+	return a.repo.CountUnreadByUserID(sess, userID)
+}
+
+// Preferences returns the Notification preferences of a User for every kind, the defaults included.
+func (a *NotificationService) Preferences(sess entity.Session, userID int64) ([]*entity.NotificationPreference, error) {
+	configured, err := a.repo.SelectPreferences(sess, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	configuredMap := entity.NotificationPreferencesMap(configured)
+	preferences := make([]*entity.NotificationPreference, len(entity.NotificationKinds))
+
+	for i, kind := range entity.NotificationKinds {
+		preference, ok := configuredMap[kind]
+		if !ok {
+			preference = entity.DefaultNotificationPreference(kind)
+		}
+
+		preferences[i] = preference
+	}
+
+	return preferences, nil
+}
+
+// SetPreference changes the Notification preference of a User for a single kind and returns the result.
+func (a *NotificationService) SetPreference(sess entity.Session, e *entity.NotificationPreferenceSet) (*entity.NotificationPreference, error) {
+	var preference *entity.NotificationPreference
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		err := a.repo.UpsertPreference(sess, e)
+		if err != nil {
+			return err
+		}
+
+		preference, err = a.preference(sess, e.UserID, e.Kind)
+
+		return err
+	})
+
+	return preference, err
+}
+
+// SendPendingEmails renders and sends the queued emails of the given mode, a single email per User.
+// A failure to deliver to one User doesn't prevent the others from being processed; the first error is returned.
+func (a *NotificationService) SendPendingEmails(sess entity.Session, mode entity.NotificationEmailMode) error {
+	digests, err := a.repo.SelectPendingEmails(sess, mode, time.Now().Add(-mode.Period()))
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+
+	for _, digest := range digests {
+		err = a.sendDigest(sess, digest)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// sendDigest sends a single digest. Its emails are claimed before the sending, so a digest is never sent twice,
+// and returned to the queue if the sending fails.
+func (a *NotificationService) sendDigest(sess entity.Session, digest *entity.NotificationDigest) error {
+	claimed, err := a.repo.ClaimEmails(sess, digest.IDs())
+	if err != nil {
+		return err
+	}
+
+	// If the user has no address, there is nowhere to send the emails, so they are dropped
+	if len(claimed) == 0 || digest.Email == nil || *digest.Email == "" {
+		return nil
+	}
+
+	err = a.send(sess, digest.Only(claimed))
+	if err != nil {
+		if releaseErr := a.repo.ReleaseEmails(sess, claimed); releaseErr != nil {
+			return releaseErr
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// send renders and sends a digest.
+func (a *NotificationService) send(sess entity.Session, digest *entity.NotificationDigest) error {
+	mail, err := a.mailRenderer.RenderDigest(digest)
+	if err != nil {
+		return err
+	}
+
+	return a.mailSender.Send(sess.Ctx, mail)
+}
+
+// preference returns the Notification preference of a User for a single kind.
+func (a *NotificationService) preference(sess entity.Session, userID int64, kind entity.NotificationKind) (*entity.NotificationPreference, error) {
+	preferences, err := a.repo.SelectPreferences(sess, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preference, ok := entity.NotificationPreferencesMap(preferences)[kind]
+	if !ok {
+		preference = entity.DefaultNotificationPreference(kind)
+	}
+
+	return preference, nil
+}
@@ -0,0 +1,191 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	assets "simplestforum"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/service"
+	"simplestforum/internal/infrastructure/mail"
+	"simplestforum/internal/infrastructure/ratelimit"
+	"strings"
+	"testing"
+	"time"
+)
+
+// emailStorage keeps the queued emails of a single digest in memory.
+type emailStorage struct {
+	service.NotificationStorage
+
+	digest *entity.NotificationDigest
+	sent   map[int64]bool
+}
+
+func newEmailStorage() *emailStorage {
+	email := "reader@example.com"
+
+	return &emailStorage{
+		digest: &entity.NotificationDigest{
+			Mode:     entity.NotificationEmailModeDaily,
+			UserID:   1,
+			Nickname: "reader",
+			Email:    &email,
+			Notifications: []*entity.NotificationEmail{
+				{ID: 1, UserID: 1, Kind: entity.NotificationKindNewPost, Text: "first reply", CreatedAt: time.Now()},
+				{ID: 2, UserID: 1, Kind: entity.NotificationKindNewPost, Text: "second reply", CreatedAt: time.Now()},
+			},
+		},
+		sent: make(map[int64]bool),
+	}
+}
+
+// SelectPendingEmails returns the digest as the previous select saw it, even if its emails were claimed since.
+func (s *emailStorage) SelectPendingEmails(entity.Session, entity.NotificationEmailMode,
+	time.Time) ([]*entity.NotificationDigest, error) {
+	return []*entity.NotificationDigest{s.digest}, nil
+}
+
+func (s *emailStorage) ClaimEmails(_ entity.Session, ids []int64) ([]int64, error) {
+	var claimed []int64
+
+	for _, id := range ids {
+		if !s.sent[id] {
+			s.sent[id] = true
+			claimed = append(claimed, id)
+		}
+	}
+
+	return claimed, nil
+}
+
+func (s *emailStorage) ReleaseEmails(_ entity.Session, ids []int64) error {
+	for _, id := range ids {
+		delete(s.sent, id)
+	}
+
+	return nil
+}
+
+// failingSender fails to send every email.
+type failingSender struct{}
+
+func (failingSender) Send(context.Context, *entity.Mail) error {
+	return errors.New("connection refused")
+}
+
+func newRenderer(t *testing.T) *mail.Renderer {
+	t.Helper()
+
+	renderer, err := mail.NewRenderer(assets.MailTemplates, "templates/mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return renderer
+}
+
+func outbox(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+func TestSendPendingEmails(t *testing.T) {
+	dir := t.TempDir()
+
+	sender, err := mail.NewFileSender(dir, "forum@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storage := newEmailStorage()
+	notifications := service.NewNotificationService(storage, sender, newRenderer(t))
+	sess := entity.Session{Ctx: context.Background()}
+
+	err = notifications.SendPendingEmails(sess, entity.NotificationEmailModeDaily)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := outbox(t, dir)
+	if len(files) != 1 {
+		t.Fatalf("expected a single email in the outbox, got %d", len(files))
+	}
+
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"To: reader@example.com", "first reply", "second reply"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected the email to contain %q:\n%s", want, content)
+		}
+	}
+
+	// The emails are claimed, so a repeated run doesn't send the digest again
+	err = notifications.SendPendingEmails(sess, entity.NotificationEmailModeDaily)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if files = outbox(t, dir); len(files) != 1 {
+		t.Fatalf("expected the digest to be sent once, got %d emails", len(files))
+	}
+}
+
+func TestSendPendingEmailsFailure(t *testing.T) {
+	storage := newEmailStorage()
+	notifications := service.NewNotificationService(storage, failingSender{}, newRenderer(t))
+	sess := entity.Session{Ctx: context.Background()}
+
+	err := notifications.SendPendingEmails(sess, entity.NotificationEmailModeDaily)
+	if err == nil {
+		t.Fatal("expected the sending error")
+	}
+
+	// The emails which failed to be sent are returned to the queue
+	if len(storage.sent) != 0 {
+		t.Fatalf("expected the emails to be released, got %v", storage.sent)
+	}
+}
+
+func TestNotificationLimit(t *testing.T) {
+	rateLimits := service.NewRateLimitService(ratelimit.NewMemoryStore(), entity.RateLimitRules{
+		Quotas: map[entity.RateLimitAction]entity.RateLimitQuota{
+			entity.RateLimitActionNotification: {Burst: 1, Period: time.Hour},
+		},
+	})
+	notifications := service.NewNotificationService(nil, nil, nil)
+	notifications.AttachAdapters(rateLimits, nil)
+
+	sess := entity.Session{Ctx: context.Background(), UserID: 2, Level: entity.UserLevelNone}
+	removed := func(authorID int64) entity.PostRemovedEvent {
+		return entity.PostRemovedEvent{ActorID: 2, Post: &entity.Post{ID: 1, TopicID: 1, UserID: authorID}}
+	}
+
+	// The Notifications to oneself are free
+	if err := notifications.Limit(sess, removed(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := notifications.Limit(sess, removed(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The action is refused with the time to wait, rather than its Notification dropped
+	err := notifications.Limit(sess, removed(1))
+
+	var domainErr *domain.Error
+	if !errors.Is(err, domain.ErrRateLimited) || !errors.As(err, &domainErr) || domainErr.RetryAfter <= 0 {
+		t.Fatalf("expected a rate limit error with the time to wait, got %v", err)
+	}
+}
@@ -5,22 +5,29 @@ import (
 	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 	"simplestforum/internal/domain/usecase"
+	"time"
 )
 
 // PostService represents a Section service.
 type PostService struct {
 	repo PostStorage
 
-	userAdapter  usecase.UserAdapter
-	topicAdapter usecase.TopicAdapter
+	userAdapter   usecase.UserAdapter
+	topicAdapter  usecase.TopicAdapter
+	followAdapter usecase.FollowAdapter
+
+	premoderation entity.PremoderationRules
+	editRules     entity.PostEditRules
 
 	Service
 }
 
 // NewPostService instantiates a PostService.
-func NewPostService(repo PostStorage) *PostService {
+func NewPostService(repo PostStorage, premoderation entity.PremoderationRules, editRules entity.PostEditRules) *PostService {
 	return &PostService{
-		repo: repo,
+		repo:          repo,
+		premoderation: premoderation,
+		editRules:     editRules,
 
 		Service: Service{
 			repo,
@@ -28,22 +35,37 @@ func NewPostService(repo PostStorage) *PostService {
 	}
 }
 
-func (a *PostService) AttachAdapters(userAdapter usecase.UserAdapter, topicAdapter usecase.TopicAdapter) {
+func (a *PostService) AttachAdapters(userAdapter usecase.UserAdapter, topicAdapter usecase.TopicAdapter,
+	followAdapter usecase.FollowAdapter) {
 	a.userAdapter = userAdapter
 	a.topicAdapter = topicAdapter
+	a.followAdapter = followAdapter
 }
 
 // Add creates a new Post.
 func (a *PostService) Add(sess entity.Session, e *entity.PostAdd) (int64, error) {
 	var id int64
 
-	err := a.DoTransaction(sess, func() error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
 		// Checking if the topic exists
 		err := a.topicAdapter.ExistsByID(sess, e.TopicID)
 		if err != nil {
 			return err
 		}
 
+		// Holding the post for approval if the author or the text require it, the moderators are trusted
+		author, err := a.userAdapter.PlainByID(sess, e.UserID)
+		if err != nil {
+			return err
+		}
+
+		e.Status = entity.PostStatusPublished
+		flagged := e.Flagged && !author.Level.AtLeast(entity.UserLevelMod)
+
+		if flagged || a.premoderation.Holds(author, e.Text, time.Now()) {
+			e.Status = entity.PostStatusPending
+		}
+
 		// Inserting the post
 		id, err = a.repo.Insert(sess, e)
 
@@ -55,7 +77,7 @@ func (a *PostService) Add(sess entity.Session, e *entity.PostAdd) (int64, error)
 
 // Edit modifies an existing Post.
 func (a *PostService) Edit(sess entity.Session, e *entity.PostEdit) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		// Check if the ID is valid
 		err := a.existsByID(sess, e.ID)
 		if err != nil {
@@ -81,19 +103,35 @@ func (a *PostService) Edit(sess entity.Session, e *entity.PostEdit) error {
 		}
 
 		// Update the post
-		return a.repo.Update(sess, e)
+		err = a.repo.Update(sess, e)
+		if err != nil {
+			return err
+		}
+
+		// Every change of the text is attributed to the one who made it
+		if e.Text == nil {
+			return nil
+		}
+
+		return a.repo.InsertEdit(sess, e.ID, sess.UserID)
 	})
 }
 
 // Delete removes a single Post entry.
 func (a *PostService) Delete(sess entity.Session, id int64) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		// Check if the ID is valid
 		err := a.existsByID(sess, id)
 		if err != nil {
 			return err
 		}
 
+		// A deleted post is no longer the accepted answer
+		err = a.topicAdapter.WithdrawAnswers(sess, []int64{id})
+		if err != nil {
+			return err
+		}
+
 		// Delete the post
 		return a.repo.Delete(sess, id)
 	})
@@ -101,7 +139,7 @@ func (a *PostService) Delete(sess entity.Session, id int64) error {
 
 // MassDelete removes multiple Post entries.
 func (a *PostService) MassDelete(sess entity.Session, e *entity.PostDelete) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		var idsToDelete []int64
 
 		// Get the Ids of topics which are about to be deleted
@@ -117,11 +155,93 @@ func (a *PostService) MassDelete(sess entity.Session, e *entity.PostDelete) erro
 			}
 		}
 
+		// If none were found, there is nothing to delete
+		if len(idsToDelete) == 0 {
+			return nil
+		}
+
+		// The deleted posts are no longer the accepted answers
+		err := a.topicAdapter.WithdrawAnswers(sess, idsToDelete)
+		if err != nil {
+			return err
+		}
+
 		// Delete the posts
 		return a.repo.Delete(sess, idsToDelete...)
 	})
 }
 
+// Restore brings back a deleted Post. The Topic of the Post must not be deleted.
+func (a *PostService) Restore(sess entity.Session, id int64) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		post, err := a.repo.SelectByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		if post.DeletedAt == nil {
+			return domain.NewError(domain.ErrCodeValidation, "Post with ID %d is not deleted", id)
+		}
+
+		// The topic should be restored first
+		topic, err := a.topicAdapter.PlainByID(sess, &entity.PlainTopicByID{
+			ID: post.TopicID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if topic.DeletedAt != nil {
+			return domain.NewError(domain.ErrCodeValidation, "Topic with ID %d is deleted, restore it first", topic.ID)
+		}
+
+		// Restore the post
+		return a.repo.Restore(sess, id)
+	})
+}
+
+// RestoreByTopic brings back the Posts of the Topic deleted at or after the given time,
+// that is along with the Topic itself.
+func (a *PostService) RestoreByTopic(sess entity.Session, topicID int64, deletedSince time.Time) error {
+	return a.repo.RestoreByTopic(sess, topicID, deletedSince)
+}
+
+// Approve publishes a pending Post.
+func (a *PostService) Approve(sess entity.Session, id int64) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		post, err := a.repo.SelectByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		if post.Status != entity.PostStatusPending || post.DeletedAt != nil {
+			return domain.NewError(domain.ErrCodeValidation, "Post with ID %d is not pending", id)
+		}
+
+		return a.repo.UpdateStatus(sess, id, entity.PostStatusPublished)
+	})
+}
+
+// UpdateCounters recomputes the numbers of Posts of the selected Topics and Users.
+func (a *PostService) UpdateCounters(sess entity.Session, e *entity.PostCounters) error {
+	return a.repo.UpdateCounters(sess, e)
+}
+
+// Purge removes the Posts deleted before the given time for good and returns their number.
+func (a *PostService) Purge(sess entity.Session, deletedBefore time.Time) (int64, error) {
+	return a.repo.Purge(sess, deletedBefore)
+}
+
+// Move moves Posts into another Topic.
+func (a *PostService) Move(sess entity.Session, e *entity.PostMove) error {
+	return a.repo.UpdateTopic(sess, e)
+}
+
+// AuthorCounts returns the number of Posts matching the filters per author.
+func (a *PostService) AuthorCounts(sess entity.Session, f *entity.PostFilters) ([]*entity.PostAuthorCount, error) {
+	return a.repo.SelectAuthorCounts(sess, f)
+}
+
 // All fetches every Post row matching the given filters, pagination, sorting and request options.
 func (a *PostService) All(sess entity.Session, f *entity.PostFilters, p *entity.Pagination, s *entity.PostSort) ([]*entity.Post, error) {
 	// If pagination was not set, use default
@@ -137,10 +257,28 @@ func (a *PostService) All(sess entity.Session, f *entity.PostFilters, p *entity.
 		}
 	}
 
+	// Pending posts are only visible to their authors and the moderators
+	f = a.visibleFilters(sess, f)
+
 	var posts []*entity.Post
 
-	err := a.DoTransaction(sess, func() error {
-		var err error
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		var (
+			ignoredIDs []int64
+			err        error
+		)
+
+		// The Posts of the ignored Users are collapsed or hidden
+		if sess.IsAuthorized() {
+			ignoredIDs, err = a.followAdapter.IgnoredIDs(sess, sess.UserID)
+			if err != nil {
+				return err
+			}
+
+			if f.HideIgnored {
+				f.ExcludeUserIDs = ignoredIDs
+			}
+		}
 
 		// Select the posts
 		posts, err = a.repo.SelectAll(sess, f, p, s)
@@ -149,6 +287,14 @@ func (a *PostService) All(sess entity.Session, f *entity.PostFilters, p *entity.
 			return err
 		}
 
+		for _, post := range posts {
+			for _, id := range ignoredIDs {
+				if post.UserID == id {
+					post.AuthorIgnored = true
+				}
+			}
+		}
+
 		// If none were found, it's safe to return
 		if len(posts) == 0 {
 			return domain.NewError(domain.ErrCodeNotFound, "Posts not found")
@@ -194,8 +340,10 @@ func (a *PostService) All(sess entity.Session, f *entity.PostFilters, p *entity.
 			sess.RequestedFields = requestedFields["topic"]
 
 			// Fetch the topics
+			// Deleted posts may belong to deleted topics
 			topics, err = a.topicAdapter.All(sess, &entity.TopicFilters{
-				IDs: topicIDs,
+				IDs:            topicIDs,
+				IncludeDeleted: f != nil && f.Deleted,
 			}, nil, nil)
 
 			// Put the initial requested fields back
@@ -213,17 +361,117 @@ func (a *PostService) All(sess entity.Session, f *entity.PostFilters, p *entity.
 			}
 		}
 
+		// If we wish to fetch the contributors
+		if requestedFields.ContainsAny("contributors") {
+			return a.attachContributors(sess, postsMap)
+		}
+
 		return nil
 	})
 
 	return posts, err
 }
 
+// CheckEditable returns nil if the current User can edit the text of the Post: the moderators always can,
+// the author within the edit window unless it's a wiki Post, and the others of the wiki rank if it's one.
+func (a *PostService) CheckEditable(sess entity.Session, post *entity.Post) error {
+	switch {
+	case sess.Level.AtLeast(entity.UserLevelMod):
+		return nil
+	case post.UserID == sess.UserID:
+		if a.editRules.Expired(post, time.Now()) {
+			return domain.NewError(domain.ErrCodeForbidden, "The time to edit post #%d is over", post.ID)
+		}
+
+		return nil
+	case !post.Wiki:
+		return domain.ErrForbidden
+	}
+
+	user, err := a.userAdapter.PlainByID(sess, sess.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !a.editRules.WikiEditor(user) {
+		return domain.NewError(domain.ErrCodeForbidden, "Rank %d is needed to edit the wiki posts",
+			a.editRules.WikiMinRank)
+	}
+
+	return nil
+}
+
+// attachContributors attaches the Users who have edited the Posts to them.
+func (a *PostService) attachContributors(sess entity.Session, postsMap map[int64]*entity.Post) error {
+	postIDs := make([]int64, 0, len(postsMap))
+
+	for id := range postsMap {
+		postIDs = append(postIDs, id)
+	}
+
+	contributors, err := a.repo.SelectContributors(sess, postIDs)
+	if err != nil || len(contributors) == 0 {
+		return err
+	}
+
+	requestedFields := sess.RequestedFields["contributors"]
+
+	// If we wish to fetch the users
+	if requestedFields.ContainsAny("user") {
+		// Recursively change the requested fields to those for users
+		initialFields := sess.RequestedFields
+		sess.RequestedFields = requestedFields["user"]
+
+		users, err := a.userAdapter.All(sess, &entity.UserFilters{
+			IDs: entity.PostContributorsEntityIDs(contributors),
+		}, nil, nil)
+
+		// Put the initial requested fields back
+		sess.RequestedFields = initialFields
+
+		if err != nil {
+			return err
+		}
+
+		usersMap := entity.UsersMap(users)
+
+		for _, contributor := range contributors {
+			contributor.User = usersMap[contributor.UserID]
+		}
+	}
+
+	for _, contributor := range contributors {
+		post := postsMap[contributor.PostID]
+		post.Contributors = append(post.Contributors, contributor)
+	}
+
+	return nil
+}
+
+// visibleFilters returns a copy of the filters which hides the pending Posts the current User is not allowed to see.
+func (a *PostService) visibleFilters(sess entity.Session, f *entity.PostFilters) *entity.PostFilters {
+	var visible entity.PostFilters
+
+	if f != nil {
+		visible = *f
+	}
+
+	if sess.Level.AtLeast(entity.UserLevelMod) {
+		visible.IncludePending = true
+	} else {
+		visible.Pending = false
+		visible.IncludePending = false
+		visible.PendingAuthorID = sess.UserID
+	}
+
+	return &visible
+}
+
 // PlainByID returns a Post by its ID.
 func (a *PostService) PlainByID(sess entity.Session, e *entity.PlainPostByID) (*entity.Post, error) {
 	var post *entity.Post
 
-	err := a.DoTransaction(sess, func() error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
 		post, err = a.repo.SelectByID(sess, e.ID)
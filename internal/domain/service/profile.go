@@ -0,0 +1,274 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"sort"
+)
+
+// profileFieldNamePattern matches the valid names of the custom profile fields.
+var profileFieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ProfileService represents a custom profile field service.
+type ProfileService struct {
+	repo ProfileStorage
+
+	Service
+}
+
+// NewProfileService instantiates a ProfileService.
+func NewProfileService(repo ProfileStorage) *ProfileService {
+	return &ProfileService{
+		repo: repo,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+// AddField defines a new custom profile field.
+func (a *ProfileService) AddField(sess entity.Session, e *entity.ProfileFieldAdd) (int64, error) {
+	if !profileFieldNamePattern.MatchString(e.Name) {
+		return 0, domain.NewError(domain.ErrCodeValidation,
+			"The name of a profile field must consist of lowercase letters, digits and underscores")
+	}
+
+	err := a.validateField(e.Type, e.Pattern, e.MaxLength, e.Options)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+
+	err = a.DoTransaction(sess, func(sess entity.Session) error {
+		// Check if the name is already taken
+		fields, err := a.repo.SelectFields(sess)
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			if field.Name == e.Name {
+				return domain.NewError(domain.ErrCodeAlreadyExists, "Profile field %s already exists", e.Name)
+			}
+		}
+
+		id, err = a.repo.InsertField(sess, e)
+
+		return err
+	})
+
+	return id, err
+}
+
+// EditField modifies an existing custom profile field. The values already given are kept even if they don't
+// pass the new validation.
+func (a *ProfileService) EditField(sess entity.Session, e *entity.ProfileFieldEdit) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		field, err := a.FieldByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		// Validating the field as it is going to be
+		if e.Pattern != nil {
+			field.Pattern = e.Pattern
+			if *e.Pattern == "" {
+				field.Pattern = nil
+			}
+		}
+
+		if e.MaxLength != nil {
+			field.MaxLength = e.MaxLength
+			if *e.MaxLength == 0 {
+				field.MaxLength = nil
+			}
+		}
+
+		if e.Options != nil {
+			field.Options = e.Options
+		}
+
+		err = a.validateField(field.Type, field.Pattern, field.MaxLength, field.Options)
+		if err != nil {
+			return err
+		}
+
+		return a.repo.UpdateField(sess, e)
+	})
+}
+
+// DeleteField removes a custom profile field along with its values.
+func (a *ProfileService) DeleteField(sess entity.Session, id int64) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		_, err := a.FieldByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		return a.repo.DeleteField(sess, id)
+	})
+}
+
+// Fields returns all custom profile fields in the order they are shown in.
+func (a *ProfileService) Fields(sess entity.Session) ([]*entity.ProfileField, error) {
+	return a.repo.SelectFields(sess)
+}
+
+// FieldByID returns a custom profile field by its ID.
+func (a *ProfileService) FieldByID(sess entity.Session, id int64) (*entity.ProfileField, error) {
+	field, err := a.repo.SelectFieldByID(sess, id)
+	if err != nil {
+		var domainErr *domain.Error
+
+		if errors.As(err, &domainErr) && domainErr.Is(domain.ErrNotFound) {
+			domainErr.SetErrorMessage("Profile field with ID %d not found", id)
+		}
+
+		return nil, err
+	}
+
+	return field, nil
+}
+
+// SetValues validates and saves the values a User gives to the custom profile fields, an empty value removes one.
+// When registering, every required field must be given a value.
+func (a *ProfileService) SetValues(sess entity.Session, userID int64, values []*entity.ProfileFieldValueSet,
+	registering bool) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		fields, err := a.repo.SelectFields(sess)
+		if err != nil {
+			return err
+		}
+
+		var (
+			fieldsMap = entity.ProfileFieldsMap(fields)
+			given     = make(map[int64]bool, len(values))
+			upserts   []*entity.ProfileFieldValue
+			deletes   []int64
+		)
+
+		for _, value := range values {
+			field, ok := fieldsMap[value.FieldID]
+			if !ok {
+				return domain.NewError(domain.ErrCodeNotFound, "Profile field with ID %d not found", value.FieldID)
+			}
+
+			given[field.ID] = true
+
+			if value.Value == "" {
+				if field.Required {
+					return domain.NewError(domain.ErrCodeValidation, "%s is required", field.Title)
+				}
+
+				deletes = append(deletes, field.ID)
+
+				continue
+			}
+
+			err = field.Validate(value.Value)
+			if err != nil {
+				return err
+			}
+
+			upserts = append(upserts, &entity.ProfileFieldValue{
+				UserID:  userID,
+				FieldID: field.ID,
+				Value:   value.Value,
+			})
+		}
+
+		// The fields added later are only required from the new Users
+		if registering {
+			for _, field := range fields {
+				if field.Required && !given[field.ID] {
+					return domain.NewError(domain.ErrCodeValidation, "%s is required", field.Title)
+				}
+			}
+		}
+
+		if len(upserts) > 0 {
+			err = a.repo.UpsertValues(sess, upserts)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(deletes) > 0 {
+			return a.repo.DeleteValues(sess, userID, deletes)
+		}
+
+		return nil
+	})
+}
+
+// Values returns the values of the custom profile fields of the Users which the current User is allowed to see,
+// in the order the fields are shown in.
+func (a *ProfileService) Values(sess entity.Session, userIDs []int64) ([]*entity.ProfileFieldValue, error) {
+	var visible []*entity.ProfileFieldValue
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		fields, err := a.repo.SelectFields(sess)
+		if err != nil || len(fields) == 0 {
+			return err
+		}
+
+		values, err := a.repo.SelectValues(sess, userIDs)
+		if err != nil {
+			return err
+		}
+
+		// Remembering the order of the fields
+		fieldsMap := entity.ProfileFieldsMap(fields)
+		order := make(map[int64]int, len(fields))
+
+		for i, field := range fields {
+			order[field.ID] = i
+		}
+
+		for _, value := range values {
+			value.Field = fieldsMap[value.FieldID]
+
+			if value.Field.Visibility.VisibleTo(sess, value.UserID) {
+				visible = append(visible, value)
+			}
+		}
+
+		sort.SliceStable(visible, func(i, j int) bool {
+			return order[visible[i].FieldID] < order[visible[j].FieldID]
+		})
+
+		return nil
+	})
+
+	return visible, err
+}
+
+// validateField checks if the restrictions of a custom profile field make sense.
+func (a *ProfileService) validateField(fieldType entity.ProfileFieldType, pattern *string, maxLength *int64,
+	options []string) error {
+	if fieldType == entity.ProfileFieldTypeSelect && len(options) == 0 {
+		return domain.NewError(domain.ErrCodeValidation, "A select profile field must have options")
+	}
+
+	if fieldType != entity.ProfileFieldTypeSelect && len(options) > 0 {
+		return domain.NewError(domain.ErrCodeValidation, "Only a select profile field can have options")
+	}
+
+	if maxLength != nil && (*maxLength < 1 || *maxLength > entity.ProfileFieldValueMaxLength) {
+		return domain.NewError(domain.ErrCodeValidation, "The maximum length must be between 1 and %d",
+			entity.ProfileFieldValueMaxLength)
+	}
+
+	if pattern != nil {
+		_, err := regexp.Compile(*pattern)
+		if err != nil {
+			return domain.NewError(domain.ErrCodeValidation, "The pattern is not a valid regular expression")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"sync"
+)
+
+// RankService represents a rank ladder service. The ladder is read on every listing of the Users, so it's cached
+// until it's changed on any instance.
+type RankService struct {
+	repo RankStorage
+
+	// cache holds the ladder read outside a transaction, version tells the ladders read before a change apart
+	mu      sync.RWMutex
+	cache   entity.RankLadder
+	cached  bool
+	version int64
+
+	Service
+}
+
+// NewRankService instantiates a RankService.
+func NewRankService(repo RankStorage) *RankService {
+	return &RankService{
+		repo: repo,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+// Add adds a new step to the rank ladder. The ranks of the Users on the ladder are recomputed to follow it.
+func (a *RankService) Add(sess entity.Session, e *entity.RankAdd) (int64, error) {
+	err := a.validate(e.MinPosts, e.Perks)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+
+	err = a.DoTransaction(sess, func(sess entity.Session) error {
+		ladder, err := a.Ladder(sess)
+		if err != nil {
+			return err
+		}
+
+		err = a.minPostsAvailable(sess, 0, e.MinPosts)
+		if err != nil {
+			return err
+		}
+
+		id, err = a.repo.Insert(sess, e)
+		if err != nil {
+			return err
+		}
+
+		// The ranks above the ladder before the new step were given by the admins
+		_, err = a.repo.UpdateUserRanks(sess, nil, int64(len(ladder)))
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	a.forget()
+
+	return id, nil
+}
+
+// Edit modifies an existing step of the rank ladder. The ranks of the Users on the ladder are recomputed to follow it.
+func (a *RankService) Edit(sess entity.Session, e *entity.RankEdit) error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		rank, err := a.ByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		if e.MinPosts != nil && *e.MinPosts != rank.MinPosts {
+			err = a.minPostsAvailable(sess, e.ID, *e.MinPosts)
+			if err != nil {
+				return err
+			}
+
+			rank.MinPosts = *e.MinPosts
+		}
+
+		if e.Perks != nil {
+			rank.Perks = e.Perks
+		}
+
+		err = a.validate(rank.MinPosts, rank.Perks)
+		if err != nil {
+			return err
+		}
+
+		err = a.repo.Update(sess, e)
+		if err != nil {
+			return err
+		}
+
+		_, err = a.recalculate(sess, nil)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	a.forget()
+
+	return nil
+}
+
+// Delete removes a step of the rank ladder. The ranks of the Users on the ladder are recomputed to follow it.
+func (a *RankService) Delete(sess entity.Session, id int64) error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		ladder, err := a.Ladder(sess)
+		if err != nil {
+			return err
+		}
+
+		_, err = a.ByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = a.repo.Delete(sess, id)
+		if err != nil {
+			return err
+		}
+
+		// The ranks above the ladder before the step was removed were given by the admins
+		_, err = a.repo.UpdateUserRanks(sess, nil, int64(len(ladder)))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	a.forget()
+
+	return nil
+}
+
+// Ladder returns the whole rank ladder, each step numbered with the rank it gives.
+// Inside a transaction, the ladder is always read, so the changes made by the transaction are seen.
+func (a *RankService) Ladder(sess entity.Session) (entity.RankLadder, error) {
+	a.mu.RLock()
+	cache, cached, version := a.cache, a.cached, a.version
+	a.mu.RUnlock()
+
+	if cached && sess.Transaction == nil {
+		return cache.Copy(), nil
+	}
+
+	ranks, err := a.repo.SelectAll(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rank := range ranks {
+		rank.Number = int64(i + 1)
+	}
+
+	if sess.Transaction != nil {
+		return ranks, nil
+	}
+
+	// The ladder changed meanwhile is not cached, since it could have been read before the change
+	a.mu.Lock()
+	if a.version == version {
+		a.cache = entity.RankLadder(ranks).Copy()
+		a.cached = true
+	}
+	a.mu.Unlock()
+
+	return ranks, nil
+}
+
+// ForgetLadder drops the cached ladder once it's changed on any instance.
+func (a *RankService) ForgetLadder(_ entity.Session, _ entity.Event) error {
+	a.forget()
+
+	return nil
+}
+
+// ByID returns a step of the rank ladder by its ID.
+func (a *RankService) ByID(sess entity.Session, id int64) (*entity.Rank, error) {
+	ladder, err := a.Ladder(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rank := range ladder {
+		if rank.ID == id {
+			return rank, nil
+		}
+	}
+
+	return nil, domain.NewError(domain.ErrCodeNotFound, "Rank with ID %d not found", id)
+}
+
+// Recalculate brings the ranks of the given Users, or of everyone if none are given, in line with the ladder
+// and returns the number of Users whose rank has changed. The ranks above the ladder, given by the admins, are kept.
+func (a *RankService) Recalculate(sess entity.Session, userIDs ...int64) (int64, error) {
+	var count int64
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		count, err = a.recalculate(sess, userIDs)
+
+		return err
+	})
+
+	return count, err
+}
+
+// recalculate brings the ranks of the given Users on the current ladder in line with it.
+func (a *RankService) recalculate(sess entity.Session, userIDs []int64) (int64, error) {
+	ladder, err := a.Ladder(sess)
+	if err != nil {
+		return 0, err
+	}
+
+	return a.repo.UpdateUserRanks(sess, userIDs, int64(len(ladder)))
+}
+
+// validate checks if a step of the rank ladder makes sense.
+func (a *RankService) validate(minPosts int64, perks []entity.RankPerk) error {
+	if minPosts < 0 {
+		return domain.NewError(domain.ErrCodeValidation, "The number of posts of a rank must not be negative")
+	}
+
+	for _, perk := range perks {
+		if perk != entity.RankPerkSignature && perk != entity.RankPerkAvatar {
+			return domain.NewError(domain.ErrCodeValidation, "Unknown rank perk %s", perk)
+		}
+	}
+
+	return nil
+}
+
+// minPostsAvailable returns nil if no other step of the ladder is reached with the same number of posts.
+func (a *RankService) minPostsAvailable(sess entity.Session, id, minPosts int64) error {
+	ladder, err := a.Ladder(sess)
+	if err != nil {
+		return err
+	}
+
+	for _, rank := range ladder {
+		if rank.ID != id && rank.MinPosts == minPosts {
+			return domain.NewError(domain.ErrCodeAlreadyExists, "Rank %s is already reached with %d posts",
+				rank.Name(), minPosts)
+		}
+	}
+
+	return nil
+}
+
+// forget drops the cached ladder, so it's read anew.
+func (a *RankService) forget() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.cache = nil
+	a.cached = false
+	a.version++
+}
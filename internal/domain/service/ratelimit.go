@@ -0,0 +1,77 @@
+package service
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/usecase"
+	"time"
+)
+
+// RateLimitService represents a rate limit service which keeps a token bucket per User or client and action.
+type RateLimitService struct {
+	repo RateLimitStorage
+
+	userAdapter usecase.UserAdapter
+
+	rules entity.RateLimitRules
+}
+
+// NewRateLimitService instantiates a RateLimitService.
+func NewRateLimitService(repo RateLimitStorage, rules entity.RateLimitRules) *RateLimitService {
+	return &RateLimitService{
+		repo:  repo,
+		rules: rules,
+	}
+}
+
+func (a *RateLimitService) AttachAdapters(userAdapter usecase.UserAdapter) {
+	a.userAdapter = userAdapter
+}
+
+// Take spends a token of the action quota of the current User, or of the client for the guests.
+// If the quota is exhausted, an error telling when to retry is returned.
+func (a *RateLimitService) Take(sess entity.Session, action entity.RateLimitAction) error {
+	var (
+		key   string
+		quota entity.RateLimitQuota
+	)
+
+	if sess.IsAuthorized() {
+		var rank int64
+
+		// The rank is only needed if the trusted Users have their own quotas
+		if a.rules.TrustedRank > 0 {
+			user, err := a.userAdapter.PlainByID(sess, sess.UserID)
+			if err != nil {
+				return err
+			}
+
+			rank = user.Rank
+		}
+
+		key = entity.RateLimitUserKey(action, sess.UserID)
+		quota = a.rules.Quota(action, sess.Level, rank)
+	} else {
+		key = entity.RateLimitClientKey(action, sess.ClientAddr)
+		quota = a.rules.Quota(action, entity.UserLevelNone, 0)
+	}
+
+	if quota.Unlimited() {
+		return nil
+	}
+
+	retryAfter, err := a.repo.Take(sess, &entity.RateLimitTake{
+		Key:   key,
+		Quota: quota,
+		Now:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if retryAfter > 0 {
+		return domain.NewRateLimitError(retryAfter)
+	}
+
+	return nil
+}
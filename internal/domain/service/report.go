@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/usecase"
+)
+
+// ReportService represents a Report service.
+type ReportService struct {
+	repo ReportStorage
+
+	userAdapter  usecase.UserAdapter
+	topicAdapter usecase.TopicAdapter
+	postAdapter  usecase.PostAdapter
+
+	Service
+}
+
+// NewReportService instantiates a ReportService.
+func NewReportService(repo ReportStorage) *ReportService {
+	return &ReportService{
+		repo: repo,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+func (a *ReportService) AttachAdapters(userAdapter usecase.UserAdapter, topicAdapter usecase.TopicAdapter,
+	postAdapter usecase.PostAdapter) {
+	a.userAdapter = userAdapter
+	a.topicAdapter = topicAdapter
+	a.postAdapter = postAdapter
+}
+
+// Add files a new Report, or updates the open one the reporter already has about the same target.
+func (a *ReportService) Add(sess entity.Session, e *entity.ReportAdd) (int64, error) {
+	var id int64
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		// Checking if the target exists and finding out its author
+		e.TargetUserID, err = a.targetUserID(sess, e.TargetType, e.TargetID)
+		if err != nil {
+			return err
+		}
+
+		if e.TargetUserID == e.ReporterID {
+			return domain.NewError(domain.ErrCodeValidation, "You can't report yourself")
+		}
+
+		id, err = a.repo.Upsert(sess, e)
+
+		return err
+	})
+
+	return id, err
+}
+
+// Assign assigns an open Report to a moderator, or unassigns it.
+func (a *ReportService) Assign(sess entity.Session, e *entity.ReportAssign) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		_, err := a.openByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		// Only the moderators can handle the reports
+		if e.AssigneeID != nil {
+			assignee, err := a.userAdapter.PlainByID(sess, *e.AssigneeID)
+			if err != nil {
+				return err
+			}
+
+			if !assignee.Level.AtLeast(entity.UserLevelMod) {
+				return domain.NewError(domain.ErrCodeValidation, "User with ID %d is not a moderator", assignee.ID)
+			}
+		}
+
+		return a.repo.UpdateAssignee(sess, e)
+	})
+}
+
+// Resolve closes an open Report. Applying the action itself is up to the caller.
+func (a *ReportService) Resolve(sess entity.Session, e *entity.ReportResolve) error {
+	if e.Status != entity.ReportStatusResolved && e.Status != entity.ReportStatusDismissed {
+		return domain.NewError(domain.ErrCodeValidation, "A report can only be resolved or dismissed")
+	}
+
+	if e.Action != nil && e.Status != entity.ReportStatusResolved {
+		return domain.NewError(domain.ErrCodeValidation, "A dismissed report can't have an action")
+	}
+
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		_, err := a.openByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		return a.repo.Resolve(sess, e)
+	})
+}
+
+// All returns all Reports.
+func (a *ReportService) All(sess entity.Session, f *entity.ReportFilters, p *entity.Pagination) ([]*entity.Report, error) {
+	return a.repo.SelectAll(sess, f, p)
+}
+
+// PlainByID returns a Report by its ID.
+func (a *ReportService) PlainByID(sess entity.Session, id int64) (*entity.Report, error) {
+	report, err := a.repo.SelectByID(sess, id)
+	if err != nil {
+		var domainErr *domain.Error
+
+		if errors.As(err, &domainErr) && domainErr.Is(domain.ErrNotFound) {
+			domainErr.SetErrorMessage("Report with ID %d not found", id)
+		}
+
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// openByID returns a Report by its ID if it is still open.
+func (a *ReportService) openByID(sess entity.Session, id int64) (*entity.Report, error) {
+	report, err := a.PlainByID(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != entity.ReportStatusOpen {
+		return nil, domain.NewError(domain.ErrCodeValidation, "Report with ID %d is already closed", id)
+	}
+
+	return report, nil
+}
+
+// targetUserID returns the author of the reported Post or Topic, or the reported User themselves.
+func (a *ReportService) targetUserID(sess entity.Session, targetType entity.ReportTargetType, targetID int64) (int64, error) {
+	switch targetType {
+	case entity.ReportTargetTypePost:
+		post, err := a.postAdapter.PlainByID(sess, &entity.PlainPostByID{
+			ID: targetID,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		// The Posts which can't be seen can't be reported either
+		if !post.VisibleTo(sess) {
+			return 0, domain.NewError(domain.ErrCodeNotFound, "Post with ID %d not found", targetID)
+		}
+
+		return post.UserID, nil
+	case entity.ReportTargetTypeTopic:
+		topic, err := a.topicAdapter.PlainByID(sess, &entity.PlainTopicByID{
+			ID: targetID,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if topic.DeletedAt != nil {
+			return 0, domain.NewError(domain.ErrCodeNotFound, "Topic with ID %d not found", targetID)
+		}
+
+		return topic.UserID, nil
+	case entity.ReportTargetTypeUser:
+		return targetID, a.userAdapter.ExistsByID(sess, targetID)
+	}
+
+	return 0, domain.NewError(domain.ErrCodeValidation, "Unknown report target type %s", targetType)
+}
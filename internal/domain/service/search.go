@@ -0,0 +1,98 @@
+package service
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/usecase"
+)
+
+// SearchService represents a search service.
+type SearchService struct {
+	engine SearchEngine
+
+	topicAdapter usecase.TopicAdapter
+	postAdapter  usecase.PostAdapter
+}
+
+// NewSearchService instantiates a SearchService.
+func NewSearchService(engine SearchEngine) *SearchService {
+	return &SearchService{
+		engine: engine,
+	}
+}
+
+func (a *SearchService) AttachAdapters(topicAdapter usecase.TopicAdapter, postAdapter usecase.PostAdapter) {
+	a.topicAdapter = topicAdapter
+	a.postAdapter = postAdapter
+}
+
+// Search finds the content matching the query and attaches the requested Posts and Topics to the hits.
+func (a *SearchService) Search(sess entity.Session, e *entity.SearchQuery, p *entity.Pagination) ([]*entity.SearchHit, error) {
+	// If pagination was not set, use default
+	if p == nil {
+		p = entity.DefaultPagination
+	}
+
+	hits, err := a.engine.Search(sess, e, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// If none were found, it's safe to return
+	if len(hits) == 0 {
+		return nil, domain.NewError(domain.ErrCodeNotFound, "Nothing was found")
+	}
+
+	postIDs, topicIDs := entity.SearchHitsEntityIDs(hits)
+	requestedFields := sess.RequestedFields
+
+	// If we wish to fetch posts
+	if len(postIDs) > 0 && requestedFields.ContainsAny("post") {
+		// Recursively change the requested fields to those for posts
+		sess.RequestedFields = requestedFields["post"]
+
+		posts, err := a.postAdapter.All(sess, &entity.PostFilters{
+			IDs: postIDs,
+		}, &entity.Pagination{Limit: int64(len(postIDs)), Page: entity.DefaultPage}, nil)
+
+		// Put the initial requested fields back
+		sess.RequestedFields = requestedFields
+
+		if err != nil {
+			return nil, err
+		}
+
+		postsMap := entity.PostsMap(posts)
+
+		for _, hit := range hits {
+			if hit.Kind == entity.SearchHitKindPost {
+				hit.Post = postsMap[hit.ID]
+			}
+		}
+	}
+
+	// If we wish to fetch topics
+	if requestedFields.ContainsAny("topic") {
+		// Recursively change the requested fields to those for topics
+		sess.RequestedFields = requestedFields["topic"]
+
+		topics, err := a.topicAdapter.All(sess, &entity.TopicFilters{
+			IDs: topicIDs,
+		}, &entity.Pagination{Limit: int64(len(topicIDs)), Page: entity.DefaultPage}, nil)
+
+		// Put the initial requested fields back
+		sess.RequestedFields = requestedFields
+
+		if err != nil {
+			return nil, err
+		}
+
+		topicsMap := entity.TopicsMap(topics)
+
+		for _, hit := range hits {
+			hit.Topic = topicsMap[hit.TopicID]
+		}
+	}
+
+	return hits, nil
+}
@@ -50,7 +50,7 @@ func (a *SectionService) Edit(sess entity.Session, e *entity.SectionEdit) error
 
 // Delete removes a single Section entry along with the entities (topics) which depend on it.
 func (a *SectionService) Delete(sess entity.Session, id int64) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		// Check if the ID is valid
 		err := a.ExistsByID(sess, id)
 		if err != nil {
@@ -87,7 +87,7 @@ func (a *SectionService) All(sess entity.Session, f *entity.SectionFilters, p *e
 
 	var sections []*entity.Section
 
-	err := a.DoTransaction(sess, func() error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
 		// Select the sections
@@ -17,12 +17,39 @@ type Storages struct {
 	Topic        TopicStorage
 	Post         PostStorage
 	Notification NotificationStorage
+	Watch        WatchStorage
+	Report       ReportStorage
+	Filter       FilterStorage
+	RateLimit    RateLimitStorage
+	Search       SearchEngine
+	Profile      ProfileStorage
+	Follow       FollowStorage
+	Rank         RankStorage
+	Badge        BadgeStorage
+	Audit        AuditStorage
+	Outbox       OutboxStorage
+	Webhook      WebhookStorage
+	Broadcast    BroadcastStorage
+
+	MailSender   MailSender
+	MailRenderer MailRenderer
+
+	WebhookSender WebhookSender
+
+	Premoderation       entity.PremoderationRules
+	PostEditRules       entity.PostEditRules
+	TopicDuplicateGuard entity.TopicDuplicateGuard
+	ContentFilters      []ContentFilter
+	RateLimitRules      entity.RateLimitRules
+	OutboxRules         entity.OutboxRules
+	EventErrors         entity.EventErrorHandler
 }
 
 // DoTransaction allows to wrap multiple service calls into a transaction.
-func (s *Service) DoTransaction(sess entity.Session, f func() error) (err error) {
+// The calls must be made with the Session passed to f, which carries the transaction.
+func (s *Service) DoTransaction(sess entity.Session, f func(entity.Session) error) (err error) {
 	if sess.Transaction != nil {
-		return f()
+		return f(sess)
 	}
 
 	tx, err := s.NewTransaction(sess.Ctx)
@@ -32,15 +59,25 @@ func (s *Service) DoTransaction(sess entity.Session, f func() error) (err error)
 
 	defer tx.RollbackUnlessCommitted()
 
+	// The Events published inside are delivered asynchronously only if the transaction is committed
+	mark := sess.Events.Begin()
+
 	sess.Transaction = tx
-	err = f()
-	sess.Transaction = nil
+	err = f(sess)
+
+	if err == nil {
+		err = tx.Commit()
+	}
 
 	if err != nil {
+		sess.Events.Rollback(mark)
+
 		return err
 	}
 
-	return tx.Commit()
+	sess.Events.Commit(mark)
+
+	return nil
 }
 
 // NewServices creates a list of all abstract Services.
@@ -48,15 +85,35 @@ func NewServices(r *Storages) *usecase.Adapters {
 	a := &usecase.Adapters{
 		User:         NewUserService(r.User),
 		Section:      NewSectionService(r.Section),
-		Topic:        NewTopicService(r.Topic),
-		Post:         NewPostService(r.Post),
-		Notification: NewNotificationService(r.Notification),
+		Topic:        NewTopicService(r.Topic, r.TopicDuplicateGuard),
+		Post:         NewPostService(r.Post, r.Premoderation, r.PostEditRules),
+		Notification: NewNotificationService(r.Notification, r.MailSender, r.MailRenderer),
+		Watch:        NewWatchService(r.Watch),
+		Report:       NewReportService(r.Report),
+		Filter:       NewFilterService(r.Filter, r.ContentFilters),
+		RateLimit:    NewRateLimitService(r.RateLimit, r.RateLimitRules),
+		Search:       NewSearchService(r.Search),
+		Profile:      NewProfileService(r.Profile),
+		Follow:       NewFollowService(r.Follow),
+		Rank:         NewRankService(r.Rank),
+		Badge:        NewBadgeService(r.Badge),
+		Audit:        NewAuditService(r.Audit),
+		Event:        NewEventService(r.Outbox, r.Broadcast, r.OutboxRules, r.EventErrors),
+		Webhook:      NewWebhookService(r.Webhook, r.WebhookSender),
+		Live:         NewLiveService(),
 	}
 
-	a.User.AttachAdapters(a.Topic, a.Post)
+	a.User.AttachAdapters(a.Topic, a.Post, a.Profile, a.Follow, a.Rank, a.Badge)
 	a.Section.AttachAdapters(a.Topic)
 	a.Topic.AttachAdapters(a.User, a.Section, a.Post)
-	a.Post.AttachAdapters(a.User, a.Topic)
+	a.Post.AttachAdapters(a.User, a.Topic, a.Follow)
+	a.Watch.AttachAdapters(a.Topic, a.Section)
+	a.Report.AttachAdapters(a.User, a.Topic, a.Post)
+	a.Notification.AttachAdapters(a.RateLimit, a.Follow)
+	a.RateLimit.AttachAdapters(a.User)
+	a.Search.AttachAdapters(a.Topic, a.Post)
+	a.Follow.AttachAdapters(a.User, a.Topic, a.Post)
+	a.Audit.AttachAdapters(a.User)
 
 	return a
 }
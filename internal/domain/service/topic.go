@@ -5,6 +5,7 @@ import (
 	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 	"simplestforum/internal/domain/usecase"
+	"time"
 )
 
 // TopicService represents a Section service.
@@ -15,13 +16,16 @@ type TopicService struct {
 	sectionAdapter usecase.SectionAdapter
 	postAdapter    usecase.PostAdapter
 
+	duplicateGuard entity.TopicDuplicateGuard
+
 	Service
 }
 
 // NewTopicService instantiates a TopicService.
-func NewTopicService(repo TopicStorage) *TopicService {
+func NewTopicService(repo TopicStorage, duplicateGuard entity.TopicDuplicateGuard) *TopicService {
 	return &TopicService{
-		repo: repo,
+		repo:           repo,
+		duplicateGuard: duplicateGuard,
 
 		Service: Service{
 			repo,
@@ -39,13 +43,21 @@ func (a *TopicService) AttachAdapters(userAdapter usecase.UserAdapter, sectionAd
 func (a *TopicService) Add(sess entity.Session, e *entity.TopicAdd) (int64, error) {
 	var id int64
 
-	err := a.DoTransaction(sess, func() error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
 		// Checking if the section exists
 		err := a.sectionAdapter.ExistsByID(sess, e.SectionID)
 		if err != nil {
 			return err
 		}
 
+		// Rejecting the duplicates of the recent topics, the moderators are trusted
+		if a.duplicateGuard.Enabled() && !sess.Level.AtLeast(entity.UserLevelMod) {
+			err = a.checkDuplicate(sess, e)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Inserting the topic
 		id, err = a.repo.Insert(sess, e)
 
@@ -57,7 +69,7 @@ func (a *TopicService) Add(sess entity.Session, e *entity.TopicAdd) (int64, erro
 
 // Edit modifies an existing Topic.
 func (a *TopicService) Edit(sess entity.Session, e *entity.TopicEdit) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		// Check if the ID is valid
 		err := a.ExistsByID(sess, e.ID)
 		if err != nil {
@@ -89,7 +101,7 @@ func (a *TopicService) Edit(sess entity.Session, e *entity.TopicEdit) error {
 
 // Delete removes a single Topic entry along with the entities (posts) which depend on it.
 func (a *TopicService) Delete(sess entity.Session, id int64) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		// Check if the ID is valid
 		err := a.ExistsByID(sess, id)
 		if err != nil {
@@ -102,7 +114,7 @@ func (a *TopicService) Delete(sess entity.Session, id int64) error {
 			return err
 		}
 
-		// Delete all its topics
+		// Delete all its posts
 		return a.postAdapter.MassDelete(sess, &entity.PostDelete{
 			TopicIDs: []int64{id},
 		})
@@ -111,7 +123,7 @@ func (a *TopicService) Delete(sess entity.Session, id int64) error {
 
 // MassDelete removes multiple Topic entries along with the entities (posts) which depend on them.
 func (a *TopicService) MassDelete(sess entity.Session, e *entity.TopicDelete) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		var (
 			idsToDelete []int64
 			err         error
@@ -128,19 +140,65 @@ func (a *TopicService) MassDelete(sess entity.Session, e *entity.TopicDelete) er
 			}
 		}
 
+		// If none were found, there is nothing to delete along with them
+		if len(idsToDelete) == 0 {
+			return nil
+		}
+
 		// Delete the topics
 		err = a.repo.Delete(sess, idsToDelete...)
 		if err != nil {
 			return err
 		}
 
-		// Delete all their topics
+		// Delete all their posts
 		return a.postAdapter.MassDelete(sess, &entity.PostDelete{
 			TopicIDs: idsToDelete,
 		})
 	})
 }
 
+// Similar returns the Topics similar to the given name, the most similar first, with the requested fields attached.
+func (a *TopicService) Similar(sess entity.Session, f *entity.TopicSimilarFilters) ([]*entity.SimilarTopic, error) {
+	if f.Limit <= 0 {
+		f.Limit = entity.SimilarTopicsLimit
+	}
+
+	similar, err := a.repo.SelectSimilar(sess, f)
+	if err != nil {
+		return nil, err
+	}
+
+	// If none were found, it's safe to return
+	if len(similar) == 0 {
+		return nil, domain.NewError(domain.ErrCodeNotFound, "Topics not found")
+	}
+
+	ids := make([]int64, len(similar))
+
+	for i, topic := range similar {
+		ids[i] = topic.TopicID
+	}
+
+	// Fetching the topics with any embedded fields requested for them
+	sess.RequestedFields = sess.RequestedFields["topic"]
+
+	topics, err := a.All(sess, &entity.TopicFilters{
+		IDs: ids,
+	}, &entity.Pagination{Limit: int64(len(ids)), Page: entity.DefaultPage}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	topicsMap := entity.TopicsMap(topics)
+
+	for _, topic := range similar {
+		topic.Topic = topicsMap[topic.TopicID]
+	}
+
+	return similar, nil
+}
+
 // All fetches every Topic row matching the given filters, pagination, sorting and request options.
 func (a *TopicService) All(sess entity.Session, f *entity.TopicFilters, p *entity.Pagination, s *entity.TopicSort) ([]*entity.Topic, error) {
 	// If pagination was not set, use default
@@ -158,7 +216,7 @@ func (a *TopicService) All(sess entity.Session, f *entity.TopicFilters, p *entit
 
 	var topics []*entity.Topic
 
-	err := a.DoTransaction(sess, func() error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
 		// Select the topics
@@ -232,6 +290,9 @@ func (a *TopicService) All(sess entity.Session, f *entity.TopicFilters, p *entit
 			}
 		}
 
+		// The posts of the topics are always the first page
+		postsPage := entity.DefaultPagination
+
 		// If we wish to fetch posts
 		if requestedFields.ContainsAny("posts") {
 			var posts []*entity.Post
@@ -242,7 +303,7 @@ func (a *TopicService) All(sess entity.Session, f *entity.TopicFilters, p *entit
 			// Fetch the posts
 			posts, err = a.postAdapter.All(sess, &entity.PostFilters{
 				TopicIDs: topicIDs,
-			}, nil, nil)
+			}, postsPage, nil)
 
 			// Put the initial requested fields back
 			sess.RequestedFields = requestedFields
@@ -258,17 +319,538 @@ func (a *TopicService) All(sess entity.Session, f *entity.TopicFilters, p *entit
 			}
 		}
 
+		// If we wish to fetch the accepted answers, or to show them first among the posts
+		if requestedFields.ContainsAny("accepted_answer", "posts") {
+			err = a.attachAcceptedAnswers(sess, topicsMap, postsPage)
+			if err != nil {
+				return err
+			}
+		}
+
+		// If we wish to know what the current user hasn't read yet
+		if sess.IsAuthorized() && requestedFields.ContainsAny("unread_count", "first_unread_post") {
+			return a.attachReads(sess, topicsMap, topicIDs)
+		}
+
 		return nil
 	})
 
 	return topics, err
 }
 
+// MarkRead marks the Topics as read up to their latest Posts.
+func (a *TopicService) MarkRead(sess entity.Session, e *entity.TopicMarkRead) error {
+	return a.repo.UpsertReads(sess, e)
+}
+
+// Restore brings back a deleted Topic along with the Posts which were deleted with it.
+// The Section of the Topic must not be deleted.
+func (a *TopicService) Restore(sess entity.Session, id int64) (*entity.Topic, error) {
+	var topic *entity.Topic
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		topic, err = a.repo.SelectByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		if topic.DeletedAt == nil {
+			return domain.NewError(domain.ErrCodeValidation, "Topic with ID %d is not deleted", id)
+		}
+
+		// The section should be restored first
+		section, err := a.sectionAdapter.PlainByID(sess, topic.SectionID)
+		if err != nil {
+			return err
+		}
+
+		if section.DeletedAt != nil {
+			return domain.NewError(domain.ErrCodeValidation, "Section with ID %d is deleted, restore it first", section.ID)
+		}
+
+		// Restore the topic
+		err = a.repo.Restore(sess, id)
+		if err != nil {
+			return err
+		}
+
+		// Restore the posts deleted along with it
+		return a.postAdapter.RestoreByTopic(sess, id, *topic.DeletedAt)
+	})
+
+	return topic, err
+}
+
+// UpdateCounters recomputes the numbers of Posts of the selected Topics, and of Topics of the selected Sections
+// and Users.
+func (a *TopicService) UpdateCounters(sess entity.Session, e *entity.TopicCounters) error {
+	return a.repo.UpdateCounters(sess, e)
+}
+
+// Purge removes the Topics and Posts deleted before the given time for good.
+func (a *TopicService) Purge(sess entity.Session, e *entity.TrashPurge) (*entity.TrashPurgeResult, error) {
+	var res entity.TrashPurgeResult
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		// The posts go first, so the topics left without any posts can be removed
+		res.Posts, err = a.postAdapter.Purge(sess, e.DeletedBefore)
+		if err != nil {
+			return err
+		}
+
+		res.Topics, err = a.repo.Purge(sess, e.DeletedBefore)
+
+		return err
+	})
+
+	return &res, err
+}
+
+// Merge moves every Post of the source Topic into the target one, turns the source into a redirect stub and
+// recomputes the counters. It returns the moved Posts per author.
+func (a *TopicService) Merge(sess entity.Session, e *entity.TopicMerge) (*entity.TopicRelocation, error) {
+	if e.SourceID == e.TargetID {
+		return nil, domain.NewError(domain.ErrCodeValidation, "A topic can't be merged into itself")
+	}
+
+	var relocation *entity.TopicRelocation
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		// Fetching both topics, neither of them may be a redirect stub already
+		source, err := a.redirectableByID(sess, e.SourceID)
+		if err != nil {
+			return err
+		}
+
+		target, err := a.redirectableByID(sess, e.TargetID)
+		if err != nil {
+			return err
+		}
+
+		// Counting the posts to be moved per author before they are moved
+		authors, err := a.postAdapter.AuthorCounts(sess, &entity.PostFilters{
+			TopicIDs: []int64{source.ID},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Moving the posts and leaving the stub
+		err = a.postAdapter.Move(sess, &entity.PostMove{
+			FromTopicID: &source.ID,
+			ToTopicID:   target.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = a.repo.UpdateRedirect(sess, source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+
+		relocation = &entity.TopicRelocation{
+			From:    source,
+			To:      target,
+			Authors: authors,
+		}
+
+		return nil
+	})
+
+	return relocation, err
+}
+
+// Split creates a new Topic out of the selected Posts of a single Topic and recomputes the counters.
+// The new Topic belongs to the author of its earliest Post. It returns the moved Posts per author.
+func (a *TopicService) Split(sess entity.Session, e *entity.TopicSplit) (*entity.TopicRelocation, error) {
+	if len(e.PostIDs) == 0 {
+		return nil, domain.NewError(domain.ErrCodeValidation, "No posts to split off")
+	}
+
+	var relocation *entity.TopicRelocation
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		// Checking if the section exists
+		err := a.sectionAdapter.ExistsByID(sess, e.SectionID)
+		if err != nil {
+			return err
+		}
+
+		// Fetching the posts without any embedded fields
+		requestedFields := sess.RequestedFields
+		sess.RequestedFields = nil
+
+		posts, err := a.postAdapter.All(sess, &entity.PostFilters{
+			IDs: e.PostIDs,
+		}, &entity.Pagination{
+			Limit: int64(len(e.PostIDs)),
+			Page:  1,
+		}, &entity.PostSort{
+			By:    entity.PostSortByCreatedAt,
+			Order: entity.SortOrderAsc,
+		})
+
+		sess.RequestedFields = requestedFields
+
+		if err != nil {
+			return err
+		}
+
+		// Every post must exist and belong to the same topic
+		postsMap := entity.PostsMap(posts)
+
+		for _, id := range e.PostIDs {
+			if _, ok := postsMap[id]; !ok {
+				return domain.NewError(domain.ErrCodeNotFound, "Post with ID %d not found", id)
+			}
+		}
+
+		for _, post := range posts {
+			if post.TopicID != posts[0].TopicID {
+				return domain.NewError(domain.ErrCodeValidation, "The posts must belong to a single topic")
+			}
+		}
+
+		source, err := a.repo.SelectByID(sess, posts[0].TopicID)
+		if err != nil {
+			return err
+		}
+
+		// Creating the new topic
+		id, err := a.repo.Insert(sess, &entity.TopicAdd{
+			UserID:    posts[0].UserID,
+			SectionID: e.SectionID,
+			Name:      e.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Counting the posts to be moved per author before they are moved
+		authors, err := a.postAdapter.AuthorCounts(sess, &entity.PostFilters{
+			IDs: e.PostIDs,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Moving the posts
+		err = a.postAdapter.Move(sess, &entity.PostMove{
+			IDs:       e.PostIDs,
+			ToTopicID: id,
+		})
+		if err != nil {
+			return err
+		}
+
+		target, err := a.repo.SelectByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		relocation = &entity.TopicRelocation{
+			From:    source,
+			To:      target,
+			Authors: authors,
+		}
+
+		return nil
+	})
+
+	return relocation, err
+}
+
+// AcceptAnswer accepts a Post as the answer to the question asked in the Topic, or withdraws the accepted answer.
+// The author of the answer gets the reputation for it, which the author of the previous answer loses;
+// nobody gets it for answering their own question.
+func (a *TopicService) AcceptAnswer(sess entity.Session, e *entity.TopicAnswerAccept) (*entity.TopicAnswerChange, error) {
+	change := &entity.TopicAnswerChange{}
+
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
+		topic, err := a.PlainByID(sess, &entity.PlainTopicByID{
+			ID:           e.TopicID,
+			FetchSection: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if topic.Section.Mode != entity.SectionModeQA {
+			return domain.NewError(domain.ErrCodeValidation,
+				"Topic with ID %d is not a question, answers are only accepted in Q&A sections", topic.ID)
+		}
+
+		change.Topic = topic
+
+		if topic.AcceptedPostID != nil {
+			change.Previous, err = a.postAdapter.PlainByID(sess, &entity.PlainPostByID{
+				ID: *topic.AcceptedPostID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if e.PostID != nil {
+			change.Accepted, err = a.postAdapter.PlainByID(sess, &entity.PlainPostByID{
+				ID: *e.PostID,
+			})
+			if err != nil {
+				return err
+			}
+
+			post := change.Accepted
+
+			if post.TopicID != topic.ID || post.DeletedAt != nil || post.Status != entity.PostStatusPublished {
+				return domain.NewError(domain.ErrCodeValidation,
+					"Post with ID %d is not a published post of topic %d", post.ID, topic.ID)
+			}
+
+			if change.Previous != nil && change.Previous.ID == post.ID {
+				return domain.NewError(domain.ErrCodeAlreadyExists,
+					"Post with ID %d is already the accepted answer", post.ID)
+			}
+		} else if change.Previous == nil {
+			return domain.NewError(domain.ErrCodeValidation, "Topic with ID %d has no accepted answer", topic.ID)
+		}
+
+		err = a.repo.UpdateAcceptedPost(sess, topic.ID, e.PostID)
+		if err != nil {
+			return err
+		}
+
+		topic.AcceptedPostID = e.PostID
+
+		// Moving the reputation from the author of the previous answer to the author of the new one
+		if change.Previous != nil && change.Previous.UserID != topic.UserID {
+			err = a.userAdapter.AddReputation(sess, change.Previous.UserID, -entity.AcceptedAnswerReputation)
+			if err != nil {
+				return err
+			}
+		}
+
+		if change.Accepted != nil && change.Accepted.UserID != topic.UserID {
+			return a.userAdapter.AddReputation(sess, change.Accepted.UserID, entity.AcceptedAnswerReputation)
+		}
+
+		return nil
+	})
+
+	return change, err
+}
+
+// WithdrawAnswers withdraws the accepted answers among the given Posts, which are being deleted,
+// and takes the reputation for them back from their authors.
+func (a *TopicService) WithdrawAnswers(sess entity.Session, postIDs []int64) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		withdrawals, err := a.repo.WithdrawAcceptedPosts(sess, postIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, withdrawal := range withdrawals {
+			// Nobody got the reputation for answering their own question
+			if withdrawal.AnswerUserID == withdrawal.TopicUserID {
+				continue
+			}
+
+			err = a.userAdapter.AddReputation(sess, withdrawal.AnswerUserID, -entity.AcceptedAnswerReputation)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// checkDuplicate returns an error if a Topic with a near-identical name was created in the same Section recently.
+func (a *TopicService) checkDuplicate(sess entity.Session, e *entity.TopicAdd) error {
+	createdAfter := time.Now().Add(-a.duplicateGuard.Window)
+
+	similar, err := a.repo.SelectSimilar(sess, &entity.TopicSimilarFilters{
+		Name:          e.Name,
+		SectionID:     &e.SectionID,
+		CreatedAfter:  &createdAfter,
+		NameOnly:      true,
+		MinSimilarity: a.duplicateGuard.MinSimilarity,
+		Limit:         1,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(similar) > 0 {
+		return domain.NewError(domain.ErrCodeAlreadyExists, "A similar topic with ID %d was created recently", similar[0].TopicID)
+	}
+
+	return nil
+}
+
+// redirectableByID returns a Topic which is not a redirect stub.
+func (a *TopicService) redirectableByID(sess entity.Session, id int64) (*entity.Topic, error) {
+	topic, err := a.PlainByID(sess, &entity.PlainTopicByID{
+		ID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if topic.RedirectTopicID != nil {
+		return nil, domain.NewError(domain.ErrCodeValidation, "Topic with ID %d was merged into topic with ID %d", id,
+			*topic.RedirectTopicID)
+	}
+
+	return topic, nil
+}
+
+// attachReads attaches the unread counters and the first unread Posts of the current User to the Topics.
+func (a *TopicService) attachReads(sess entity.Session, topicsMap map[int64]*entity.Topic, topicIDs []int64) error {
+	reads, err := a.repo.SelectReads(sess, sess.UserID, topicIDs)
+	if err != nil {
+		return err
+	}
+
+	readsMap := entity.TopicReadsMap(reads)
+	firstUnreadPostIDs := make([]int64, 0, len(reads))
+
+	for _, topic := range topicsMap {
+		var unreadCount int64
+
+		if read, ok := readsMap[topic.ID]; ok {
+			unreadCount = read.UnreadCount
+
+			if read.FirstUnreadPostID != nil {
+				firstUnreadPostIDs = append(firstUnreadPostIDs, *read.FirstUnreadPostID)
+			}
+		}
+
+		topic.UnreadCount = &unreadCount
+	}
+
+	requestedFields := sess.RequestedFields
+
+	// If we don't wish to fetch the first unread posts or there are none, it's safe to return
+	if !requestedFields.ContainsAny("first_unread_post") || len(firstUnreadPostIDs) == 0 {
+		return nil
+	}
+
+	// Recursively change the requested fields to those for posts
+	sess.RequestedFields = requestedFields["first_unread_post"]
+
+	// Fetch the posts
+	posts, err := a.postAdapter.All(sess, &entity.PostFilters{
+		IDs: firstUnreadPostIDs,
+	}, &entity.Pagination{
+		Limit: int64(len(firstUnreadPostIDs)),
+		Page:  entity.DefaultPage,
+	}, nil)
+
+	// Put the initial requested fields back
+	sess.RequestedFields = requestedFields
+
+	if err != nil {
+		return err
+	}
+
+	// If successfully, then attach the posts to the respective topics
+	for _, post := range posts {
+		topicsMap[post.TopicID].FirstUnreadPost = post
+	}
+
+	return nil
+}
+
+// attachAcceptedAnswers attaches the accepted answers to the Topics and moves them to the beginning of their Posts
+// if those are the first page, each one fetched with the fields requested for where it goes. The answers moved away
+// or no longer visible are skipped.
+func (a *TopicService) attachAcceptedAnswers(sess entity.Session, topicsMap map[int64]*entity.Topic,
+	postsPage *entity.Pagination) error {
+	requestedFields := sess.RequestedFields
+
+	fetch := func(field string, ids []int64) (map[int64]*entity.Post, error) {
+		if len(ids) == 0 {
+			return nil, nil
+		}
+
+		// Recursively change the requested fields to those of the field the answers go to
+		sess.RequestedFields = requestedFields[field]
+
+		posts, err := a.postAdapter.All(sess, &entity.PostFilters{
+			IDs: ids,
+		}, nil, nil)
+
+		// Put the initial requested fields back
+		sess.RequestedFields = requestedFields
+
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+
+		answersMap := make(map[int64]*entity.Post, len(posts))
+
+		for _, post := range posts {
+			if topic := topicsMap[post.TopicID]; topic != nil && topic.AcceptedPostID != nil && *topic.AcceptedPostID == post.ID {
+				answersMap[post.TopicID] = post
+			}
+		}
+
+		return answersMap, nil
+	}
+
+	if requestedFields.ContainsAny("accepted_answer") {
+		var ids []int64
+
+		for _, topic := range topicsMap {
+			if topic.AcceptedPostID != nil {
+				ids = append(ids, *topic.AcceptedPostID)
+			}
+		}
+
+		answersMap, err := fetch("accepted_answer", ids)
+		if err != nil {
+			return err
+		}
+
+		for topicID, answer := range answersMap {
+			topicsMap[topicID].AcceptedAnswer = answer
+		}
+	}
+
+	// The answer is shown once, before the rest of the posts, rather than on every page
+	if requestedFields.ContainsAny("posts") && postsPage.Page == entity.DefaultPage {
+		var ids []int64
+
+		// Only the answers which didn't make it into the fetched posts are missing
+		for _, topic := range topicsMap {
+			if topic.AcceptedPostID != nil && entity.PostsMap(topic.Posts)[*topic.AcceptedPostID] == nil {
+				ids = append(ids, *topic.AcceptedPostID)
+			}
+		}
+
+		answersMap, err := fetch("posts", ids)
+		if err != nil {
+			return err
+		}
+
+		for topicID, topic := range topicsMap {
+			topic.PutAcceptedAnswerFirst(answersMap[topicID])
+		}
+	}
+
+	return nil
+}
+
 // PlainByID returns a Topic by its ID.
 func (a *TopicService) PlainByID(sess entity.Session, e *entity.PlainTopicByID) (*entity.Topic, error) {
 	var topic *entity.Topic
 
-	err := a.DoTransaction(sess, func() error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
 		topic, err = a.repo.SelectByID(sess, e.ID)
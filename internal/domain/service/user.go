@@ -5,6 +5,7 @@ import (
 	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 	"simplestforum/internal/domain/usecase"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -13,8 +14,12 @@ import (
 type UserService struct {
 	repo UserStorage
 
-	topicAdapter usecase.TopicAdapter
-	postAdapter  usecase.PostAdapter
+	topicAdapter   usecase.TopicAdapter
+	postAdapter    usecase.PostAdapter
+	profileAdapter usecase.ProfileAdapter
+	followAdapter  usecase.FollowAdapter
+	rankAdapter    usecase.RankAdapter
+	badgeAdapter   usecase.BadgeAdapter
 
 	Service
 }
@@ -30,16 +35,32 @@ func NewUserService(repo UserStorage) *UserService {
 	}
 }
 
-func (a *UserService) AttachAdapters(topicAdapter usecase.TopicAdapter, postAdapter usecase.PostAdapter) {
+func (a *UserService) AttachAdapters(topicAdapter usecase.TopicAdapter, postAdapter usecase.PostAdapter,
+	profileAdapter usecase.ProfileAdapter, followAdapter usecase.FollowAdapter, rankAdapter usecase.RankAdapter,
+	badgeAdapter usecase.BadgeAdapter) {
 	a.topicAdapter = topicAdapter
 	a.postAdapter = postAdapter
+	a.profileAdapter = profileAdapter
+	a.followAdapter = followAdapter
+	a.rankAdapter = rankAdapter
+	a.badgeAdapter = badgeAdapter
 }
 
 // Add creates a new User.
 func (a *UserService) Add(sess entity.Session, e *entity.UserAdd) (*entity.User, error) {
-	// Hash the password
-	var err error
+	if e.UserInfo == nil {
+		e.UserInfo = &entity.UserInfo{}
+	}
+
+	err := a.validateInfo(e.UserInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// The info fields without a visibility of their own follow the show_info flag
+	e.UserInfo.DefaultVisibility(e.ShowInfo)
 
+	// Hash the password
 	e.Password, err = a.hashPassword(e.Password)
 	if err != nil {
 		return nil, err
@@ -47,13 +68,19 @@ func (a *UserService) Add(sess entity.Session, e *entity.UserAdd) (*entity.User,
 
 	var user *entity.User
 
-	err = a.DoTransaction(sess, func() error {
+	err = a.DoTransaction(sess, func(sess entity.Session) error {
 		// Check if the nickname is already registered
 		err := a.nicknameAlreadyRegistered(sess, e.Nickname)
 		if err != nil {
 			return err
 		}
 
+		// Every new User starts from the first rank
+		err = a.checkSignaturePerk(sess, e.UserInfo, 1)
+		if err != nil {
+			return err
+		}
+
 		// Insert the main info
 		user, err = a.repo.Insert(sess, e)
 		if err != nil {
@@ -71,10 +98,22 @@ func (a *UserService) Add(sess entity.Session, e *entity.UserAdd) (*entity.User,
 
 // Edit modifies an existing User.
 func (a *UserService) Edit(sess entity.Session, e *entity.UserEdit) error {
+	err := a.validateInfo(e.UserInfo)
+	if err != nil {
+		return err
+	}
+
+	// The info fields without a visibility of their own follow the show_info flag
+	if e.ShowInfo != nil {
+		if e.UserInfo == nil {
+			e.UserInfo = &entity.UserInfo{}
+		}
+
+		e.UserInfo.DefaultVisibility(*e.ShowInfo)
+	}
+
 	// If the password is present, hash it
 	if e.Password != nil {
-		var err error
-
 		*e.Password, err = a.hashPassword(*e.Password)
 
 		if err != nil {
@@ -82,13 +121,20 @@ func (a *UserService) Edit(sess entity.Session, e *entity.UserEdit) error {
 		}
 	}
 
-	var user *entity.User
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		// Check if the ID is valid
+		user, err := a.PlainByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
 
-	return a.DoTransaction(sess, func() error {
-		var err error
+		// The rank being given is the one the signature should be allowed by
+		rank := user.Rank
+		if e.Rank != nil {
+			rank = *e.Rank
+		}
 
-		// Check if the ID is valid
-		err = a.ExistsByID(sess, e.ID)
+		err = a.checkSignaturePerk(sess, e.UserInfo, rank)
 		if err != nil {
 			return err
 		}
@@ -110,7 +156,7 @@ func (a *UserService) Edit(sess entity.Session, e *entity.UserEdit) error {
 
 		// Update additional info if needed
 		if e.UserInfo != nil {
-			return a.repo.UpdateInfo(sess, e.UserInfo, user.ID)
+			return a.repo.UpdateInfo(sess, e.UserInfo, e.ID)
 		}
 
 		return nil
@@ -119,7 +165,7 @@ func (a *UserService) Edit(sess entity.Session, e *entity.UserEdit) error {
 
 // Delete removes a single User entry along with the entities (posts and topics) which depend on it.
 func (a *UserService) Delete(sess entity.Session, id int64) error {
-	return a.DoTransaction(sess, func() error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
 		// Check if the ID is valid
 		err := a.ExistsByID(sess, id)
 		if err != nil {
@@ -164,7 +210,7 @@ func (a *UserService) All(sess entity.Session, f *entity.UserFilters, p *entity.
 
 	var users []*entity.User
 
-	err := a.DoTransaction(sess, func() error {
+	err := a.DoTransaction(sess, func(sess entity.Session) error {
 		var (
 			requestedFields = sess.RequestedFields
 			err             error
@@ -187,11 +233,33 @@ func (a *UserService) All(sess entity.Session, f *entity.UserFilters, p *entity.
 			return domain.NewError(domain.ErrCodeNotFound, "Users not found")
 		}
 
-		// If the private info is not supposed to be seen, hide it
-		if infoRequested && !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		// Hide the info fields which are not supposed to be seen
+		if infoRequested {
+			for _, user := range users {
+				if user.UserInfo != nil {
+					user.UserInfo.HideFrom(sess, user.ID)
+				}
+			}
+		}
+
+		// If we wish to fetch the rank steps or the signatures, the ladder is needed: a signature is only shown
+		// while the rank of its author allows it, the author and the admins still see it to be able to edit it
+		stepRequested := requestedFields.ContainsAny("rank_step")
+
+		if stepRequested || infoRequested {
+			ladder, err := a.rankAdapter.Ladder(sess)
+			if err != nil {
+				return err
+			}
+
 			for _, user := range users {
-				if !user.ShowInfo && user.ID != sess.UserID {
-					user.UserInfo = nil
+				if stepRequested {
+					user.RankStep = ladder.Step(user.Rank)
+				}
+
+				if user.UserInfo != nil && !ladder.Allows(user.Rank, entity.RankPerkSignature) &&
+					!entity.ProfileVisibilityPrivate.VisibleTo(sess, user.ID) {
+					user.UserInfo.Signature = nil
 				}
 			}
 		}
@@ -200,6 +268,32 @@ func (a *UserService) All(sess entity.Session, f *entity.UserFilters, p *entity.
 		userIDs := entity.UsersEntityIDs(users)
 		usersMap := entity.UsersMap(users)
 
+		// If we wish to fetch the custom profile fields, only the visible ones are returned
+		if requestedFields.ContainsAny("profile_fields") {
+			values, err := a.profileAdapter.Values(sess, userIDs)
+			if err != nil {
+				return err
+			}
+
+			for _, value := range values {
+				user := usersMap[value.UserID]
+				user.ProfileFields = append(user.ProfileFields, value)
+			}
+		}
+
+		// If we wish to fetch the badges
+		if requestedFields.ContainsAny("badges") {
+			badges, err := a.badgeAdapter.UserBadges(sess, userIDs)
+			if err != nil {
+				return err
+			}
+
+			for _, badge := range badges {
+				user := usersMap[badge.UserID]
+				user.Badges = append(user.Badges, badge)
+			}
+		}
+
 		// If we wish to fetch topics
 		if requestedFields.ContainsAny("topics") {
 			var topics []*entity.Topic
@@ -252,6 +346,26 @@ func (a *UserService) All(sess entity.Session, f *entity.UserFilters, p *entity.
 			}
 		}
 
+		// If we wish to fetch the followers
+		if requestedFields.ContainsAny("followers") {
+			err = a.attachFollows(sess, requestedFields["followers"], usersMap, &entity.UserFollowFilters{
+				FollowedUserIDs: userIDs,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// If we wish to fetch the followed users
+		if requestedFields.ContainsAny("following") {
+			err = a.attachFollows(sess, requestedFields["following"], usersMap, &entity.UserFollowFilters{
+				UserIDs: userIDs,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 
@@ -287,6 +401,11 @@ func (a *UserService) PlainByID(sess entity.Session, id int64) (*entity.User, er
 	return a.repo.SelectByID(sess, id)
 }
 
+// AddReputation adds the delta, which may be negative, to the reputation of the User.
+func (a *UserService) AddReputation(sess entity.Session, id, delta int64) error {
+	return a.repo.UpdateReputation(sess, id, delta)
+}
+
 // hashPassword attempts to hash the password string and return it (or an error).
 func (a *UserService) hashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -297,6 +416,82 @@ func (a *UserService) hashPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
+// attachFollows fetches the Users on the other side of the follows matching the filters, with the requested fields,
+// and attaches them to the users as their followers or the followed users.
+func (a *UserService) attachFollows(sess entity.Session, requestedFields entity.RequestFields,
+	usersMap map[int64]*entity.User, f *entity.UserFollowFilters) error {
+	follows, err := a.followAdapter.Follows(sess, f)
+	if err != nil || len(follows) == 0 {
+		return err
+	}
+
+	followers := len(f.FollowedUserIDs) > 0
+	ids := make([]int64, len(follows))
+
+	for i, follow := range follows {
+		if followers {
+			ids[i] = follow.UserID
+		} else {
+			ids[i] = follow.FollowedUserID
+		}
+	}
+
+	sess.RequestedFields = requestedFields
+
+	related, err := a.All(sess, &entity.UserFilters{
+		IDs: ids,
+	}, &entity.Pagination{Limit: int64(len(ids)), Page: entity.DefaultPage}, nil)
+	if err != nil {
+		return err
+	}
+
+	relatedMap := entity.UsersMap(related)
+
+	for _, follow := range follows {
+		if followers {
+			if follower, ok := relatedMap[follow.UserID]; ok {
+				user := usersMap[follow.FollowedUserID]
+				user.Followers = append(user.Followers, follower)
+			}
+		} else {
+			if followed, ok := relatedMap[follow.FollowedUserID]; ok {
+				user := usersMap[follow.UserID]
+				user.Following = append(user.Following, followed)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateInfo checks the free-form texts of the info.
+func (a *UserService) validateInfo(e *entity.UserInfo) error {
+	if e != nil && e.Signature != nil && strings.Count(*e.Signature, "\n") >= entity.SignatureMaxLines {
+		return domain.NewError(domain.ErrCodeValidation, "The signature must not be longer than %d lines",
+			entity.SignatureMaxLines)
+	}
+
+	return nil
+}
+
+// checkSignaturePerk returns nil if the signature of the info is allowed by the rank. The moderation isn't restricted.
+func (a *UserService) checkSignaturePerk(sess entity.Session, e *entity.UserInfo, rank int64) error {
+	if e == nil || e.Signature == nil || *e.Signature == "" || sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil
+	}
+
+	ladder, err := a.rankAdapter.Ladder(sess)
+	if err != nil {
+		return err
+	}
+
+	if !ladder.Allows(rank, entity.RankPerkSignature) {
+		return domain.NewError(domain.ErrCodeForbidden, "Your rank doesn't allow a signature yet")
+	}
+
+	return nil
+}
+
 // nicknameAlreadyRegistered returns nil if the nickname doesn't exist.
 func (a *UserService) nicknameAlreadyRegistered(sess entity.Session, nickname string) error {
 	_, _, err := a.repo.SelectByNicknameWithPassword(sess, nickname)
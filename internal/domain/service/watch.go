@@ -0,0 +1,70 @@
+package service
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/usecase"
+)
+
+// WatchService represents a Watch service.
+type WatchService struct {
+	repo WatchStorage
+
+	topicAdapter   usecase.TopicAdapter
+	sectionAdapter usecase.SectionAdapter
+
+	Service
+}
+
+// NewWatchService instantiates a WatchService.
+func NewWatchService(repo WatchStorage) *WatchService {
+	return &WatchService{
+		repo: repo,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+func (a *WatchService) AttachAdapters(topicAdapter usecase.TopicAdapter, sectionAdapter usecase.SectionAdapter) {
+	a.topicAdapter = topicAdapter
+	a.sectionAdapter = sectionAdapter
+}
+
+// WatchTopic subscribes a User to a Topic.
+func (a *WatchService) WatchTopic(sess entity.Session, e *entity.TopicWatch) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		// Checking if the topic exists
+		err := a.topicAdapter.ExistsByID(sess, e.TopicID)
+		if err != nil {
+			return err
+		}
+
+		// Subscribing
+		return a.repo.InsertTopicWatch(sess, e)
+	})
+}
+
+// UnwatchTopic unsubscribes a User from a Topic.
+func (a *WatchService) UnwatchTopic(sess entity.Session, e *entity.TopicWatch) error {
+	return a.repo.DeleteTopicWatch(sess, e)
+}
+
+// WatchSection subscribes a User to a Section.
+func (a *WatchService) WatchSection(sess entity.Session, e *entity.SectionWatch) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		// Checking if the section exists
+		err := a.sectionAdapter.ExistsByID(sess, e.SectionID)
+		if err != nil {
+			return err
+		}
+
+		// Subscribing
+		return a.repo.InsertSectionWatch(sess, e)
+	})
+}
+
+// UnwatchSection unsubscribes a User from a Section.
+func (a *WatchService) UnwatchSection(sess entity.Session, e *entity.SectionWatch) error {
+	return a.repo.DeleteSectionWatch(sess, e)
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"time"
+)
+
+const (
+	// webhookSecretSize is the number of random bytes in a generated secret of a Webhook.
+	webhookSecretSize = 32
+	// webhookEventIDSize is the number of random bytes in the ID of an Event sent to the Webhooks.
+	webhookEventIDSize = 16
+)
+
+// webhookEnvelope is the body of the requests sent to the Webhooks.
+type webhookEnvelope struct {
+	ID        string           `json:"id"`
+	Event     entity.EventType `json:"event"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      entity.Event     `json:"data"`
+}
+
+// WebhookService represents a Webhook service.
+type WebhookService struct {
+	repo   WebhookStorage
+	sender WebhookSender
+
+	Service
+}
+
+// NewWebhookService instantiates a WebhookService.
+func NewWebhookService(repo WebhookStorage, sender WebhookSender) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		sender: sender,
+
+		Service: Service{
+			repo,
+		},
+	}
+}
+
+// Add registers a new Webhook, generating its secret if none is given.
+func (a *WebhookService) Add(sess entity.Session, e *entity.WebhookAdd) (int64, error) {
+	err := a.validateURL(e.URL)
+	if err != nil {
+		return 0, err
+	}
+
+	err = a.validateEventTypes(e.EventTypes)
+	if err != nil {
+		return 0, err
+	}
+
+	if e.Secret == "" {
+		e.Secret, err = a.randomHex(webhookSecretSize)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return a.repo.Insert(sess, e)
+}
+
+// Edit modifies an existing Webhook.
+func (a *WebhookService) Edit(sess entity.Session, e *entity.WebhookEdit) error {
+	if e.URL != nil {
+		err := a.validateURL(*e.URL)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.Secret != nil && *e.Secret == "" {
+		return domain.NewError(domain.ErrCodeValidation, "The secret of a webhook must not be empty")
+	}
+
+	err := a.validateEventTypes(e.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		_, err := a.ByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		return a.repo.Update(sess, e)
+	})
+}
+
+// Delete removes a Webhook along with its delivery log. The Events already triggered for it are dropped.
+func (a *WebhookService) Delete(sess entity.Session, id int64) error {
+	return a.DoTransaction(sess, func(sess entity.Session) error {
+		_, err := a.ByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		return a.repo.Delete(sess, id)
+	})
+}
+
+// All returns every Webhook registered.
+func (a *WebhookService) All(sess entity.Session) ([]*entity.Webhook, error) {
+	return a.repo.SelectAll(sess, false)
+}
+
+// ByID returns a Webhook by its ID.
+func (a *WebhookService) ByID(sess entity.Session, id int64) (*entity.Webhook, error) {
+	webhook, err := a.repo.SelectByID(sess, id)
+	if err != nil {
+		var domainErr *domain.Error
+
+		if errors.As(err, &domainErr) && domainErr.Is(domain.ErrNotFound) {
+			domainErr.SetErrorMessage("Webhook with ID %d not found", id)
+		}
+
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Matching returns the active Webhooks whose filters the Event of the type, which belongs to the Section if any,
+// passes.
+func (a *WebhookService) Matching(sess entity.Session, eventType entity.EventType, sectionID *int64) ([]*entity.Webhook, error) {
+	webhooks, err := a.repo.SelectAll(sess, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []*entity.Webhook
+
+	for _, webhook := range webhooks {
+		if webhook.Matches(eventType, sectionID) {
+			matching = append(matching, webhook)
+		}
+	}
+
+	return matching, nil
+}
+
+// Payload encodes the Event into the body sent to the Webhooks, giving it a unique ID.
+// The Event must not carry any private info.
+func (a *WebhookService) Payload(e entity.Event) (*entity.WebhookPayload, error) {
+	id, err := a.randomHex(webhookEventIDSize)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:        id,
+		Event:     e.EventType(),
+		CreatedAt: time.Now().UTC(),
+		Data:      e,
+	})
+	if err != nil {
+		return nil, domain.NewErrorWrap(err, domain.ErrCodeInternal, "Cannot encode the event %s", e.EventType())
+	}
+
+	return &entity.WebhookPayload{
+		ID:        id,
+		EventType: e.EventType(),
+		Body:      string(body),
+	}, nil
+}
+
+// Deliver sends the payload to the Webhook and logs the delivery. An error is returned if the Webhook didn't accept
+// the payload, so it's retried. The payloads of the deleted and inactive Webhooks are dropped.
+func (a *WebhookService) Deliver(sess entity.Session, e *entity.WebhookTriggeredEvent) (*entity.WebhookDelivery, error) {
+	webhook, err := a.repo.SelectByID(sess, e.WebhookID)
+	if err != nil {
+		var domainErr *domain.Error
+
+		if errors.As(err, &domainErr) && domainErr.Is(domain.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if !webhook.Active {
+		return nil, nil
+	}
+
+	delivery, err := a.send(sess, webhook, e.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if !delivery.Succeeded() {
+		return delivery, domain.NewError(domain.ErrCodeInternal, "Webhook %d failed: %s", webhook.ID, *delivery.Error)
+	}
+
+	return delivery, nil
+}
+
+// Redeliver sends the payload of a logged delivery to its Webhook once more, even if the Webhook is inactive.
+// The new delivery is logged and returned whether it succeeded or not.
+func (a *WebhookService) Redeliver(sess entity.Session, deliveryID int64) (*entity.WebhookDelivery, error) {
+	delivery, err := a.DeliveryByID(sess, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := a.ByID(sess, delivery.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.send(sess, webhook, &entity.WebhookPayload{
+		ID:        delivery.EventID,
+		EventType: delivery.EventType,
+		Body:      delivery.Payload,
+	})
+}
+
+// Deliveries returns the entries of the delivery log matching the filters.
+func (a *WebhookService) Deliveries(sess entity.Session, f *entity.WebhookDeliveryFilters,
+	p *entity.Pagination) ([]*entity.WebhookDelivery, error) {
+	// If pagination was not set, use default
+	if p == nil {
+		p = entity.DefaultPagination
+	}
+
+	deliveries, err := a.repo.SelectDeliveries(sess, f, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return deliveries, nil
+}
+
+// DeliveryByID returns an entry of the delivery log by its ID.
+func (a *WebhookService) DeliveryByID(sess entity.Session, id int64) (*entity.WebhookDelivery, error) {
+	deliveries, err := a.repo.SelectDeliveries(sess, &entity.WebhookDeliveryFilters{
+		IDs: []int64{id},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, domain.NewError(domain.ErrCodeNotFound, "Webhook delivery with ID %d not found", id)
+	}
+
+	return deliveries[0], nil
+}
+
+// send signs the payload with the secret of the Webhook, sends it and logs the delivery.
+// Any response other than 2xx fails the delivery.
+func (a *WebhookService) send(sess entity.Session, webhook *entity.Webhook,
+	payload *entity.WebhookPayload) (*entity.WebhookDelivery, error) {
+	delivery := &entity.WebhookDeliveryAdd{
+		WebhookID: webhook.ID,
+		EventID:   payload.ID,
+		EventType: payload.EventType,
+		Payload:   payload.Body,
+	}
+
+	start := time.Now()
+
+	statusCode, err := a.sender.Send(sess.Ctx, &entity.WebhookRequest{
+		URL:       webhook.URL,
+		Signature: webhook.Sign(payload.Body),
+		Payload:   payload,
+	})
+
+	delivery.Duration = time.Since(start)
+
+	switch {
+	case err != nil:
+		message := err.Error()
+		delivery.Error = &message
+	case statusCode < 200 || statusCode > 299:
+		message := fmt.Sprintf("Unexpected response status %d", statusCode)
+		delivery.StatusCode = &statusCode
+		delivery.Error = &message
+	default:
+		delivery.StatusCode = &statusCode
+	}
+
+	return a.repo.InsertDelivery(sess, delivery)
+}
+
+// validateURL checks if the requests can be sent to the URL.
+func (a *WebhookService) validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.NewError(domain.ErrCodeValidation, "The URL of a webhook must be an absolute HTTP or HTTPS URL")
+	}
+
+	return nil
+}
+
+// validateEventTypes checks if the Events of the types can be delivered to the Webhooks.
+func (a *WebhookService) validateEventTypes(eventTypes []entity.EventType) error {
+	for _, eventType := range eventTypes {
+		if !eventType.Valid() {
+			return domain.NewError(domain.ErrCodeValidation, "Unknown event type %s", eventType)
+		}
+	}
+
+	return nil
+}
+
+// randomHex returns size random bytes encoded in hex.
+func (a *WebhookService) randomHex(size int) (string, error) {
+	b := make([]byte, size)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", domain.NewErrorWrap(err, domain.ErrCodeInternal, "Cannot generate random bytes")
+	}
+
+	return hex.EncodeToString(b), nil
+}
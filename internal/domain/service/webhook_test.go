@@ -0,0 +1,356 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/domain/service"
+	"simplestforum/internal/domain/usecase"
+	"simplestforum/internal/infrastructure/webhook"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeTransactioner starts the transactions of the fake storages and counts the ones in progress.
+type fakeTransactioner struct {
+	open int64
+}
+
+func (t *fakeTransactioner) NewTransaction(context.Context) (entity.AbstractTransaction, error) {
+	atomic.AddInt64(&t.open, 1)
+
+	return &fakeTx{transactioner: t}, nil
+}
+
+// inTransaction tells if any transaction is in progress.
+func (t *fakeTransactioner) inTransaction() bool {
+	return atomic.LoadInt64(&t.open) > 0
+}
+
+// fakeTx holds the writes made in a transaction until it's committed, and drops them if it's rolled back.
+type fakeTx struct {
+	transactioner *fakeTransactioner
+	writes        []func()
+	done          bool
+}
+
+func (tx *fakeTx) Commit() error {
+	for _, write := range tx.writes {
+		write()
+	}
+
+	tx.finish()
+
+	return nil
+}
+
+func (tx *fakeTx) RollbackUnlessCommitted() {
+	if !tx.done {
+		tx.finish()
+	}
+}
+
+func (tx *fakeTx) finish() {
+	tx.done = true
+	tx.writes = nil
+	atomic.AddInt64(&tx.transactioner.open, -1)
+}
+
+// write applies the write once the transaction of the Session is committed, or at once outside a transaction.
+func write(sess entity.Session, f func()) {
+	if tx, ok := sess.Transaction.(*fakeTx); ok {
+		tx.writes = append(tx.writes, f)
+
+		return
+	}
+
+	f()
+}
+
+// webhookStorage keeps a single Webhook and the delivery log in memory.
+type webhookStorage struct {
+	service.WebhookStorage
+
+	transactions *fakeTransactioner
+	webhook      *entity.Webhook
+	deliveries   []*entity.WebhookDelivery
+}
+
+func (s *webhookStorage) NewTransaction(ctx context.Context) (entity.AbstractTransaction, error) {
+	return s.transactions.NewTransaction(ctx)
+}
+
+func (s *webhookStorage) SelectByID(entity.Session, int64) (*entity.Webhook, error) {
+	return s.webhook, nil
+}
+
+func (s *webhookStorage) InsertDelivery(sess entity.Session, e *entity.WebhookDeliveryAdd) (*entity.WebhookDelivery, error) {
+	delivery := &entity.WebhookDelivery{
+		ID:         int64(len(s.deliveries) + 1),
+		WebhookID:  e.WebhookID,
+		EventID:    e.EventID,
+		EventType:  e.EventType,
+		Payload:    e.Payload,
+		StatusCode: e.StatusCode,
+		Error:      e.Error,
+		Duration:   e.Duration,
+	}
+
+	write(sess, func() {
+		s.deliveries = append(s.deliveries, delivery)
+	})
+
+	return delivery, nil
+}
+
+// outboxStorage hands out the messages once and keeps track of their removal and updates.
+type outboxStorage struct {
+	service.OutboxStorage
+
+	transactions *fakeTransactioner
+	messages     []*entity.OutboxMessage
+	deleted      []int64
+	updated      []*entity.OutboxMessageEdit
+}
+
+func (s *outboxStorage) NewTransaction(ctx context.Context) (entity.AbstractTransaction, error) {
+	return s.transactions.NewTransaction(ctx)
+}
+
+func (s *outboxStorage) Claim(entity.Session, int64, time.Duration) ([]*entity.OutboxMessage, error) {
+	messages := s.messages
+	s.messages = nil
+
+	return messages, nil
+}
+
+func (s *outboxStorage) Delete(sess entity.Session, id int64) error {
+	write(sess, func() {
+		s.deleted = append(s.deleted, id)
+	})
+
+	return nil
+}
+
+func (s *outboxStorage) Update(sess entity.Session, e *entity.OutboxMessageEdit) error {
+	write(sess, func() {
+		s.updated = append(s.updated, e)
+	})
+
+	return nil
+}
+
+// receivedRequest is what the test server got from the sender.
+type receivedRequest struct {
+	body          string
+	event         string
+	signature     string
+	inTransaction bool
+}
+
+// newWebhookServer starts a local server answering with the status code and recording the requests.
+func newWebhookServer(t *testing.T, statusCode int, transactions *fakeTransactioner) (*httptest.Server,
+	*[]receivedRequest) {
+	t.Helper()
+
+	var received []receivedRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		received = append(received, receivedRequest{
+			body:          string(body),
+			event:         r.Header.Get(webhook.HeaderEvent),
+			signature:     r.Header.Get(webhook.HeaderSignature),
+			inTransaction: transactions.inTransaction(),
+		})
+
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &received
+}
+
+func newWebhookService(server *httptest.Server, transactions *fakeTransactioner) (*service.WebhookService,
+	*webhookStorage) {
+	storage := &webhookStorage{
+		transactions: transactions,
+		webhook: &entity.Webhook{
+			ID:         1,
+			URL:        server.URL,
+			Secret:     "s3cret",
+			EventTypes: []entity.EventType{entity.EventTypeTopicCreated},
+			Active:     true,
+		},
+	}
+
+	return service.NewWebhookService(storage, webhook.NewHTTPSender(server.Client())), storage
+}
+
+func triggeredEvent() *entity.WebhookTriggeredEvent {
+	return &entity.WebhookTriggeredEvent{
+		WebhookID: 1,
+		Payload: &entity.WebhookPayload{
+			ID:        "event-1",
+			EventType: entity.EventTypeTopicCreated,
+			Body:      `{"id":"event-1","event":"TOPIC_CREATED"}`,
+		},
+	}
+}
+
+func TestWebhookDeliver(t *testing.T) {
+	transactions := &fakeTransactioner{}
+	server, received := newWebhookServer(t, http.StatusNoContent, transactions)
+	webhooks, storage := newWebhookService(server, transactions)
+	sess := entity.Session{Ctx: context.Background()}
+	event := triggeredEvent()
+
+	delivery, err := webhooks.Deliver(sess, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*received) != 1 {
+		t.Fatalf("expected a single request, got %d", len(*received))
+	}
+
+	request := (*received)[0]
+	if request.body != event.Payload.Body {
+		t.Errorf("expected the body %s, got %s", event.Payload.Body, request.body)
+	}
+
+	if request.event != string(entity.EventTypeTopicCreated) {
+		t.Errorf("expected the event %s, got %s", entity.EventTypeTopicCreated, request.event)
+	}
+
+	// The receiver checks the signature with the shared secret
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(request.body))
+
+	if want := entity.WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil)); request.signature != want {
+		t.Errorf("expected the signature %s, got %s", want, request.signature)
+	}
+
+	if len(storage.deliveries) != 1 || storage.deliveries[0] != delivery {
+		t.Fatalf("expected the delivery to be logged, got %v", storage.deliveries)
+	}
+
+	if !delivery.Succeeded() || delivery.StatusCode == nil || *delivery.StatusCode != http.StatusNoContent {
+		t.Errorf("expected a successful delivery with the status %d, got %+v", http.StatusNoContent, delivery)
+	}
+}
+
+func TestWebhookDeliverFailure(t *testing.T) {
+	transactions := &fakeTransactioner{}
+	server, received := newWebhookServer(t, http.StatusInternalServerError, transactions)
+	webhooks, storage := newWebhookService(server, transactions)
+	sess := entity.Session{Ctx: context.Background()}
+
+	_, err := webhooks.Deliver(sess, triggeredEvent())
+	if err == nil {
+		t.Fatal("expected an error for the failed delivery, so it is retried")
+	}
+
+	if len(*received) != 1 {
+		t.Fatalf("expected a single request, got %d", len(*received))
+	}
+
+	// The failed attempt is logged as well
+	if len(storage.deliveries) != 1 {
+		t.Fatalf("expected the delivery to be logged, got %d entries", len(storage.deliveries))
+	}
+
+	delivery := storage.deliveries[0]
+	if delivery.Succeeded() || delivery.StatusCode == nil || *delivery.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected a failed delivery with the status %d, got %+v", http.StatusInternalServerError, delivery)
+	}
+}
+
+func TestWebhookDispatch(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		statusCode int
+		delivered  bool
+	}{
+		{name: "accepted", statusCode: http.StatusOK, delivered: true},
+		{name: "failed", statusCode: http.StatusBadGateway},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			transactions := &fakeTransactioner{}
+			server, received := newWebhookServer(t, tc.statusCode, transactions)
+			webhooks, storage := newWebhookService(server, transactions)
+
+			payload, err := json.Marshal(triggeredEvent())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outbox := &outboxStorage{
+				transactions: transactions,
+				messages: []*entity.OutboxMessage{{
+					ID:         1,
+					Subscriber: "webhook-deliveries",
+					EventType:  entity.EventTypeWebhookTriggered,
+					Payload:    string(payload),
+					Status:     entity.OutboxStatusPending,
+				}},
+			}
+
+			events := service.NewEventService(outbox, nil, entity.OutboxRules{
+				BatchSize:   10,
+				MaxAttempts: 3,
+				BaseBackoff: time.Second,
+				MaxBackoff:  time.Minute,
+			}, nil)
+			events.Subscribe("webhook-deliveries", entity.EventDeliveryExternal,
+				usecase.NewWebhookSubscriber(webhooks, nil, events).Deliver, entity.EventTypeWebhookTriggered)
+
+			result, err := events.Dispatch(entity.Session{Ctx: context.Background(), Level: entity.UserLevelAdmin})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(*received) != 1 {
+				t.Fatalf("expected a single request, got %d", len(*received))
+			}
+
+			if (*received)[0].inTransaction {
+				t.Error("expected the request to be sent outside a transaction")
+			}
+
+			// Every attempt is kept in the delivery log, whatever the outcome
+			if len(storage.deliveries) != 1 {
+				t.Fatalf("expected the attempt to be logged, got %d entries", len(storage.deliveries))
+			}
+
+			delivery := storage.deliveries[0]
+			if delivery.StatusCode == nil || *delivery.StatusCode != int64(tc.statusCode) {
+				t.Errorf("expected the status %d to be logged, got %+v", tc.statusCode, delivery)
+			}
+
+			if tc.delivered {
+				if result.Delivered != 1 || len(outbox.deleted) != 1 {
+					t.Errorf("expected the message to be delivered and removed, got %+v, removed %v",
+						result, outbox.deleted)
+				}
+
+				return
+			}
+
+			if result.Retried != 1 || len(outbox.deleted) != 0 || len(outbox.updated) != 1 {
+				t.Errorf("expected the message to be retried, got %+v, removed %v", result, outbox.deleted)
+			}
+		})
+	}
+}
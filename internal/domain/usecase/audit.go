@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// AuditUC is an audit log usecase.
+type AuditUC struct {
+	auditService AuditAdapter
+}
+
+// NewAuditUC instantiates an audit log usecase.
+func NewAuditUC(auditService AuditAdapter) *AuditUC {
+	return &AuditUC{
+		auditService: auditService,
+	}
+}
+
+// All returns the entries of the audit log.
+func (uc *AuditUC) All(sess entity.Session, f *entity.AuditFilters, p *entity.Pagination) ([]*entity.AuditEntry, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.auditService.All(sess, f, p)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// BadgeUC is a Badge usecase.
+type BadgeUC struct {
+	badgeService BadgeAdapter
+	eventService EventAdapter
+}
+
+// NewBadgeUC instantiates a Badge usecase.
+func NewBadgeUC(badgeService BadgeAdapter, eventService EventAdapter) *BadgeUC {
+	return &BadgeUC{
+		badgeService: badgeService,
+		eventService: eventService,
+	}
+}
+
+// Add defines a new Badge.
+func (uc *BadgeUC) Add(sess entity.Session, e *entity.BadgeAdd) (*entity.Badge, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	id, err := uc.badgeService.Add(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.badgeService.ByID(sess, id)
+}
+
+// Edit modifies an existing Badge.
+func (uc *BadgeUC) Edit(sess entity.Session, e *entity.BadgeEdit) (*entity.Badge, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	err := uc.badgeService.Edit(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.badgeService.ByID(sess, e.ID)
+}
+
+// Delete removes a Badge along with its grants.
+func (uc *BadgeUC) Delete(sess entity.Session, id int64) error {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return domain.ErrForbidden
+	}
+
+	return uc.badgeService.Delete(sess, id)
+}
+
+// All returns every Badge defined.
+func (uc *BadgeUC) All(sess entity.Session) ([]*entity.Badge, error) {
+	return uc.badgeService.All(sess)
+}
+
+// Backfill evaluates the rules of every Badge for every User, so that the Badges defined later and the ones earned
+// by time are granted as well. The number of Badges granted is returned.
+func (uc *BadgeUC) Backfill(sess entity.Session) (int64, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return 0, domain.ErrForbidden
+	}
+
+	granted, err := grantBadges(sess, uc.badgeService, uc.eventService, &entity.BadgeGrant{})
+
+	return int64(len(granted)), err
+}
+
+// grantBadges grants the Badges earned and announces every grant in the same transaction.
+func grantBadges(sess entity.Session, badgeService BadgeAdapter, eventService EventAdapter,
+	e *entity.BadgeGrant) ([]*entity.UserBadge, error) {
+	var granted []*entity.UserBadge
+
+	err := badgeService.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		granted, err = badgeService.Grant(sess, e)
+		if err != nil {
+			return err
+		}
+
+		for _, userBadge := range granted {
+			err = eventService.Publish(sess, entity.BadgeGrantedEvent{
+				UserID: userBadge.UserID,
+				Badge:  userBadge.Badge,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return granted, nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain/entity"
+)
+
+// FollowUC is a usecase of the follow and ignore lists.
+type FollowUC struct {
+	followService FollowAdapter
+	eventService  EventAdapter
+}
+
+// NewFollowUC instantiates a follow and ignore list usecase.
+func NewFollowUC(followService FollowAdapter, eventService EventAdapter) *FollowUC {
+	return &FollowUC{
+		followService: followService,
+		eventService:  eventService,
+	}
+}
+
+// Follow subscribes the current User to the new Topics and Posts of another one.
+func (uc *FollowUC) Follow(sess entity.Session, userID int64) error {
+	err := uc.followService.Follow(sess, &entity.UserFollow{
+		UserID:         sess.UserID,
+		FollowedUserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.eventService.Publish(sess, entity.UserFollowedEvent{
+		UserID:         sess.UserID,
+		FollowedUserID: userID,
+	})
+}
+
+// Unfollow unsubscribes the current User from another one.
+func (uc *FollowUC) Unfollow(sess entity.Session, userID int64) error {
+	return uc.followService.Unfollow(sess, &entity.UserFollow{
+		UserID:         sess.UserID,
+		FollowedUserID: userID,
+	})
+}
+
+// Ignore adds a User to the ignore list of the current User.
+func (uc *FollowUC) Ignore(sess entity.Session, userID int64) error {
+	return uc.followService.Ignore(sess, &entity.UserIgnore{
+		UserID:        sess.UserID,
+		IgnoredUserID: userID,
+	})
+}
+
+// Unignore removes a User from the ignore list of the current User.
+func (uc *FollowUC) Unignore(sess entity.Session, userID int64) error {
+	return uc.followService.Unignore(sess, &entity.UserIgnore{
+		UserID:        sess.UserID,
+		IgnoredUserID: userID,
+	})
+}
+
+// Feed returns the new Topics and Posts of the Users followed by the current User.
+func (uc *FollowUC) Feed(sess entity.Session, p *entity.Pagination) ([]*entity.FeedItem, error) {
+	return uc.followService.Feed(sess, sess.UserID, p)
+}
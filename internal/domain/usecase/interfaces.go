@@ -1,14 +1,16 @@
 package usecase
 
 import (
+	"context"
 	"simplestforum/internal/domain/entity"
+	"time"
 )
 
 // UserAdapter represents a set of User Service methods.
 type UserAdapter interface {
 	entity.Transactionable
 
-	AttachAdapters(TopicAdapter, PostAdapter)
+	AttachAdapters(TopicAdapter, PostAdapter, ProfileAdapter, FollowAdapter, RankAdapter, BadgeAdapter)
 
 	Add(entity.Session, *entity.UserAdd) (*entity.User, error)
 	Edit(entity.Session, *entity.UserEdit) error
@@ -17,6 +19,7 @@ type UserAdapter interface {
 
 	ByLoginAndPassword(entity.Session, string, string) (*entity.User, error)
 	PlainByID(entity.Session, int64) (*entity.User, error)
+	AddReputation(entity.Session, int64, int64) error
 
 	ExistsByID(entity.Session, int64) error
 }
@@ -25,9 +28,21 @@ type UserAdapter interface {
 type NotificationAdapter interface {
 	entity.Transactionable
 
+	AttachAdapters(RateLimitAdapter, FollowAdapter)
+
 	Add(entity.Session, *entity.NotificationAdd) (*entity.Notification, error)
+	AddForWatchers(entity.Session, *entity.WatchersNotificationAdd) ([]*entity.Notification, error)
+	Limit(entity.Session, entity.NotificationEvent) error
+	Notify(entity.Session, entity.NotificationEvent) ([]*entity.Notification, error)
+	MarkRead(entity.Session, *entity.NotificationMarkRead) error
 	Clear(entity.Session, int64) error
 	All(entity.Session, int64, *entity.Pagination) ([]*entity.Notification, error)
+	After(entity.Session, int64, int64, int64) ([]*entity.Notification, error)
+	CountUnread(entity.Session, int64) (int64, error)
+	Preferences(entity.Session, int64) ([]*entity.NotificationPreference, error)
+	SetPreference(entity.Session, *entity.NotificationPreferenceSet) (*entity.NotificationPreference, error)
+
+	SendPendingEmails(entity.Session, entity.NotificationEmailMode) error
 }
 
 // SectionAdapter represents a set of Section Service methods.
@@ -59,13 +74,25 @@ type TopicAdapter interface {
 
 	PlainByID(entity.Session, *entity.PlainTopicByID) (*entity.Topic, error)
 	ExistsByID(entity.Session, int64) error
+
+	MarkRead(entity.Session, *entity.TopicMarkRead) error
+
+	Merge(entity.Session, *entity.TopicMerge) (*entity.TopicRelocation, error)
+	Split(entity.Session, *entity.TopicSplit) (*entity.TopicRelocation, error)
+	Similar(entity.Session, *entity.TopicSimilarFilters) ([]*entity.SimilarTopic, error)
+	AcceptAnswer(entity.Session, *entity.TopicAnswerAccept) (*entity.TopicAnswerChange, error)
+	WithdrawAnswers(entity.Session, []int64) error
+	UpdateCounters(entity.Session, *entity.TopicCounters) error
+
+	Restore(entity.Session, int64) (*entity.Topic, error)
+	Purge(entity.Session, *entity.TrashPurge) (*entity.TrashPurgeResult, error)
 }
 
 // PostAdapter represents a set of Post Service methods.
 type PostAdapter interface {
 	entity.Transactionable
 
-	AttachAdapters(UserAdapter, TopicAdapter)
+	AttachAdapters(UserAdapter, TopicAdapter, FollowAdapter)
 
 	Add(entity.Session, *entity.PostAdd) (int64, error)
 	Edit(entity.Session, *entity.PostEdit) error
@@ -74,4 +101,169 @@ type PostAdapter interface {
 	All(entity.Session, *entity.PostFilters, *entity.Pagination, *entity.PostSort) ([]*entity.Post, error)
 
 	PlainByID(entity.Session, *entity.PlainPostByID) (*entity.Post, error)
+	CheckEditable(entity.Session, *entity.Post) error
+
+	Move(entity.Session, *entity.PostMove) error
+	AuthorCounts(entity.Session, *entity.PostFilters) ([]*entity.PostAuthorCount, error)
+	UpdateCounters(entity.Session, *entity.PostCounters) error
+
+	Restore(entity.Session, int64) error
+	Approve(entity.Session, int64) error
+	RestoreByTopic(entity.Session, int64, time.Time) error
+	Purge(entity.Session, time.Time) (int64, error)
+}
+
+// WatchAdapter represents a set of Watch Service methods.
+type WatchAdapter interface {
+	entity.Transactionable
+
+	AttachAdapters(TopicAdapter, SectionAdapter)
+
+	WatchTopic(entity.Session, *entity.TopicWatch) error
+	UnwatchTopic(entity.Session, *entity.TopicWatch) error
+	WatchSection(entity.Session, *entity.SectionWatch) error
+	UnwatchSection(entity.Session, *entity.SectionWatch) error
+}
+
+// ReportAdapter represents a set of Report Service methods.
+type ReportAdapter interface {
+	entity.Transactionable
+
+	AttachAdapters(UserAdapter, TopicAdapter, PostAdapter)
+
+	Add(entity.Session, *entity.ReportAdd) (int64, error)
+	Assign(entity.Session, *entity.ReportAssign) error
+	Resolve(entity.Session, *entity.ReportResolve) error
+	All(entity.Session, *entity.ReportFilters, *entity.Pagination) ([]*entity.Report, error)
+	PlainByID(entity.Session, int64) (*entity.Report, error)
+}
+
+// FilterAdapter represents a set of content filter Service methods.
+type FilterAdapter interface {
+	Check(entity.Session, *entity.FilterContent) (*entity.FilterResult, error)
+	Train(entity.Session, *entity.SpamSample) error
+}
+
+// RateLimitAdapter represents a set of rate limit Service methods.
+type RateLimitAdapter interface {
+	AttachAdapters(UserAdapter)
+
+	Take(entity.Session, entity.RateLimitAction) error
+}
+
+// SearchAdapter represents a set of search Service methods.
+type SearchAdapter interface {
+	AttachAdapters(TopicAdapter, PostAdapter)
+
+	Search(entity.Session, *entity.SearchQuery, *entity.Pagination) ([]*entity.SearchHit, error)
+}
+
+// ProfileAdapter represents a set of custom profile field Service methods.
+type ProfileAdapter interface {
+	entity.Transactionable
+
+	AddField(entity.Session, *entity.ProfileFieldAdd) (int64, error)
+	EditField(entity.Session, *entity.ProfileFieldEdit) error
+	DeleteField(entity.Session, int64) error
+	Fields(entity.Session) ([]*entity.ProfileField, error)
+	FieldByID(entity.Session, int64) (*entity.ProfileField, error)
+
+	SetValues(entity.Session, int64, []*entity.ProfileFieldValueSet, bool) error
+	Values(entity.Session, []int64) ([]*entity.ProfileFieldValue, error)
+}
+
+// FollowAdapter represents a set of follow and ignore list Service methods.
+type FollowAdapter interface {
+	entity.Transactionable
+
+	AttachAdapters(UserAdapter, TopicAdapter, PostAdapter)
+
+	Follow(entity.Session, *entity.UserFollow) error
+	Unfollow(entity.Session, *entity.UserFollow) error
+	Ignore(entity.Session, *entity.UserIgnore) error
+	Unignore(entity.Session, *entity.UserIgnore) error
+	Follows(entity.Session, *entity.UserFollowFilters) ([]*entity.UserFollow, error)
+	IgnoredIDs(entity.Session, int64) ([]int64, error)
+	Ignores(entity.Session, int64, int64) (bool, error)
+
+	Feed(entity.Session, int64, *entity.Pagination) ([]*entity.FeedItem, error)
+}
+
+// RankAdapter represents a set of rank ladder Service methods.
+type RankAdapter interface {
+	entity.Transactionable
+
+	Add(entity.Session, *entity.RankAdd) (int64, error)
+	Edit(entity.Session, *entity.RankEdit) error
+	Delete(entity.Session, int64) error
+	Ladder(entity.Session) (entity.RankLadder, error)
+	ByID(entity.Session, int64) (*entity.Rank, error)
+	Recalculate(entity.Session, ...int64) (int64, error)
+
+	ForgetLadder(entity.Session, entity.Event) error
+}
+
+// BadgeAdapter represents a set of Badge Service methods.
+type BadgeAdapter interface {
+	entity.Transactionable
+
+	Add(entity.Session, *entity.BadgeAdd) (int64, error)
+	Edit(entity.Session, *entity.BadgeEdit) error
+	Delete(entity.Session, int64) error
+	All(entity.Session) ([]*entity.Badge, error)
+	ByID(entity.Session, int64) (*entity.Badge, error)
+
+	UserBadges(entity.Session, []int64) ([]*entity.UserBadge, error)
+	Grant(entity.Session, *entity.BadgeGrant) ([]*entity.UserBadge, error)
+}
+
+// AuditAdapter represents a set of audit log Service methods.
+type AuditAdapter interface {
+	entity.Transactionable
+
+	AttachAdapters(UserAdapter)
+
+	Record(entity.Session, *entity.AuditEntryAdd) error
+	All(entity.Session, *entity.AuditFilters, *entity.Pagination) ([]*entity.AuditEntry, error)
+}
+
+// WebhookAdapter represents a set of Webhook Service methods.
+type WebhookAdapter interface {
+	entity.Transactionable
+
+	Add(entity.Session, *entity.WebhookAdd) (int64, error)
+	Edit(entity.Session, *entity.WebhookEdit) error
+	Delete(entity.Session, int64) error
+	All(entity.Session) ([]*entity.Webhook, error)
+	ByID(entity.Session, int64) (*entity.Webhook, error)
+
+	Matching(entity.Session, entity.EventType, *int64) ([]*entity.Webhook, error)
+	Payload(entity.Event) (*entity.WebhookPayload, error)
+	Deliver(entity.Session, *entity.WebhookTriggeredEvent) (*entity.WebhookDelivery, error)
+	Redeliver(entity.Session, int64) (*entity.WebhookDelivery, error)
+	Deliveries(entity.Session, *entity.WebhookDeliveryFilters, *entity.Pagination) ([]*entity.WebhookDelivery, error)
+}
+
+// EventAdapter represents a set of event bus Service methods.
+type EventAdapter interface {
+	entity.Transactionable
+
+	Subscribe(string, entity.EventDelivery, entity.EventHandler, ...entity.EventType)
+	Publish(entity.Session, entity.Event) error
+	Wait()
+
+	Dispatch(entity.Session) (*entity.OutboxDispatchResult, error)
+	Retry(entity.Session, int64) error
+	OutboxMessages(entity.Session, *entity.OutboxFilters, *entity.Pagination) ([]*entity.OutboxMessage, error)
+	OutboxMessageByID(entity.Session, int64) (*entity.OutboxMessage, error)
+
+	ListenBroadcasts(context.Context) <-chan struct{}
+	ReceiveBroadcasts(entity.Session) (*entity.BroadcastReceiveResult, error)
+	PruneBroadcasts(entity.Session, time.Time) (int64, error)
+}
+
+// LiveAdapter represents a set of live update Service methods.
+type LiveAdapter interface {
+	Listen(context.Context, ...entity.EventType) <-chan entity.Event
+	Push(entity.Session, entity.Event) error
 }
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// liveResumeBatchSize is the number of the missed Notifications read at once when a stream is resumed.
+const liveResumeBatchSize = 100
+
+// LiveUC is a live update usecase.
+type LiveUC struct {
+	liveService         LiveAdapter
+	postService         PostAdapter
+	topicService        TopicAdapter
+	sectionService      SectionAdapter
+	followService       FollowAdapter
+	notificationService NotificationAdapter
+}
+
+// NewLiveUC instantiates a live update usecase.
+func NewLiveUC(liveService LiveAdapter, postService PostAdapter, topicService TopicAdapter,
+	sectionService SectionAdapter, followService FollowAdapter, notificationService NotificationAdapter) *LiveUC {
+	return &LiveUC{
+		liveService:         liveService,
+		postService:         postService,
+		topicService:        topicService,
+		sectionService:      sectionService,
+		followService:       followService,
+		notificationService: notificationService,
+	}
+}
+
+// PostAdded streams the Posts published in the Topic until the context of the Session is done.
+// Every Post is read on behalf of the current User, so the ones it can't see or whose authors it ignores are skipped.
+func (uc *LiveUC) PostAdded(sess entity.Session, topicID int64) (<-chan *entity.Post, error) {
+	err := uc.topicService.ExistsByID(sess, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := uc.liveService.Listen(sess.Ctx, entity.EventTypePostCreated)
+	posts := make(chan *entity.Post)
+
+	go func() {
+		defer close(posts)
+
+		for event := range events {
+			e, ok := event.(entity.PostCreatedEvent)
+			if !ok || e.Post.TopicID != topicID {
+				continue
+			}
+
+			found, err := uc.postService.All(sess, &entity.PostFilters{
+				IDs:         []int64{e.Post.ID},
+				HideIgnored: true,
+			}, nil, nil)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case posts <- found[0]:
+			case <-sess.Ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return posts, nil
+}
+
+// TopicAdded streams the Topics created in the Section until the context of the Session is done.
+// Every Topic is read on behalf of the current User, so the ones it can't see or whose authors it ignores are skipped.
+func (uc *LiveUC) TopicAdded(sess entity.Session, sectionID int64) (<-chan *entity.Topic, error) {
+	err := uc.sectionService.ExistsByID(sess, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := uc.liveService.Listen(sess.Ctx, entity.EventTypeTopicCreated)
+	topics := make(chan *entity.Topic)
+
+	go func() {
+		defer close(topics)
+
+		for event := range events {
+			e, ok := event.(entity.TopicCreatedEvent)
+			if !ok || e.Topic.SectionID != sectionID {
+				continue
+			}
+
+			if sess.IsAuthorized() {
+				ignores, err := uc.followService.Ignores(sess, sess.UserID, e.Topic.UserID)
+				if err != nil || ignores {
+					continue
+				}
+			}
+
+			found, err := uc.topicService.All(sess, &entity.TopicFilters{
+				IDs: []int64{e.Topic.ID},
+			}, nil, nil)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case topics <- found[0]:
+			case <-sess.Ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return topics, nil
+}
+
+// NotificationReceived streams the Notifications of the current User until the context of the Session is done.
+func (uc *LiveUC) NotificationReceived(sess entity.Session) (<-chan *entity.Notification, error) {
+	return uc.notificationsReceived(sess, nil)
+}
+
+// NotificationReceivedAfter streams the Notifications of the current User created or merged into after the change
+// with the given number first, then the new ones until the context of the Session is done, so a client can resume
+// where it stopped.
+func (uc *LiveUC) NotificationReceivedAfter(sess entity.Session, afterSeq int64) (<-chan *entity.Notification, error) {
+	return uc.notificationsReceived(sess, &afterSeq)
+}
+
+// notificationsReceived streams the Notifications of the current User, the ones changed after afterSeq first, if any.
+func (uc *LiveUC) notificationsReceived(sess entity.Session, afterSeq *int64) (<-chan *entity.Notification, error) {
+	if !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	// Listening first, so the Notifications created while the missed ones are read aren't lost
+	events := uc.liveService.Listen(sess.Ctx, entity.EventTypeNotificationsAdded)
+	notifications := make(chan *entity.Notification)
+
+	go func() {
+		defer close(notifications)
+
+		send := func(notification *entity.Notification) bool {
+			select {
+			case notifications <- notification:
+				return true
+			case <-sess.Ctx.Done():
+				return false
+			}
+		}
+
+		// The last changes of the missed Notifications, so they aren't streamed twice once received live
+		resumed := make(map[int64]int64)
+
+		for last := afterSeq; last != nil; {
+			missed, err := uc.notificationService.After(sess, sess.UserID, *last, liveResumeBatchSize)
+			if err != nil {
+				return
+			}
+
+			for _, notification := range missed {
+				resumed[notification.ID] = notification.Seq
+				last = &notification.Seq
+
+				if !send(notification) {
+					return
+				}
+			}
+
+			if len(missed) < liveResumeBatchSize {
+				break
+			}
+		}
+
+		for event := range events {
+			e, ok := event.(entity.NotificationsAddedEvent)
+			if !ok {
+				continue
+			}
+
+			for _, notification := range e.Notifications {
+				if notification.UserID != sess.UserID {
+					continue
+				}
+
+				if seq, ok := resumed[notification.ID]; ok && seq >= notification.Seq {
+					continue
+				}
+
+				if !send(notification) {
+					return
+				}
+			}
+		}
+	}()
+
+	return notifications, nil
+}
@@ -20,6 +20,36 @@ func (uc *NotificationUC) Clear(sess entity.Session) error {
 	return uc.notificationService.Clear(sess, sess.UserID)
 }
 
+// MarkRead marks the given Notifications of the current User as read, or all of them if none are given.
+func (uc *NotificationUC) MarkRead(sess entity.Session, ids []int64) error {
+	return uc.notificationService.MarkRead(sess, &entity.NotificationMarkRead{
+		UserID: sess.UserID,
+		IDs:    ids,
+	})
+}
+
 func (uc *NotificationUC) All(sess entity.Session, p *entity.Pagination) ([]*entity.Notification, error) {
 	return uc.notificationService.All(sess, sess.UserID, p)
 }
+
+// CountUnread returns the number of unread Notifications of the current User.
+func (uc *NotificationUC) CountUnread(sess entity.Session) (int64, error) {
+	return uc.notificationService.CountUnread(sess, sess.UserID)
+}
+
+// Preferences returns the Notification preferences of the current User for every kind.
+func (uc *NotificationUC) Preferences(sess entity.Session) ([]*entity.NotificationPreference, error) {
+	return uc.notificationService.Preferences(sess, sess.UserID)
+}
+
+// SetPreference changes the Notification preference of the current User for a single kind.
+func (uc *NotificationUC) SetPreference(sess entity.Session, e *entity.NotificationPreferenceSet) (*entity.NotificationPreference, error) {
+	e.UserID = sess.UserID
+
+	return uc.notificationService.SetPreference(sess, e)
+}
+
+// SendPendingEmails sends the queued notification emails of the given mode which are due.
+func (uc *NotificationUC) SendPendingEmails(sess entity.Session, mode entity.NotificationEmailMode) error {
+	return uc.notificationService.SendPendingEmails(sess, mode)
+}
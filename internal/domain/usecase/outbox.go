@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// OutboxUC is an outbox usecase.
+type OutboxUC struct {
+	eventService EventAdapter
+}
+
+// NewOutboxUC instantiates an outbox usecase.
+func NewOutboxUC(eventService EventAdapter) *OutboxUC {
+	return &OutboxUC{
+		eventService: eventService,
+	}
+}
+
+// All returns the messages waiting in the outbox, i.e. the failing and the dead-lettered ones.
+func (uc *OutboxUC) All(sess entity.Session, f *entity.OutboxFilters, p *entity.Pagination) ([]*entity.OutboxMessage, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.eventService.OutboxMessages(sess, f, p)
+}
+
+// Retry puts an outbox message back into the queue.
+func (uc *OutboxUC) Retry(sess entity.Session, id int64) (*entity.OutboxMessage, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	err := uc.eventService.Retry(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.eventService.OutboxMessageByID(sess, id)
+}
+
+// Dispatch delivers a batch of the due outbox messages.
+func (uc *OutboxUC) Dispatch(sess entity.Session) (*entity.OutboxDispatchResult, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.eventService.Dispatch(sess)
+}
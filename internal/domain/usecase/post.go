@@ -1,24 +1,30 @@
 package usecase
 
 import (
-	"fmt"
 	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 )
 
 // PostUC is a Post usecase.
 type PostUC struct {
-	postService         PostAdapter
-	userService         UserAdapter
-	notificationService NotificationAdapter
+	postService      PostAdapter
+	eventService     EventAdapter
+	watchService     WatchAdapter
+	filterService    FilterAdapter
+	rateLimitService RateLimitAdapter
+	auditService     AuditAdapter
 }
 
 // NewPostUC instantiates a Post usecase.
-func NewPostUC(postService PostAdapter, userService UserAdapter, notificationService NotificationAdapter) *PostUC {
+func NewPostUC(postService PostAdapter, eventService EventAdapter, watchService WatchAdapter,
+	filterService FilterAdapter, rateLimitService RateLimitAdapter, auditService AuditAdapter) *PostUC {
 	return &PostUC{
-		postService:         postService,
-		userService:         userService,
-		notificationService: notificationService,
+		postService:      postService,
+		eventService:     eventService,
+		watchService:     watchService,
+		filterService:    filterService,
+		rateLimitService: rateLimitService,
+		auditService:     auditService,
 	}
 }
 
@@ -30,55 +36,59 @@ func (uc *PostUC) Add(sess entity.Session, e *entity.PostAdd) (*entity.Post, err
 
 	e.UserID = sess.UserID
 
-	// Creating a new Post
-	postID, err := uc.postService.Add(sess, e)
+	// Spending a token of the post quota before any work is done
+	err := uc.rateLimitService.Take(sess, entity.RateLimitActionPost)
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		shouldUpdateRank bool
-		newRank          int64
-	)
+	// Running the text through the content filters
+	filtered, err := uc.filterService.Check(sess, &entity.FilterContent{
+		Kind:   entity.FilterContentKindPost,
+		UserID: e.UserID,
+		Text:   e.Text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if filtered.Verdict == entity.FilterVerdictReject {
+		return nil, domain.NewError(domain.ErrCodeValidation, "The post was rejected by the content filters")
+	}
+
+	e.Text = filtered.Text
+	e.Flagged = filtered.Verdict == entity.FilterVerdictFlag
+
+	var postID int64
+
+	err = uc.postService.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
 
-	err = uc.userService.DoTransaction(sess, func() error {
-		// Getting info about the author
-		user, err := uc.userService.PlainByID(sess, e.UserID)
+		// Creating a new Post
+		postID, err = uc.postService.Add(sess, e)
 		if err != nil {
 			return err
 		}
 
-		// Increasing the number of posts
-		var (
-			newCountPosts = user.CountPosts + 1
-			userEdit      = &entity.UserEdit{
-				ID:         user.ID,
-				CountPosts: &newCountPosts,
-			}
-		)
-
-		shouldUpdateRank = user.PostsUntilNextRank() == 1
+		// Replying to a topic means watching it
+		err = uc.watchService.WatchTopic(sess, &entity.TopicWatch{
+			UserID:  e.UserID,
+			TopicID: e.TopicID,
+		})
+		if err != nil {
+			return err
+		}
 
-		// If a new newRank is coming up, updating it as well
-		if shouldUpdateRank {
-			newRank = user.Rank + 1
-			userEdit.Rank = &newRank
+		// A pending post is announced once it is approved
+		if e.Status != entity.PostStatusPublished {
+			return nil
 		}
 
-		// Applying the new data to the User
-		return uc.userService.Edit(sess, userEdit)
+		return uc.publishCreated(sess, postID)
 	})
 
 	if err != nil {
-		return uc.ByID(sess, postID)
-	}
-
-	// If the newRank was updated, notify
-	if shouldUpdateRank {
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: e.UserID,
-			Text:   fmt.Sprintf("You achieved the newRank %d, congratulations!", newRank),
-		})
+		return nil, err
 	}
 
 	return uc.ByID(sess, postID)
@@ -96,25 +106,29 @@ func (uc *PostUC) Edit(sess entity.Session, e *entity.PostEdit) (*entity.Post, e
 		post       *entity.Post
 	)
 
-	err := uc.postService.DoTransaction(sess, func() error {
+	err := uc.postService.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
-		// If current user doesn't have privileges, fetch the current state of the post to get its author ID
-		if !isMod {
-			postBefore, err = uc.postService.PlainByID(sess, &entity.PlainPostByID{
-				ID:         e.ID,
-				FetchTopic: e.TopicID != nil,
-				FetchUser:  e.UserID != nil,
-			})
+		// Fetch the current state of the post to check who can edit it, to notify about moves and to audit the change
+		postBefore, err = uc.postService.PlainByID(sess, &entity.PlainPostByID{
+			ID:         e.ID,
+			FetchTopic: e.TopicID != nil,
+			FetchUser:  e.UserID != nil,
+		})
 
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			return err
+		}
 
-			// If it's someone else's post or the 'protected' fields are to be modified, return an error
-			if sess.UserID != postBefore.UserID || e.TopicID != nil || e.UserID != nil {
-				return domain.ErrForbidden
-			}
+		// Only the moderators can modify the 'protected' fields
+		if !isMod && (e.TopicID != nil || e.UserID != nil || e.Wiki != nil) {
+			return domain.ErrForbidden
+		}
+
+		// The others' posts can only be edited if they are wiki, and the own ones until the edit window is over
+		err = uc.postService.CheckEditable(sess, postBefore)
+		if err != nil {
+			return err
 		}
 
 		// Apply the modification
@@ -125,73 +139,254 @@ func (uc *PostUC) Edit(sess entity.Session, e *entity.PostEdit) (*entity.Post, e
 
 		// Fetch the modified post with any embedded fields
 		post, err = uc.ByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
 
-		return err
+		// Editing someone else's post or the 'protected' fields is a privilege of the moderators
+		if isMod && (postBefore.UserID != sess.UserID || e.TopicID != nil || e.UserID != nil || e.Wiki != nil) {
+			err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionPostEdit, post,
+				postBefore.AuditState(), post.AuditState()))
+			if err != nil {
+				return err
+			}
+		}
+
+		// If the topic was changed, announce it
+		if e.TopicID != nil {
+			err = uc.eventService.Publish(sess, entity.PostMovedEvent{
+				ActorID: sess.UserID,
+				Post:    post,
+				From:    postBefore.Topic,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// If the user was changed, announce it
+		if e.UserID != nil {
+			return uc.eventService.Publish(sess, entity.PostReassignedEvent{
+				ActorID: sess.UserID,
+				Post:    post,
+				From:    postBefore.User,
+			})
+		}
+
+		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	// If the topic was changed, notify about it
-	if e.TopicID != nil {
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: post.UserID,
-			Text:   fmt.Sprintf("Your post #%d was moved from topic %s to topic %s", post.ID, postBefore.Topic.Name, post.Topic.Name),
-		})
+	return post, nil
+}
+
+// Delete removes an existing Post.
+func (uc *PostUC) Delete(sess entity.Session, id int64) error {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return domain.ErrForbidden
 	}
 
-	// If the user was changed, notify both about it
-	if e.UserID != nil {
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: postBefore.UserID,
-			Text:   fmt.Sprintf("Your post #%d was assigned to user %s", post.ID, post.User.Nickname),
+	return uc.postService.DoTransaction(sess, func(sess entity.Session) error {
+		// Fetching the post to get its author ID
+		post, err := uc.postService.PlainByID(sess, &entity.PlainPostByID{
+			ID: id,
 		})
 
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: post.UserID,
-			Text:   fmt.Sprintf("Post #%d was assigned from user %s to you", post.ID, postBefore.User.Nickname),
+		if err != nil {
+			return err
+		}
+
+		// Deleting the post
+		err = uc.postService.Delete(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionPostDelete, post,
+			post.AuditState(), nil))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.PostRemovedEvent{
+			ActorID: sess.UserID,
+			Post:    post,
+		})
+	})
+}
+
+// Restore brings back a deleted Post and notifies its author.
+func (uc *PostUC) Restore(sess entity.Session, id int64) (*entity.Post, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
+	}
+
+	var post *entity.Post
+
+	err := uc.postService.DoTransaction(sess, func(sess entity.Session) error {
+		err := uc.postService.Restore(sess, id)
+		if err != nil {
+			return err
+		}
+
+		post, err = uc.ByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionPostRestore, post,
+			nil, post.AuditState()))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.PostRestoredEvent{
+			ActorID: sess.UserID,
+			Post:    post,
 		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return post, nil
 }
 
-// Delete removes an existing Post.
-func (uc *PostUC) Delete(sess entity.Session, id int64) error {
+// AllDeleted selects the deleted Posts.
+func (uc *PostUC) AllDeleted(sess entity.Session, f *entity.PostFilters, p *entity.Pagination, s *entity.PostSort) ([]*entity.Post, error) {
 	if !sess.Level.AtLeast(entity.UserLevelMod) {
-		return domain.ErrForbidden
+		return nil, domain.ErrForbidden
+	}
+
+	if f == nil {
+		f = &entity.PostFilters{}
+	}
+
+	f.Deleted = true
+
+	return uc.postService.All(sess, f, p, s)
+}
+
+// Approve publishes a pending Post and notifies its author and the watchers.
+func (uc *PostUC) Approve(sess entity.Session, id int64) (*entity.Post, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
 	}
 
 	var post *entity.Post
 
-	err := uc.postService.DoTransaction(sess, func() error {
-		var err error
+	err := uc.postService.DoTransaction(sess, func(sess entity.Session) error {
+		postBefore, err := uc.postService.PlainByID(sess, &entity.PlainPostByID{
+			ID: id,
+		})
+		if err != nil {
+			return err
+		}
 
-		// Fetching the post to get its author ID
-		post, err = uc.postService.PlainByID(sess, &entity.PlainPostByID{
+		err = uc.postService.Approve(sess, id)
+		if err != nil {
+			return err
+		}
+
+		post, err = uc.ByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionPostApprove, post,
+			postBefore.AuditState(), post.AuditState()))
+		if err != nil {
+			return err
+		}
+
+		err = uc.eventService.Publish(sess, entity.PostApprovedEvent{
+			ActorID: sess.UserID,
+			Post:    post,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The post is announced to the watchers only now
+		return uc.publishCreated(sess, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// Reject deletes a pending Post and notifies its author.
+func (uc *PostUC) Reject(sess entity.Session, id int64) error {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return domain.ErrForbidden
+	}
+
+	return uc.postService.DoTransaction(sess, func(sess entity.Session) error {
+		post, err := uc.postService.PlainByID(sess, &entity.PlainPostByID{
 			ID: id,
 		})
+		if err != nil {
+			return err
+		}
 
+		if post.Status != entity.PostStatusPending || post.DeletedAt != nil {
+			return domain.NewError(domain.ErrCodeValidation, "Post with ID %d is not pending", id)
+		}
+
+		// A rejected post stays pending, so restoring it puts it back into the queue
+		err = uc.postService.Delete(sess, id)
 		if err != nil {
 			return err
 		}
 
-		// Deleting the post
-		return uc.postService.Delete(sess, id)
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionPostReject, post,
+			post.AuditState(), nil))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.PostRejectedEvent{
+			ActorID: sess.UserID,
+			Post:    post,
+		})
+	})
+}
+
+// AllPending selects the Posts waiting for approval.
+func (uc *PostUC) AllPending(sess entity.Session, f *entity.PostFilters, p *entity.Pagination, s *entity.PostSort) ([]*entity.Post, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
+	}
+
+	if f == nil {
+		f = &entity.PostFilters{}
+	}
+
+	f.Pending = true
+
+	return uc.postService.All(sess, f, p, s)
+}
+
+// publishCreated announces a published Post to the subscribers, once it's visible to everyone.
+func (uc *PostUC) publishCreated(sess entity.Session, postID int64) error {
+	post, err := uc.postService.PlainByID(sess, &entity.PlainPostByID{
+		ID:         postID,
+		FetchTopic: true,
+		FetchUser:  true,
 	})
 
 	if err != nil {
 		return err
 	}
 
-	// Notifying the author
-	_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-		UserID: post.UserID,
-		Text:   fmt.Sprintf("Your post #%d was removed", post.ID),
+	return uc.eventService.Publish(sess, entity.PostCreatedEvent{
+		Post: post,
 	})
-
-	return nil
 }
 
 // ByID returns a Posts by its ID.
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// ProfileUC is a custom profile field usecase.
+type ProfileUC struct {
+	profileService ProfileAdapter
+}
+
+// NewProfileUC instantiates a custom profile field usecase.
+func NewProfileUC(profileService ProfileAdapter) *ProfileUC {
+	return &ProfileUC{
+		profileService: profileService,
+	}
+}
+
+// AddField defines a new custom profile field.
+func (uc *ProfileUC) AddField(sess entity.Session, e *entity.ProfileFieldAdd) (*entity.ProfileField, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	id, err := uc.profileService.AddField(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.profileService.FieldByID(sess, id)
+}
+
+// EditField modifies an existing custom profile field.
+func (uc *ProfileUC) EditField(sess entity.Session, e *entity.ProfileFieldEdit) (*entity.ProfileField, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	err := uc.profileService.EditField(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.profileService.FieldByID(sess, e.ID)
+}
+
+// DeleteField removes a custom profile field along with the values Users gave to it.
+func (uc *ProfileUC) DeleteField(sess entity.Session, id int64) error {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return domain.ErrForbidden
+	}
+
+	return uc.profileService.DeleteField(sess, id)
+}
+
+// Fields returns all custom profile fields, so that the forms can be built.
+func (uc *ProfileUC) Fields(sess entity.Session) ([]*entity.ProfileField, error) {
+	return uc.profileService.Fields(sess)
+}
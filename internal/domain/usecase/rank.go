@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// RankUC is a rank ladder usecase.
+type RankUC struct {
+	rankService  RankAdapter
+	eventService EventAdapter
+}
+
+// NewRankUC instantiates a rank ladder usecase.
+func NewRankUC(rankService RankAdapter, eventService EventAdapter) *RankUC {
+	return &RankUC{
+		rankService:  rankService,
+		eventService: eventService,
+	}
+}
+
+// Add adds a new step to the rank ladder.
+func (uc *RankUC) Add(sess entity.Session, e *entity.RankAdd) (*entity.Rank, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	id, err := uc.rankService.Add(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.publishChanged(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.rankService.ByID(sess, id)
+}
+
+// Edit modifies an existing step of the rank ladder.
+func (uc *RankUC) Edit(sess entity.Session, e *entity.RankEdit) (*entity.Rank, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	err := uc.rankService.Edit(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.publishChanged(sess, e.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.rankService.ByID(sess, e.ID)
+}
+
+// Delete removes a step of the rank ladder.
+func (uc *RankUC) Delete(sess entity.Session, id int64) error {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return domain.ErrForbidden
+	}
+
+	err := uc.rankService.Delete(sess, id)
+	if err != nil {
+		return err
+	}
+
+	return uc.publishChanged(sess, id)
+}
+
+// Ladder returns the whole rank ladder.
+func (uc *RankUC) Ladder(sess entity.Session) (entity.RankLadder, error) {
+	return uc.rankService.Ladder(sess)
+}
+
+// Recalculate brings the ranks of every User in line with the ladder and returns the number of Users changed.
+func (uc *RankUC) Recalculate(sess entity.Session) (int64, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return 0, domain.ErrForbidden
+	}
+
+	return uc.rankService.Recalculate(sess)
+}
+
+// publishChanged announces a change of the ladder, so the other instances drop their cached ladders.
+func (uc *RankUC) publishChanged(sess entity.Session, rankID int64) error {
+	return uc.eventService.Publish(sess, entity.RankLadderChangedEvent{
+		RankID: rankID,
+	})
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// ReportUC is a Report usecase.
+type ReportUC struct {
+	reportService ReportAdapter
+	userService   UserAdapter
+	topicService  TopicAdapter
+	postService   PostAdapter
+	eventService  EventAdapter
+	auditService  AuditAdapter
+}
+
+// NewReportUC instantiates a Report usecase.
+func NewReportUC(reportService ReportAdapter, userService UserAdapter, topicService TopicAdapter, postService PostAdapter,
+	eventService EventAdapter, auditService AuditAdapter) *ReportUC {
+	return &ReportUC{
+		reportService: reportService,
+		userService:   userService,
+		topicService:  topicService,
+		postService:   postService,
+		eventService:  eventService,
+		auditService:  auditService,
+	}
+}
+
+// Add files a Report on behalf of the current User.
+func (uc *ReportUC) Add(sess entity.Session, e *entity.ReportAdd) (*entity.Report, error) {
+	e.ReporterID = sess.UserID
+
+	id, err := uc.reportService.Add(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.reportService.PlainByID(sess, id)
+}
+
+// Assign assigns an open Report to a moderator, or unassigns it.
+func (uc *ReportUC) Assign(sess entity.Session, e *entity.ReportAssign) (*entity.Report, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
+	}
+
+	err := uc.reportService.Assign(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.reportService.PlainByID(sess, e.ID)
+}
+
+// Resolve closes an open Report, applying the action to the reported content in the same transaction,
+// and notifies the affected User.
+func (uc *ReportUC) Resolve(sess entity.Session, e *entity.ReportResolve) (*entity.Report, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
+	}
+
+	e.ResolvedByID = sess.UserID
+
+	err := uc.reportService.DoTransaction(sess, func(sess entity.Session) error {
+		report, err := uc.reportService.PlainByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		// Closing the report
+		err = uc.reportService.Resolve(sess, e)
+		if err != nil {
+			return err
+		}
+
+		if e.Action == nil {
+			return nil
+		}
+
+		// Applying the action
+		event, err := uc.applyAction(sess, report, *e.Action)
+		if err != nil || event == nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, event)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.reportService.PlainByID(sess, e.ID)
+}
+
+// All returns the moderation queue.
+func (uc *ReportUC) All(sess entity.Session, f *entity.ReportFilters, p *entity.Pagination) ([]*entity.Report, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.reportService.All(sess, f, p)
+}
+
+// applyAction deletes the reported content or restricts its author, and returns the event to notify about.
+func (uc *ReportUC) applyAction(sess entity.Session, report *entity.Report, action entity.ReportAction) (entity.NotificationEvent, error) {
+	switch action {
+	case entity.ReportActionDeleteContent:
+		return uc.deleteTarget(sess, report)
+	case entity.ReportActionRestrictAuthor:
+		return uc.restrictTargetUser(sess, report, entity.UserRestrictionReadOnly)
+	case entity.ReportActionBanAuthor:
+		return uc.restrictTargetUser(sess, report, entity.UserRestrictionBanned)
+	}
+
+	return nil, domain.NewError(domain.ErrCodeValidation, "Unknown report action %s", action)
+}
+
+// deleteTarget deletes the reported Post or Topic.
+func (uc *ReportUC) deleteTarget(sess entity.Session, report *entity.Report) (entity.NotificationEvent, error) {
+	switch report.TargetType {
+	case entity.ReportTargetTypePost:
+		post, err := uc.postService.PlainByID(sess, &entity.PlainPostByID{
+			ID: report.TargetID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = uc.postService.Delete(sess, post.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionPostDelete, post,
+			post.AuditState(), nil))
+		if err != nil {
+			return nil, err
+		}
+
+		return entity.PostRemovedEvent{
+			ActorID: sess.UserID,
+			Post:    post,
+			Spam:    report.Reason == entity.ReportReasonSpam,
+		}, nil
+	case entity.ReportTargetTypeTopic:
+		topic, err := uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
+			ID: report.TargetID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = uc.topicService.Delete(sess, topic.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionTopicDelete, topic,
+			topic.AuditState(), nil))
+		if err != nil {
+			return nil, err
+		}
+
+		return entity.TopicRemovedEvent{
+			ActorID: sess.UserID,
+			Topic:   topic,
+		}, nil
+	}
+
+	return nil, domain.NewError(domain.ErrCodeValidation, "Only posts and topics can be deleted")
+}
+
+// restrictTargetUser restricts the author of the reported content or the reported User.
+func (uc *ReportUC) restrictTargetUser(sess entity.Session, report *entity.Report, restriction entity.UserRestriction) (entity.NotificationEvent, error) {
+	user, err := uc.userService.PlainByID(sess, report.TargetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The moderators are only restricted by the admins
+	if user.Level.AtLeast(entity.UserLevelMod) && !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	err = uc.userService.Edit(sess, &entity.UserEdit{
+		ID:          user.ID,
+		Restriction: &restriction,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	before := user.AuditState()
+	user.Restriction = restriction
+
+	err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionUserEdit, user,
+		before, user.AuditState()))
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.UserRestrictedEvent{
+		ActorID: sess.UserID,
+		User:    user,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// SearchUC is a search usecase.
+type SearchUC struct {
+	searchService SearchAdapter
+}
+
+// NewSearchUC instantiates a search usecase.
+func NewSearchUC(searchService SearchAdapter) *SearchUC {
+	return &SearchUC{
+		searchService: searchService,
+	}
+}
+
+// Search finds the published Posts and the Topics matching the query.
+func (uc *SearchUC) Search(sess entity.Session, e *entity.SearchQuery, p *entity.Pagination) ([]*entity.SearchHit, error) {
+	if e.DateFrom != nil && e.DateTo != nil && e.DateTo.Before(*e.DateFrom) {
+		return nil, domain.NewError(domain.ErrCodeValidation, "The end of the date range must not be before its start")
+	}
+
+	return uc.searchService.Search(sess, e, p)
+}
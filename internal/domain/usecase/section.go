@@ -8,12 +8,16 @@ import (
 // SectionUC is a Section usecase.
 type SectionUC struct {
 	sectionService SectionAdapter
+	eventService   EventAdapter
+	auditService   AuditAdapter
 }
 
 // NewSectionUC instantiates a Section usecase.
-func NewSectionUC(sectionService SectionAdapter) *SectionUC {
+func NewSectionUC(sectionService SectionAdapter, eventService EventAdapter, auditService AuditAdapter) *SectionUC {
 	return &SectionUC{
 		sectionService: sectionService,
+		eventService:   eventService,
+		auditService:   auditService,
 	}
 }
 
@@ -23,7 +27,21 @@ func (uc *SectionUC) Add(sess entity.Session, e *entity.SectionAdd) (*entity.Sec
 		return nil, domain.ErrForbidden
 	}
 
-	return uc.sectionService.Add(sess, e)
+	var section *entity.Section
+
+	err := uc.sectionService.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		section, err = uc.sectionService.Add(sess, e)
+		if err != nil {
+			return err
+		}
+
+		return uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionSectionAdd, section,
+			nil, section.AuditState()))
+	})
+
+	return section, err
 }
 
 // Edit updates an existing Section.
@@ -32,12 +50,29 @@ func (uc *SectionUC) Edit(sess entity.Session, e *entity.SectionEdit) (*entity.S
 		return nil, domain.ErrForbidden
 	}
 
-	err := uc.sectionService.Edit(sess, e)
-	if err != nil {
-		return nil, err
-	}
+	var section *entity.Section
 
-	return uc.ByID(sess, e.ID)
+	err := uc.sectionService.DoTransaction(sess, func(sess entity.Session) error {
+		sectionBefore, err := uc.sectionService.PlainByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		err = uc.sectionService.Edit(sess, e)
+		if err != nil {
+			return err
+		}
+
+		section, err = uc.ByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		return uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionSectionEdit, section,
+			sectionBefore.AuditState(), section.AuditState()))
+	})
+
+	return section, err
 }
 
 // Delete removes an existing Section.
@@ -46,7 +81,28 @@ func (uc *SectionUC) Delete(sess entity.Session, id int64) error {
 		return domain.ErrForbidden
 	}
 
-	return uc.sectionService.Delete(sess, id)
+	return uc.sectionService.DoTransaction(sess, func(sess entity.Session) error {
+		section, err := uc.sectionService.PlainByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.sectionService.Delete(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionSectionDelete, section,
+			section.AuditState(), nil))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.SectionRemovedEvent{
+			ActorID: sess.UserID,
+			Section: section,
+		})
+	})
 }
 
 // ByID returns a Section by its ID.
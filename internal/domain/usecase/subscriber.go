@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"errors"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// NotificationSubscriber reports the Events to the Users involved.
+type NotificationSubscriber struct {
+	notificationService NotificationAdapter
+	eventService        EventAdapter
+}
+
+// NewNotificationSubscriber instantiates a notification subscriber.
+func NewNotificationSubscriber(notificationService NotificationAdapter, eventService EventAdapter) *NotificationSubscriber {
+	return &NotificationSubscriber{
+		notificationService: notificationService,
+		eventService:        eventService,
+	}
+}
+
+// Handle notifies the watchers about the new Posts, and the Users involved about the rest of the Events.
+// The Notifications created are published once stored, so they can be pushed to their recipients.
+func (s *NotificationSubscriber) Handle(sess entity.Session, event entity.Event) error {
+	var (
+		notifications []*entity.Notification
+		err           error
+	)
+
+	switch e := event.(type) {
+	case entity.PostCreatedEvent:
+		// The notifications are created by a single statement, so the number of watchers doesn't affect the request
+		notifications, err = s.notificationService.AddForWatchers(sess, e.WatchersNotification())
+	case entity.NotificationEvent:
+		notifications, err = s.notificationService.Notify(sess, e)
+	}
+
+	if err != nil || len(notifications) == 0 {
+		return err
+	}
+
+	return s.eventService.Publish(sess, entity.NotificationsAddedEvent{
+		Notifications: notifications,
+	})
+}
+
+// Limit refuses the Events of the current User once its Notification quota is exhausted.
+func (s *NotificationSubscriber) Limit(sess entity.Session, event entity.Event) error {
+	if e, ok := event.(entity.NotificationEvent); ok {
+		return s.notificationService.Limit(sess, e)
+	}
+
+	return nil
+}
+
+// BadgeSubscriber grants the Badges earned by the activity.
+type BadgeSubscriber struct {
+	badgeService BadgeAdapter
+	eventService EventAdapter
+}
+
+// NewBadgeSubscriber instantiates a Badge subscriber.
+func NewBadgeSubscriber(badgeService BadgeAdapter, eventService EventAdapter) *BadgeSubscriber {
+	return &BadgeSubscriber{
+		badgeService: badgeService,
+		eventService: eventService,
+	}
+}
+
+// Handle grants the Badges whose rule is affected by the Event.
+func (s *BadgeSubscriber) Handle(sess entity.Session, event entity.Event) error {
+	var grant *entity.BadgeGrant
+
+	switch e := event.(type) {
+	case entity.PostCreatedEvent:
+		grant = &entity.BadgeGrant{
+			Rules:   []entity.BadgeRule{entity.BadgeRulePosts},
+			UserIDs: []int64{e.Post.UserID},
+		}
+	case entity.TopicCreatedEvent:
+		grant = &entity.BadgeGrant{
+			Rules:   []entity.BadgeRule{entity.BadgeRuleTopics},
+			UserIDs: []int64{e.Topic.UserID},
+		}
+	case entity.UserFollowedEvent:
+		grant = &entity.BadgeGrant{
+			Rules:   []entity.BadgeRule{entity.BadgeRuleFollowers},
+			UserIDs: []int64{e.FollowedUserID},
+		}
+	default:
+		return nil
+	}
+
+	_, err := grantBadges(sess, s.badgeService, s.eventService, grant)
+
+	return err
+}
+
+// RankSubscriber congratulates the Users on reaching a new rank.
+type RankSubscriber struct {
+	userService  UserAdapter
+	rankService  RankAdapter
+	eventService EventAdapter
+}
+
+// NewRankSubscriber instantiates a rank subscriber.
+func NewRankSubscriber(userService UserAdapter, rankService RankAdapter, eventService EventAdapter) *RankSubscriber {
+	return &RankSubscriber{
+		userService:  userService,
+		rankService:  rankService,
+		eventService: eventService,
+	}
+}
+
+// Handle publishes a RankAchievedEvent if the new Post is the one which got its author a new rank.
+// The rank itself follows the number of posts as it's recomputed.
+func (s *RankSubscriber) Handle(sess entity.Session, event entity.Event) error {
+	e, ok := event.(entity.PostCreatedEvent)
+	if !ok {
+		return nil
+	}
+
+	// Getting info about the author, the number of posts already includes the new one
+	user, err := s.userService.PlainByID(sess, e.Post.UserID)
+	if err != nil {
+		return err
+	}
+
+	ladder, err := s.rankService.Ladder(sess)
+	if err != nil {
+		return err
+	}
+
+	rank := ladder.ReachedAt(user.CountPosts)
+	if rank == nil {
+		return nil
+	}
+
+	return s.eventService.Publish(sess, entity.RankAchievedEvent{
+		UserID: user.ID,
+		Rank:   rank,
+	})
+}
+
+// FilterSubscriber teaches the content filters with the moderation decisions.
+type FilterSubscriber struct {
+	filterService FilterAdapter
+}
+
+// NewFilterSubscriber instantiates a content filter subscriber.
+func NewFilterSubscriber(filterService FilterAdapter) *FilterSubscriber {
+	return &FilterSubscriber{
+		filterService: filterService,
+	}
+}
+
+// Handle trains the content filters with the approved and rejected Posts, and the ones removed as spam.
+func (s *FilterSubscriber) Handle(sess entity.Session, event entity.Event) error {
+	var sample *entity.SpamSample
+
+	switch e := event.(type) {
+	case entity.PostApprovedEvent:
+		sample = &entity.SpamSample{
+			Text: e.Post.Text,
+		}
+	case entity.PostRejectedEvent:
+		sample = &entity.SpamSample{
+			Text: e.Post.Text,
+			Spam: true,
+		}
+	case entity.PostRemovedEvent:
+		if !e.Spam {
+			return nil
+		}
+
+		sample = &entity.SpamSample{
+			Text: e.Post.Text,
+			Spam: true,
+		}
+	default:
+		return nil
+	}
+
+	return s.filterService.Train(sess, sample)
+}
+
+// CounterSubscriber keeps the numbers of Posts and Topics of the Users, Topics and Sections up to date.
+// It is run synchronously, so the counters change in the same transaction as the Posts and Topics they count.
+type CounterSubscriber struct {
+	postService  PostAdapter
+	topicService TopicAdapter
+}
+
+// NewCounterSubscriber instantiates a counter subscriber.
+func NewCounterSubscriber(postService PostAdapter, topicService TopicAdapter) *CounterSubscriber {
+	return &CounterSubscriber{
+		postService:  postService,
+		topicService: topicService,
+	}
+}
+
+// Handle recomputes the counters affected by the Event.
+func (s *CounterSubscriber) Handle(sess entity.Session, event entity.Event) error {
+	var (
+		posts  *entity.PostCounters
+		topics *entity.TopicCounters
+	)
+
+	switch e := event.(type) {
+	case entity.PostCreatedEvent:
+		posts = &entity.PostCounters{PostIDs: []int64{e.Post.ID}}
+	case entity.PostMovedEvent:
+		posts = &entity.PostCounters{PostIDs: []int64{e.Post.ID}, TopicIDs: []int64{e.From.ID}}
+	case entity.PostReassignedEvent:
+		posts = &entity.PostCounters{PostIDs: []int64{e.Post.ID}, UserIDs: []int64{e.From.ID}}
+	case entity.PostRemovedEvent:
+		posts = &entity.PostCounters{PostIDs: []int64{e.Post.ID}}
+	case entity.PostRestoredEvent:
+		posts = &entity.PostCounters{PostIDs: []int64{e.Post.ID}}
+	case entity.PostRejectedEvent:
+		posts = &entity.PostCounters{PostIDs: []int64{e.Post.ID}}
+	case entity.TopicCreatedEvent:
+		topics = &entity.TopicCounters{TopicIDs: []int64{e.Topic.ID}}
+	case entity.TopicMovedEvent:
+		topics = &entity.TopicCounters{TopicIDs: []int64{e.Topic.ID}, SectionIDs: []int64{e.From.ID}}
+	case entity.TopicReassignedEvent:
+		topics = &entity.TopicCounters{TopicIDs: []int64{e.Topic.ID}, UserIDs: []int64{e.From.ID}}
+	case entity.TopicRemovedEvent:
+		// The Posts are deleted and restored along with their Topic
+		posts = &entity.PostCounters{TopicIDs: []int64{e.Topic.ID}}
+		topics = &entity.TopicCounters{TopicIDs: []int64{e.Topic.ID}}
+	case entity.TopicRestoredEvent:
+		posts = &entity.PostCounters{TopicIDs: []int64{e.Topic.ID}}
+		topics = &entity.TopicCounters{TopicIDs: []int64{e.Topic.ID}}
+	case entity.TopicsMergedEvent:
+		topics = &entity.TopicCounters{TopicIDs: []int64{e.Relocation.From.ID, e.Relocation.To.ID}}
+	case entity.TopicSplitEvent:
+		topics = &entity.TopicCounters{TopicIDs: []int64{e.Relocation.From.ID, e.Relocation.To.ID}}
+	case entity.SectionRemovedEvent:
+		posts = &entity.PostCounters{SectionIDs: []int64{e.Section.ID}}
+		topics = &entity.TopicCounters{SectionIDs: []int64{e.Section.ID}}
+	case entity.UserRemovedEvent:
+		posts = &entity.PostCounters{UserIDs: []int64{e.User.ID}, TopicUserIDs: []int64{e.User.ID}}
+		topics = &entity.TopicCounters{UserIDs: []int64{e.User.ID}}
+	}
+
+	if posts != nil {
+		err := s.postService.UpdateCounters(sess, posts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if topics != nil {
+		return s.topicService.UpdateCounters(sess, topics)
+	}
+
+	return nil
+}
+
+// WebhookSubscriber delivers the Events to the Webhooks registered by the admins.
+type WebhookSubscriber struct {
+	webhookService WebhookAdapter
+	topicService   TopicAdapter
+	eventService   EventAdapter
+}
+
+// NewWebhookSubscriber instantiates a Webhook subscriber.
+func NewWebhookSubscriber(webhookService WebhookAdapter, topicService TopicAdapter,
+	eventService EventAdapter) *WebhookSubscriber {
+	return &WebhookSubscriber{
+		webhookService: webhookService,
+		topicService:   topicService,
+		eventService:   eventService,
+	}
+}
+
+// Handle triggers the Webhooks whose filters the Event passes. A WebhookTriggeredEvent is published for every one
+// of them, so a failing Webhook is retried without sending the Event to the others again.
+func (s *WebhookSubscriber) Handle(sess entity.Session, event entity.Event) error {
+	sectionID, err := s.sectionID(sess, event)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := s.webhookService.Matching(sess, event.EventType(), sectionID)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	entity.HidePrivateInfo(event)
+
+	payload, err := s.webhookService.Payload(event)
+	if err != nil {
+		return err
+	}
+
+	return s.webhookService.DoTransaction(sess, func(sess entity.Session) error {
+		for _, webhook := range webhooks {
+			err := s.eventService.Publish(sess, entity.WebhookTriggeredEvent{
+				WebhookID: webhook.ID,
+				Payload:   payload,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Deliver sends the payload of a WebhookTriggeredEvent to its Webhook.
+func (s *WebhookSubscriber) Deliver(sess entity.Session, event entity.Event) error {
+	e, ok := event.(entity.WebhookTriggeredEvent)
+	if !ok {
+		return nil
+	}
+
+	_, err := s.webhookService.Deliver(sess, &e)
+
+	return err
+}
+
+// sectionID returns the Section the Event took place in, or nil if it's not related to a Section.
+func (s *WebhookSubscriber) sectionID(sess entity.Session, event entity.Event) (*int64, error) {
+	topic, post := entity.EventLocation(event)
+
+	// The Topic of the Post is only known by its ID
+	if topic == nil && post != nil {
+		var err error
+
+		topic, err = s.topicService.PlainByID(sess, &entity.PlainTopicByID{
+			ID: post.TopicID,
+		})
+		if err != nil {
+			var domainErr *domain.Error
+
+			// The Topic may be gone along with the Post
+			if errors.As(err, &domainErr) && domainErr.Is(domain.ErrNotFound) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+	}
+
+	if topic == nil {
+		return nil, nil
+	}
+
+	return &topic.SectionID, nil
+}
@@ -1,24 +1,30 @@
 package usecase
 
 import (
-	"fmt"
 	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 )
 
 // TopicUC is a Topic usecase.
 type TopicUC struct {
-	topicService        TopicAdapter
-	userService         UserAdapter
-	notificationService NotificationAdapter
+	topicService     TopicAdapter
+	eventService     EventAdapter
+	watchService     WatchAdapter
+	filterService    FilterAdapter
+	rateLimitService RateLimitAdapter
+	auditService     AuditAdapter
 }
 
 // NewTopicUC instantiates a Topic usecase.
-func NewTopicUC(topicService TopicAdapter, userService UserAdapter, notificationService NotificationAdapter) *TopicUC {
+func NewTopicUC(topicService TopicAdapter, eventService EventAdapter, watchService WatchAdapter,
+	filterService FilterAdapter, rateLimitService RateLimitAdapter, auditService AuditAdapter) *TopicUC {
 	return &TopicUC{
-		topicService:        topicService,
-		userService:         userService,
-		notificationService: notificationService,
+		topicService:     topicService,
+		eventService:     eventService,
+		watchService:     watchService,
+		filterService:    filterService,
+		rateLimitService: rateLimitService,
+		auditService:     auditService,
 	}
 }
 
@@ -30,20 +36,64 @@ func (uc *TopicUC) Add(sess entity.Session, e *entity.TopicAdd) (*entity.Topic,
 
 	e.UserID = sess.UserID
 
-	// Inserting the topic
-	topicID, err := uc.topicService.Add(sess, e)
+	// Spending a token of the topic quota before any work is done
+	err := uc.rateLimitService.Take(sess, entity.RateLimitActionTopic)
 	if err != nil {
 		return nil, err
 	}
 
-	// Increasing the user's topic count
-	inc := int64(1)
+	// Running the name through the content filters, topics are not held for approval, so flagging is only logged
+	filtered, err := uc.filterService.Check(sess, &entity.FilterContent{
+		Kind:   entity.FilterContentKindTopic,
+		UserID: e.UserID,
+		Text:   e.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if filtered.Verdict == entity.FilterVerdictReject {
+		return nil, domain.NewError(domain.ErrCodeValidation, "The topic was rejected by the content filters")
+	}
+
+	e.Name = filtered.Text
+
+	var topicID int64
+
+	err = uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		// Inserting the topic
+		topicID, err = uc.topicService.Add(sess, e)
+		if err != nil {
+			return err
+		}
+
+		// The author watches their own topic
+		err = uc.watchService.WatchTopic(sess, &entity.TopicWatch{
+			UserID:  e.UserID,
+			TopicID: topicID,
+		})
+		if err != nil {
+			return err
+		}
+
+		topic, err := uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
+			ID: topicID,
+		})
+		if err != nil {
+			return err
+		}
 
-	_ = uc.userService.Edit(sess, &entity.UserEdit{
-		ID:          e.UserID,
-		CountTopics: &inc,
+		return uc.eventService.Publish(sess, entity.TopicCreatedEvent{
+			Topic: topic,
+		})
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
 	return uc.ByID(sess, topicID)
 }
 
@@ -59,25 +109,23 @@ func (uc *TopicUC) Edit(sess entity.Session, e *entity.TopicEdit) (*entity.Topic
 		topic       *entity.Topic
 	)
 
-	err := uc.topicService.DoTransaction(sess, func() error {
+	err := uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
-		// If current user doesn't have privileges, fetch the current state of the topic to get its author ID
-		if !isMod {
-			topicBefore, err = uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
-				ID:           e.ID,
-				FetchSection: e.SectionID != nil,
-				FetchUser:    e.UserID != nil,
-			})
+		// Fetch the current state of the topic to check its author ID, to notify about moves and to audit the change
+		topicBefore, err = uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
+			ID:           e.ID,
+			FetchSection: e.SectionID != nil,
+			FetchUser:    e.UserID != nil,
+		})
 
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			return err
+		}
 
-			// If it's someone else's topic or the 'protected' fields are to be modified, return an error
-			if sess.UserID != topicBefore.UserID || e.SectionID != nil || e.UserID != nil {
-				return domain.ErrForbidden
-			}
+		// If it's someone else's topic or the 'protected' fields are to be modified, return an error
+		if !isMod && (sess.UserID != topicBefore.UserID || e.SectionID != nil || e.UserID != nil) {
+			return domain.ErrForbidden
 		}
 
 		// Apply the modification
@@ -88,74 +136,304 @@ func (uc *TopicUC) Edit(sess entity.Session, e *entity.TopicEdit) (*entity.Topic
 
 		// Fetch the modified topic with any embedded fields
 		topic, err = uc.ByID(sess, e.ID)
+		if err != nil {
+			return err
+		}
+
+		// Editing someone else's topic or the 'protected' fields is a privilege of the moderators
+		if isMod && (topicBefore.UserID != sess.UserID || e.SectionID != nil || e.UserID != nil) {
+			err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionTopicEdit, topic,
+				topicBefore.AuditState(), topic.AuditState()))
+			if err != nil {
+				return err
+			}
+		}
+
+		// If the section was changed, announce it
+		if e.SectionID != nil {
+			err = uc.eventService.Publish(sess, entity.TopicMovedEvent{
+				ActorID: sess.UserID,
+				Topic:   topic,
+				From:    topicBefore.Section,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// If the user was changed, announce it
+		if e.UserID != nil {
+			return uc.eventService.Publish(sess, entity.TopicReassignedEvent{
+				ActorID: sess.UserID,
+				Topic:   topic,
+				From:    topicBefore.User,
+			})
+		}
 
-		return err
+		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	// If the section was changed, notify about it
-	if e.SectionID != nil {
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: topic.UserID,
-			Text: fmt.Sprintf("Your topic %s was moved from section %s to section %s", topic.Name, topicBefore.Section.Name,
-				topic.Section.Name),
+	return topic, nil
+}
+
+// Delete removes an existing Topic.
+func (uc *TopicUC) Delete(sess entity.Session, id int64) error {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return domain.ErrForbidden
+	}
+
+	return uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
+		// Fetching the topic to get its author ID
+		topic, err := uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
+			ID: id,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		// Deleting the topic
+		err = uc.topicService.Delete(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionTopicDelete, topic,
+			topic.AuditState(), nil))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.TopicRemovedEvent{
+			ActorID: sess.UserID,
+			Topic:   topic,
 		})
+	})
+}
+
+// Restore brings back a deleted Topic along with the Posts deleted with it and notifies the author.
+func (uc *TopicUC) Restore(sess entity.Session, id int64) (*entity.Topic, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
 	}
 
-	// If the user was changed, notify both about it
-	if e.UserID != nil {
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: topicBefore.UserID,
-			Text:   fmt.Sprintf("Your topic %s was assigned to user %s", topic.Name, topic.User.Nickname),
+	err := uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
+		topic, err := uc.topicService.Restore(sess, id)
+		if err != nil {
+			return err
+		}
+
+		// The topic is returned as it was before being restored
+		restored, err := uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
+			ID: id,
 		})
+		if err != nil {
+			return err
+		}
 
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: topic.UserID,
-			Text:   fmt.Sprintf("Topic %s was assigned from user %s to you", topic.Name, topicBefore.User.Nickname),
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionTopicRestore, restored,
+			nil, restored.AuditState()))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.TopicRestoredEvent{
+			ActorID: sess.UserID,
+			Topic:   topic,
 		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return topic, nil
+	return uc.ByID(sess, id)
 }
 
-// Delete removes an existing Topic.
-func (uc *TopicUC) Delete(sess entity.Session, id int64) error {
+// Purge removes the Topics and Posts deleted before the given time for good.
+func (uc *TopicUC) Purge(sess entity.Session, e *entity.TrashPurge) (*entity.TrashPurgeResult, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.topicService.Purge(sess, e)
+}
+
+// AllDeleted selects the deleted Topics.
+func (uc *TopicUC) AllDeleted(sess entity.Session, f *entity.TopicFilters, p *entity.Pagination, s *entity.TopicSort) ([]*entity.Topic, error) {
 	if !sess.Level.AtLeast(entity.UserLevelMod) {
-		return domain.ErrForbidden
+		return nil, domain.ErrForbidden
+	}
+
+	if f == nil {
+		f = &entity.TopicFilters{}
+	}
+
+	f.Deleted = true
+	f.UnreadOnly = false
+
+	return uc.topicService.All(sess, f, p, s)
+}
+
+// Merge moves every Post of the source Topic into the target one and notifies each author of the moved Posts once.
+func (uc *TopicUC) Merge(sess entity.Session, e *entity.TopicMerge) (*entity.Topic, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
 	}
 
-	var topic *entity.Topic
+	var relocation *entity.TopicRelocation
 
-	err := uc.topicService.DoTransaction(sess, func() error {
+	err := uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
-		// Fetching the topic to get its author ID
-		topic, err = uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
-			ID: id,
+		relocation, err = uc.topicService.Merge(sess, e)
+		if err != nil {
+			return err
+		}
+
+		// The source topic turns into a redirect to the target one
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionTopicMerge, relocation.From,
+			relocation.From.AuditState(), entity.AuditState{"merged_into_topic_id": relocation.To.ID}))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.TopicsMergedEvent{
+			ActorID:    sess.UserID,
+			Relocation: relocation,
 		})
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	return uc.ByID(sess, relocation.To.ID)
+}
+
+// Split creates a new Topic out of the selected Posts and notifies each author of the moved Posts once.
+func (uc *TopicUC) Split(sess entity.Session, e *entity.TopicSplit) (*entity.Topic, error) {
+	if !sess.Level.AtLeast(entity.UserLevelMod) {
+		return nil, domain.ErrForbidden
+	}
+
+	var relocation *entity.TopicRelocation
+
+	err := uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		relocation, err = uc.topicService.Split(sess, e)
 		if err != nil {
 			return err
 		}
 
-		// Deleting the topic
-		return uc.topicService.Delete(sess, id)
+		// The new topic is made out of the posts of the source one
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionTopicSplit, relocation.To,
+			entity.AuditState{"topic_id": relocation.From.ID, "post_ids": e.PostIDs}, relocation.To.AuditState()))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.TopicSplitEvent{
+			ActorID:    sess.UserID,
+			Relocation: relocation,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.ByID(sess, relocation.To.ID)
+}
+
+// AcceptAnswer accepts a Post as the answer to the question asked in the Topic and notifies its author.
+// Only the author of the Topic and the moderators can do it.
+func (uc *TopicUC) AcceptAnswer(sess entity.Session, topicID, postID int64) (*entity.Topic, error) {
+	return uc.changeAnswer(sess, &entity.TopicAnswerAccept{
+		TopicID: topicID,
+		PostID:  &postID,
+	})
+}
+
+// UnacceptAnswer withdraws the accepted answer of the Topic.
+// Only the author of the Topic and the moderators can do it.
+func (uc *TopicUC) UnacceptAnswer(sess entity.Session, topicID int64) (*entity.Topic, error) {
+	return uc.changeAnswer(sess, &entity.TopicAnswerAccept{
+		TopicID: topicID,
+	})
+}
+
+// changeAnswer applies the change of the accepted answer on behalf of the author of the Topic or a moderator.
+func (uc *TopicUC) changeAnswer(sess entity.Session, e *entity.TopicAnswerAccept) (*entity.Topic, error) {
+	if sess.Restriction.AtLeast(entity.UserRestrictionReadOnly) {
+		return nil, domain.ErrRestricted
+	}
+
+	err := uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
+		if !sess.Level.AtLeast(entity.UserLevelMod) {
+			topic, err := uc.topicService.PlainByID(sess, &entity.PlainTopicByID{
+				ID: e.TopicID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if topic.UserID != sess.UserID {
+				return domain.ErrForbidden
+			}
+		}
+
+		change, err := uc.topicService.AcceptAnswer(sess, e)
+		if err != nil || change.Accepted == nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.AnswerAcceptedEvent{
+			ActorID: sess.UserID,
+			Topic:   change.Topic,
+			Post:    change.Accepted,
+		})
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Notifying the author
-	_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-		UserID: topic.UserID,
-		Text:   fmt.Sprintf("Your topic %s was removed", topic.Name),
+	return uc.ByID(sess, e.TopicID)
+}
+
+// SuggestSimilar returns the existing Topics similar to the name of a Topic about to be created,
+// optionally within a single Section.
+func (uc *TopicUC) SuggestSimilar(sess entity.Session, name string, sectionID *int64) ([]*entity.SimilarTopic, error) {
+	return uc.topicService.Similar(sess, &entity.TopicSimilarFilters{
+		Name:      name,
+		SectionID: sectionID,
 	})
+}
 
-	return nil
+// MarkTopicRead marks every Post in the Topic as read by the current User.
+func (uc *TopicUC) MarkTopicRead(sess entity.Session, id int64) error {
+	return uc.topicService.DoTransaction(sess, func(sess entity.Session) error {
+		err := uc.topicService.ExistsByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		return uc.topicService.MarkRead(sess, &entity.TopicMarkRead{
+			UserID:   sess.UserID,
+			TopicIDs: []int64{id},
+		})
+	})
+}
+
+// MarkSectionRead marks every Post in every Topic of the Section as read by the current User.
+func (uc *TopicUC) MarkSectionRead(sess entity.Session, sectionID int64) error {
+	return uc.topicService.MarkRead(sess, &entity.TopicMarkRead{
+		UserID:     sess.UserID,
+		SectionIDs: []int64{sectionID},
+	})
 }
 
 // ByID returns a Topic by its ID.
@@ -173,5 +451,10 @@ func (uc *TopicUC) ByID(sess entity.Session, id int64) (*entity.Topic, error) {
 
 // All selects all Sections.
 func (uc *TopicUC) All(sess entity.Session, f *entity.TopicFilters, p *entity.Pagination, s *entity.TopicSort) ([]*entity.Topic, error) {
+	// Unread topics only make sense for a logged-in user
+	if f != nil && f.UnreadOnly && !sess.IsAuthorized() {
+		return nil, domain.ErrNotAuthorized
+	}
+
 	return uc.topicService.All(sess, f, p, s)
 }
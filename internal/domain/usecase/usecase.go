@@ -5,11 +5,22 @@ import "simplestforum/internal/delivery/gql/resolvers"
 // NewAdapters creates a list of all abstract Usecases.
 func NewAdapters(s *Adapters) *resolvers.Interactors {
 	return &resolvers.Interactors{
-		User:         NewUserUC(s.User, s.Notification),
-		Section:      NewSectionUC(s.Section),
-		Topic:        NewTopicUC(s.Topic, s.User, s.Notification),
-		Post:         NewPostUC(s.Post, s.User, s.Notification),
+		User:         NewUserUC(s.User, s.Event, s.RateLimit, s.Profile, s.Audit),
+		Section:      NewSectionUC(s.Section, s.Event, s.Audit),
+		Topic:        NewTopicUC(s.Topic, s.Event, s.Watch, s.Filter, s.RateLimit, s.Audit),
+		Post:         NewPostUC(s.Post, s.Event, s.Watch, s.Filter, s.RateLimit, s.Audit),
 		Notification: NewNotificationUC(s.Notification),
+		Watch:        NewWatchUC(s.Watch),
+		Report:       NewReportUC(s.Report, s.User, s.Topic, s.Post, s.Event, s.Audit),
+		Search:       NewSearchUC(s.Search),
+		Profile:      NewProfileUC(s.Profile),
+		Follow:       NewFollowUC(s.Follow, s.Event),
+		Rank:         NewRankUC(s.Rank, s.Event),
+		Badge:        NewBadgeUC(s.Badge, s.Event),
+		Audit:        NewAuditUC(s.Audit),
+		Outbox:       NewOutboxUC(s.Event),
+		Webhook:      NewWebhookUC(s.Webhook),
+		Live:         NewLiveUC(s.Live, s.Post, s.Topic, s.Section, s.Follow, s.Notification),
 	}
 }
 
@@ -20,4 +31,17 @@ type Adapters struct {
 	Section      SectionAdapter
 	Topic        TopicAdapter
 	Post         PostAdapter
+	Watch        WatchAdapter
+	Report       ReportAdapter
+	Filter       FilterAdapter
+	RateLimit    RateLimitAdapter
+	Search       SearchAdapter
+	Profile      ProfileAdapter
+	Follow       FollowAdapter
+	Rank         RankAdapter
+	Badge        BadgeAdapter
+	Audit        AuditAdapter
+	Event        EventAdapter
+	Webhook      WebhookAdapter
+	Live         LiveAdapter
 }
@@ -1,38 +1,63 @@
 package usecase
 
 import (
-	"fmt"
 	"simplestforum/internal/domain"
 	"simplestforum/internal/domain/entity"
 )
 
 // UserUC is a User usecase.
 type UserUC struct {
-	userService         UserAdapter
-	notificationService NotificationAdapter
+	userService      UserAdapter
+	eventService     EventAdapter
+	rateLimitService RateLimitAdapter
+	profileService   ProfileAdapter
+	auditService     AuditAdapter
 }
 
 // NewUserUC instantiates a User usecase.
-func NewUserUC(userService UserAdapter, notificationService NotificationAdapter) *UserUC {
+func NewUserUC(userService UserAdapter, eventService EventAdapter, rateLimitService RateLimitAdapter,
+	profileService ProfileAdapter, auditService AuditAdapter) *UserUC {
 	return &UserUC{
-		userService:         userService,
-		notificationService: notificationService,
+		userService:      userService,
+		eventService:     eventService,
+		rateLimitService: rateLimitService,
+		profileService:   profileService,
+		auditService:     auditService,
 	}
 }
 
 // Add creates a new User.
 func (uc *UserUC) Add(sess entity.Session, e *entity.UserAdd) (*entity.User, error) {
-	// Adding a user
-	user, err := uc.userService.Add(sess, e)
+	// Limiting the registrations from a single client
+	err := uc.rateLimitService.Take(sess, entity.RateLimitActionRegistration)
 	if err != nil {
 		return nil, err
 	}
 
-	// Adding a welcome notification
-	_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-		UserID: user.ID,
-		Text:   "Welcome to the forum!",
+	var user *entity.User
+
+	err = uc.userService.DoTransaction(sess, func(sess entity.Session) error {
+		var err error
+
+		// Adding a user
+		user, err = uc.userService.Add(sess, e)
+		if err != nil {
+			return err
+		}
+
+		// Saving the custom profile fields, the required ones must be filled in right away
+		err = uc.profileService.SetValues(sess, user.ID, e.ProfileFields, true)
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.UserRegisteredEvent{
+			User: user,
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
@@ -40,22 +65,24 @@ func (uc *UserUC) Add(sess entity.Session, e *entity.UserAdd) (*entity.User, err
 // Edit updates an existing User.
 func (uc *UserUC) Edit(sess entity.Session, e *entity.UserEdit) (*entity.User, error) {
 	var (
-		shouldRetrieveUserBefore = e.Level != nil || e.Restriction != nil
-		protectedFieldsChanged   = shouldRetrieveUserBefore || e.Rank != nil || e.CountPosts != nil || e.CountTopics != nil
-		userBefore               *entity.User
-		user                     *entity.User
+		protectedFieldsChanged = e.Level != nil || e.Restriction != nil || e.Rank != nil || e.CountPosts != nil ||
+			e.CountTopics != nil
+		privileged = e.ID != sess.UserID || protectedFieldsChanged
+		userBefore *entity.User
+		user       *entity.User
 	)
 
-	err := uc.userService.DoTransaction(sess, func() error {
+	err := uc.userService.DoTransaction(sess, func(sess entity.Session) error {
 		var err error
 
 		// If we're editing another user or 'protected' fields, and we're not the admin, return an error
-		if (e.ID != sess.UserID || protectedFieldsChanged) && !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		if privileged && !sess.Level.AtLeast(entity.UserLevelAdmin) {
 			return domain.ErrForbidden
 		}
 
-		// If the level or restriction were changed, fetch the current state of the User for the previous values
-		if shouldRetrieveUserBefore {
+		// If it's a privileged change, fetch the current state of the User for the previous level and restriction,
+		// and to audit the change
+		if privileged {
 			userBefore, err = uc.userService.PlainByID(sess, e.ID)
 			if err != nil {
 				return err
@@ -68,32 +95,52 @@ func (uc *UserUC) Edit(sess entity.Session, e *entity.UserEdit) (*entity.User, e
 			return err
 		}
 
+		// Saving the custom profile fields if any were given
+		if e.ProfileFields != nil {
+			err = uc.profileService.SetValues(sess, e.ID, e.ProfileFields, false)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Fetch the modified user with any embedded fields
 		user, err = uc.ByID(sess, e.ID)
+		if err != nil || !privileged {
+			return err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionUserEdit, user,
+			userBefore.AuditState(), user.AuditState()))
+		if err != nil {
+			return err
+		}
 
-		return err
+		// If the level was changed, announce it
+		if userBefore.Level != user.Level {
+			err = uc.eventService.Publish(sess, entity.UserLevelChangedEvent{
+				ActorID: sess.UserID,
+				User:    user,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// If the restriction was changed, announce it
+		if userBefore.Restriction != user.Restriction {
+			return uc.eventService.Publish(sess, entity.UserRestrictedEvent{
+				ActorID: sess.UserID,
+				User:    user,
+			})
+		}
+
+		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	// If the level was changed, notify about it
-	if e.Level != nil && userBefore.Level != user.Level {
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: user.ID,
-			Text:   fmt.Sprintf("Your privilege level has been changed to %s", user.Level),
-		})
-	}
-
-	// If the restriction was changed, notify about it
-	if e.Restriction != nil && userBefore.Restriction != user.Restriction {
-		_, _ = uc.notificationService.Add(sess, &entity.NotificationAdd{
-			UserID: user.ID,
-			Text:   fmt.Sprintf("Your restriction level has been changed to %s", user.Restriction),
-		})
-	}
-
 	return user, nil
 }
 
@@ -103,7 +150,28 @@ func (uc *UserUC) Delete(sess entity.Session, id int64) error {
 		return domain.ErrForbidden
 	}
 
-	return uc.userService.Delete(sess, id)
+	return uc.userService.DoTransaction(sess, func(sess entity.Session) error {
+		user, err := uc.userService.PlainByID(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.userService.Delete(sess, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.auditService.Record(sess, entity.NewAuditEntry(entity.AuditActionUserDelete, user,
+			user.AuditState(), nil))
+		if err != nil {
+			return err
+		}
+
+		return uc.eventService.Publish(sess, entity.UserRemovedEvent{
+			ActorID: sess.UserID,
+			User:    user,
+		})
+	})
 }
 
 // ByID returns a User by its ID.
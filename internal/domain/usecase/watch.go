@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain/entity"
+)
+
+// WatchUC is a Watch usecase.
+type WatchUC struct {
+	watchService WatchAdapter
+}
+
+// NewWatchUC instantiates a Watch usecase.
+func NewWatchUC(watchService WatchAdapter) *WatchUC {
+	return &WatchUC{
+		watchService: watchService,
+	}
+}
+
+// WatchTopic subscribes the current User to the new Posts in a Topic.
+func (uc *WatchUC) WatchTopic(sess entity.Session, topicID int64) error {
+	return uc.watchService.WatchTopic(sess, &entity.TopicWatch{
+		UserID:  sess.UserID,
+		TopicID: topicID,
+	})
+}
+
+// UnwatchTopic unsubscribes the current User from a Topic.
+func (uc *WatchUC) UnwatchTopic(sess entity.Session, topicID int64) error {
+	return uc.watchService.UnwatchTopic(sess, &entity.TopicWatch{
+		UserID:  sess.UserID,
+		TopicID: topicID,
+	})
+}
+
+// WatchSection subscribes the current User to the new Posts in a Section.
+func (uc *WatchUC) WatchSection(sess entity.Session, sectionID int64) error {
+	return uc.watchService.WatchSection(sess, &entity.SectionWatch{
+		UserID:    sess.UserID,
+		SectionID: sectionID,
+	})
+}
+
+// UnwatchSection unsubscribes the current User from a Section.
+func (uc *WatchUC) UnwatchSection(sess entity.Session, sectionID int64) error {
+	return uc.watchService.UnwatchSection(sess, &entity.SectionWatch{
+		UserID:    sess.UserID,
+		SectionID: sectionID,
+	})
+}
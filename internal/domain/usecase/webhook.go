@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+)
+
+// WebhookUC is a Webhook usecase.
+type WebhookUC struct {
+	webhookService WebhookAdapter
+}
+
+// NewWebhookUC instantiates a Webhook usecase.
+func NewWebhookUC(webhookService WebhookAdapter) *WebhookUC {
+	return &WebhookUC{
+		webhookService: webhookService,
+	}
+}
+
+// Add registers a new Webhook.
+func (uc *WebhookUC) Add(sess entity.Session, e *entity.WebhookAdd) (*entity.Webhook, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	id, err := uc.webhookService.Add(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.webhookService.ByID(sess, id)
+}
+
+// Edit modifies an existing Webhook.
+func (uc *WebhookUC) Edit(sess entity.Session, e *entity.WebhookEdit) (*entity.Webhook, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	err := uc.webhookService.Edit(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.webhookService.ByID(sess, e.ID)
+}
+
+// Delete removes a Webhook along with its delivery log.
+func (uc *WebhookUC) Delete(sess entity.Session, id int64) error {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return domain.ErrForbidden
+	}
+
+	return uc.webhookService.Delete(sess, id)
+}
+
+// All returns every Webhook registered.
+func (uc *WebhookUC) All(sess entity.Session) ([]*entity.Webhook, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.webhookService.All(sess)
+}
+
+// Deliveries returns the delivery log of the Webhooks, the latest deliveries first.
+func (uc *WebhookUC) Deliveries(sess entity.Session, f *entity.WebhookDeliveryFilters,
+	p *entity.Pagination) ([]*entity.WebhookDelivery, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.webhookService.Deliveries(sess, f, p)
+}
+
+// Redeliver sends a logged delivery once more and returns the new one.
+func (uc *WebhookUC) Redeliver(sess entity.Session, deliveryID int64) (*entity.WebhookDelivery, error) {
+	if !sess.Level.AtLeast(entity.UserLevelAdmin) {
+		return nil, domain.ErrForbidden
+	}
+
+	return uc.webhookService.Redeliver(sess, deliveryID)
+}
@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func AuditEntriesToRest(e []*entity.AuditEntry) []*apimodel.AuditEntry {
+	if e == nil {
+		return nil
+	}
+
+	entries := make([]*apimodel.AuditEntry, len(e))
+
+	for i, entry := range e {
+		entries[i] = &apimodel.AuditEntry{
+			ID:         entry.ID,
+			ActorID:    entry.ActorID,
+			Actor:      UserToRest(entry.Actor),
+			Action:     apimodel.AuditAction(entry.Action),
+			TargetType: apimodel.AuditTargetType(entry.TargetType),
+			TargetID:   entry.TargetID,
+			Before:     entry.Before,
+			After:      entry.After,
+			SessionID:  entry.SessionID,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+
+	return entries
+}
+
+func AuditFiltersFromRest(a *apimodel.AuditFilters) *entity.AuditFilters {
+	if a == nil {
+		return nil
+	}
+
+	e := &entity.AuditFilters{
+		ActorIDs:  a.ActorIds,
+		TargetIDs: a.TargetIds,
+		SessionID: a.SessionID,
+		From:      a.From,
+		To:        a.To,
+	}
+
+	for _, action := range a.Actions {
+		e.Actions = append(e.Actions, entity.AuditAction(action))
+	}
+
+	for _, targetType := range a.TargetTypes {
+		e.TargetTypes = append(e.TargetTypes, entity.AuditTargetType(targetType))
+	}
+
+	return e
+}
+
+func AuditEntryAddToDB(e *entity.AuditEntryAdd, before, after *string) *dbmodel.AuditEntry {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.AuditEntry{
+		ActorID:    e.ActorID,
+		Action:     string(e.Action),
+		TargetType: string(e.TargetType),
+		TargetID:   e.TargetID,
+		Before:     before,
+		After:      after,
+		SessionID:  e.SessionID,
+	}
+}
+
+func AuditFiltersToDB(e *entity.AuditFilters) *dbmodel.AuditFilters {
+	if e == nil {
+		return nil
+	}
+
+	a := &dbmodel.AuditFilters{
+		ActorIDs:  e.ActorIDs,
+		TargetIDs: e.TargetIDs,
+		SessionID: e.SessionID,
+		From:      e.From,
+		To:        e.To,
+	}
+
+	for _, action := range e.Actions {
+		a.Actions = append(a.Actions, string(action))
+	}
+
+	for _, targetType := range e.TargetTypes {
+		a.TargetTypes = append(a.TargetTypes, string(targetType))
+	}
+
+	return a
+}
+
+func AuditEntriesFromDB(a []*dbmodel.AuditEntry) []*entity.AuditEntry {
+	e := make([]*entity.AuditEntry, len(a))
+
+	for i, entry := range a {
+		e[i] = &entity.AuditEntry{
+			ID:         entry.ID,
+			ActorID:    entry.ActorID,
+			Action:     entity.AuditAction(entry.Action),
+			TargetType: entity.AuditTargetType(entry.TargetType),
+			TargetID:   entry.TargetID,
+			Before:     entry.Before,
+			After:      entry.After,
+			SessionID:  entry.SessionID,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+
+	return e
+}
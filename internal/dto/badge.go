@@ -0,0 +1,157 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func BadgeAddToDB(e *entity.BadgeAdd) *dbmodel.Badge {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.Badge{
+		Name:        e.Name,
+		Title:       e.Title,
+		Description: e.Description,
+		Rule:        string(e.Rule),
+		Threshold:   e.Threshold,
+	}
+}
+
+func BadgeEditToDB(e *entity.BadgeEdit) (*dbmodel.BadgeUpdate, int64) {
+	if e == nil {
+		return nil, 0
+	}
+
+	return &dbmodel.BadgeUpdate{
+		Title:       e.Title,
+		Description: e.Description,
+		Threshold:   e.Threshold,
+	}, e.ID
+}
+
+func BadgeFromDB(b *dbmodel.Badge) *entity.Badge {
+	if b == nil {
+		return nil
+	}
+
+	return &entity.Badge{
+		ID:          b.ID,
+		Name:        b.Name,
+		Title:       b.Title,
+		Description: b.Description,
+		Rule:        entity.BadgeRule(b.Rule),
+		Threshold:   b.Threshold,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+}
+
+func BadgesFromDB(b []*dbmodel.Badge) []*entity.Badge {
+	if b == nil {
+		return nil
+	}
+
+	badges := make([]*entity.Badge, len(b))
+
+	for i, badge := range b {
+		badges[i] = BadgeFromDB(badge)
+	}
+
+	return badges
+}
+
+func UserBadgesFromDB(b []*dbmodel.UserBadge) []*entity.UserBadge {
+	if b == nil {
+		return nil
+	}
+
+	badges := make([]*entity.UserBadge, len(b))
+
+	for i, badge := range b {
+		badges[i] = &entity.UserBadge{
+			UserID:    badge.UserID,
+			BadgeID:   badge.BadgeID,
+			GrantedAt: badge.GrantedAt,
+		}
+	}
+
+	return badges
+}
+
+func BadgeAddFromRest(f *apimodel.AddBadgeInput) *entity.BadgeAdd {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.BadgeAdd{
+		Name:        f.Name,
+		Title:       f.Title,
+		Description: f.Description,
+		Rule:        entity.BadgeRule(f.Rule),
+		Threshold:   f.Threshold,
+	}
+}
+
+func BadgeEditFromRest(f *apimodel.EditBadgeInput) *entity.BadgeEdit {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.BadgeEdit{
+		ID:          f.ID,
+		Title:       f.Title,
+		Description: f.Description,
+		Threshold:   f.Threshold,
+	}
+}
+
+func BadgeToRest(e *entity.Badge) *apimodel.Badge {
+	if e == nil {
+		return nil
+	}
+
+	return &apimodel.Badge{
+		ID:          e.ID,
+		Name:        e.Name,
+		Title:       e.Title,
+		Description: e.Description,
+		Rule:        apimodel.BadgeRule(e.Rule),
+		Threshold:   e.Threshold,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+func BadgesToRest(e []*entity.Badge) []*apimodel.Badge {
+	if e == nil {
+		return nil
+	}
+
+	badges := make([]*apimodel.Badge, len(e))
+
+	for i, badge := range e {
+		badges[i] = BadgeToRest(badge)
+	}
+
+	return badges
+}
+
+func UserBadgesToRest(e []*entity.UserBadge) []*apimodel.UserBadge {
+	if e == nil {
+		return nil
+	}
+
+	badges := make([]*apimodel.UserBadge, len(e))
+
+	for i, badge := range e {
+		badges[i] = &apimodel.UserBadge{
+			Badge:     BadgeToRest(badge.Badge),
+			GrantedAt: badge.GrantedAt,
+		}
+	}
+
+	return badges
+}
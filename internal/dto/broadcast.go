@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func BroadcastMessageAddToDB(e *entity.BroadcastMessageAdd) *dbmodel.BroadcastMessage {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.BroadcastMessage{
+		Subscriber: e.Subscriber,
+		EventType:  string(e.EventType),
+		Payload:    e.Payload,
+		SessionID:  e.SessionID,
+	}
+}
+
+func BroadcastMessagesFromDB(a []*dbmodel.BroadcastMessage) []*entity.BroadcastMessage {
+	e := make([]*entity.BroadcastMessage, len(a))
+
+	for i, message := range a {
+		e[i] = &entity.BroadcastMessage{
+			Seq:        message.Seq,
+			Subscriber: message.Subscriber,
+			EventType:  entity.EventType(message.EventType),
+			Payload:    message.Payload,
+			SessionID:  message.SessionID,
+			CreatedAt:  message.CreatedAt,
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func FilterDecisionLogToDB(e *entity.FilterDecisionLog) []*dbmodel.FilterDecision {
+	if e == nil {
+		return nil
+	}
+
+	decisions := make([]*dbmodel.FilterDecision, len(e.Decisions))
+
+	for i, decision := range e.Decisions {
+		decisions[i] = &dbmodel.FilterDecision{
+			ContentKind: string(e.Kind),
+			UserID:      e.UserID,
+			Filter:      decision.Filter,
+			Verdict:     string(decision.Verdict),
+			Score:       decision.Score,
+			Reason:      decision.Reason,
+		}
+	}
+
+	return decisions
+}
+
+func SpamTokenCountsFromDB(t []*dbmodel.SpamTokenCount) []*entity.SpamTokenCount {
+	if t == nil {
+		return nil
+	}
+
+	counts := make([]*entity.SpamTokenCount, len(t))
+
+	for i, count := range t {
+		counts[i] = &entity.SpamTokenCount{
+			Token: count.Token,
+			Spam:  count.SpamCount,
+			Ham:   count.HamCount,
+		}
+	}
+
+	return counts
+}
+
+func SpamTotalsFromDB(t *dbmodel.SpamTotals) *entity.SpamTotals {
+	if t == nil {
+		return nil
+	}
+
+	return &entity.SpamTotals{
+		Spam: t.SpamCount,
+		Ham:  t.HamCount,
+	}
+}
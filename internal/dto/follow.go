@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func UserFollowToDB(e *entity.UserFollow) *dbmodel.UserFollow {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.UserFollow{
+		UserID:         e.UserID,
+		FollowedUserID: e.FollowedUserID,
+	}
+}
+
+func UserIgnoreToDB(e *entity.UserIgnore) *dbmodel.UserIgnore {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.UserIgnore{
+		UserID:        e.UserID,
+		IgnoredUserID: e.IgnoredUserID,
+	}
+}
+
+func UserFollowFiltersToDB(e *entity.UserFollowFilters) *dbmodel.UserFollowFilters {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.UserFollowFilters{
+		UserIDs:         e.UserIDs,
+		FollowedUserIDs: e.FollowedUserIDs,
+	}
+}
+
+func UserFollowsFromDB(f []*dbmodel.UserFollow) []*entity.UserFollow {
+	if f == nil {
+		return nil
+	}
+
+	follows := make([]*entity.UserFollow, len(f))
+
+	for i, follow := range f {
+		follows[i] = &entity.UserFollow{
+			UserID:         follow.UserID,
+			FollowedUserID: follow.FollowedUserID,
+		}
+	}
+
+	return follows
+}
+
+func FeedItemsToRest(e []*entity.FeedItem) []*apimodel.FeedItem {
+	if e == nil {
+		return nil
+	}
+
+	items := make([]*apimodel.FeedItem, len(e))
+
+	for i, item := range e {
+		items[i] = &apimodel.FeedItem{
+			Kind:      apimodel.FeedItemKind(item.Kind),
+			UserID:    item.UserID,
+			CreatedAt: item.CreatedAt,
+			Topic:     TopicToRest(item.Topic),
+			Post:      PostToRest(item.Post),
+		}
+	}
+
+	return items
+}
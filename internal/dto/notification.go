@@ -28,7 +28,14 @@ func NotificationToRest(e *entity.Notification) *apimodel.Notification {
 	return &apimodel.Notification{
 		ID:        e.ID,
 		UserID:    e.UserID,
+		Kind:      apimodel.NotificationKind(e.Kind),
+		ActorID:   e.ActorID,
+		PostID:    e.PostID,
+		TopicID:   e.TopicID,
+		SectionID: e.SectionID,
 		Text:      e.Text,
+		Count:     e.Count,
+		ReadAt:    e.ReadAt,
 		CreatedAt: e.CreatedAt,
 	}
 }
@@ -39,8 +46,15 @@ func NotificationAddToDB(e *entity.NotificationAdd) *dbmodel.Notification {
 	}
 
 	return &dbmodel.Notification{
-		UserID: e.UserID,
-		Text:   e.Text,
+		UserID:    e.UserID,
+		Kind:      string(e.Kind),
+		ActorID:   e.ActorID,
+		PostID:    e.PostID,
+		TopicID:   e.TopicID,
+		SectionID: e.SectionID,
+		Text:      e.Text,
+		GroupKey:  e.GroupKey,
+		GroupText: e.GroupText,
 	}
 }
 
@@ -49,12 +63,31 @@ func NotificationFromDB(n *dbmodel.Notification) *entity.Notification {
 		return nil
 	}
 
-	return &entity.Notification{
+	e := &entity.Notification{
 		ID:        n.ID,
+		Seq:       n.Seq,
 		UserID:    n.UserID,
+		Kind:      entity.NotificationKind(n.Kind),
+		ActorID:   n.ActorID,
 		Text:      n.Text,
+		Count:     n.Count,
+		ReadAt:    n.ReadAt,
 		CreatedAt: n.CreatedAt,
+		NotificationTarget: entity.NotificationTarget{
+			PostID:    n.PostID,
+			TopicID:   n.TopicID,
+			SectionID: n.SectionID,
+		},
 	}
+
+	// Grouped notifications are described by the group text
+	if n.Count > 1 && n.GroupText != nil {
+		if text, ok := entity.NotificationGroupText(e.Kind, n.Count, *n.GroupText); ok {
+			e.Text = text
+		}
+	}
+
+	return e
 }
 
 func NotificationsFromDB(n []*dbmodel.Notification) []*entity.Notification {
@@ -70,3 +103,113 @@ func NotificationsFromDB(n []*dbmodel.Notification) []*entity.Notification {
 
 	return e
 }
+
+func NotificationPreferencesToRest(e []*entity.NotificationPreference) []*apimodel.NotificationPreference {
+	if e == nil {
+		return nil
+	}
+
+	preferences := make([]*apimodel.NotificationPreference, len(e))
+
+	for i, preference := range e {
+		preferences[i] = NotificationPreferenceToRest(preference)
+	}
+
+	return preferences
+}
+
+func NotificationPreferenceToRest(e *entity.NotificationPreference) *apimodel.NotificationPreference {
+	if e == nil {
+		return nil
+	}
+
+	return &apimodel.NotificationPreference{
+		Kind:  apimodel.NotificationKind(e.Kind),
+		InApp: e.InApp,
+		Email: apimodel.NotificationEmailMode(e.Email),
+	}
+}
+
+func NotificationPreferenceSetFromRest(kind apimodel.NotificationKind, inApp *bool,
+	email *apimodel.NotificationEmailMode) *entity.NotificationPreferenceSet {
+	e := &entity.NotificationPreferenceSet{
+		Kind:  entity.NotificationKind(kind),
+		InApp: inApp,
+	}
+
+	if email != nil {
+		mode := entity.NotificationEmailMode(*email)
+		e.Email = &mode
+	}
+
+	return e
+}
+
+func NotificationPreferencesFromDB(n []*dbmodel.NotificationPreference) []*entity.NotificationPreference {
+	if n == nil {
+		return nil
+	}
+
+	e := make([]*entity.NotificationPreference, len(n))
+
+	for i, preference := range n {
+		e[i] = &entity.NotificationPreference{
+			Kind:  entity.NotificationKind(preference.Kind),
+			InApp: preference.InApp,
+			Email: entity.NotificationEmailMode(preference.Email),
+		}
+	}
+
+	return e
+}
+
+func NotificationEmailToDB(e *entity.NotificationAdd, mode entity.NotificationEmailMode) *dbmodel.NotificationEmail {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.NotificationEmail{
+		UserID: e.UserID,
+		Kind:   string(e.Kind),
+		Mode:   string(mode),
+		Text:   e.Text,
+	}
+}
+
+// NotificationDigestsFromDB groups the pending emails by their recipients, keeping the order of the rows.
+func NotificationDigestsFromDB(n []*dbmodel.NotificationEmailWithUser) []*entity.NotificationDigest {
+	if n == nil {
+		return nil
+	}
+
+	var (
+		digests []*entity.NotificationDigest
+		byUser  = make(map[int64]*entity.NotificationDigest)
+	)
+
+	for _, row := range n {
+		digest, ok := byUser[row.UserID]
+		if !ok {
+			digest = &entity.NotificationDigest{
+				Mode:     entity.NotificationEmailMode(row.Mode),
+				UserID:   row.UserID,
+				Nickname: row.Nickname,
+				Email:    row.UserEmail,
+			}
+
+			byUser[row.UserID] = digest
+			digests = append(digests, digest)
+		}
+
+		digest.Notifications = append(digest.Notifications, &entity.NotificationEmail{
+			ID:        row.ID,
+			UserID:    row.UserID,
+			Kind:      entity.NotificationKind(row.Kind),
+			Mode:      entity.NotificationEmailMode(row.Mode),
+			Text:      row.Text,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+
+	return digests
+}
@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func OutboxMessageToRest(e *entity.OutboxMessage) *apimodel.OutboxMessage {
+	if e == nil {
+		return nil
+	}
+
+	return &apimodel.OutboxMessage{
+		ID:            e.ID,
+		Subscriber:    e.Subscriber,
+		EventType:     string(e.EventType),
+		Payload:       e.Payload,
+		SessionID:     e.SessionID,
+		UserID:        e.UserID,
+		Status:        apimodel.OutboxStatus(e.Status),
+		Attempts:      e.Attempts,
+		LastError:     e.LastError,
+		NextAttemptAt: e.NextAttemptAt,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func OutboxMessagesToRest(e []*entity.OutboxMessage) []*apimodel.OutboxMessage {
+	if e == nil {
+		return nil
+	}
+
+	messages := make([]*apimodel.OutboxMessage, len(e))
+
+	for i, message := range e {
+		messages[i] = OutboxMessageToRest(message)
+	}
+
+	return messages
+}
+
+func OutboxFiltersFromRest(a *apimodel.OutboxFilters) *entity.OutboxFilters {
+	if a == nil {
+		return nil
+	}
+
+	e := &entity.OutboxFilters{
+		IDs:          a.Ids,
+		Subscribers:  a.Subscribers,
+		AttemptsFrom: a.AttemptsFrom,
+	}
+
+	for _, status := range a.Statuses {
+		e.Statuses = append(e.Statuses, entity.OutboxStatus(status))
+	}
+
+	for _, eventType := range a.EventTypes {
+		e.EventTypes = append(e.EventTypes, entity.EventType(eventType))
+	}
+
+	return e
+}
+
+func OutboxMessageAddToDB(e *entity.OutboxMessageAdd) *dbmodel.OutboxMessage {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.OutboxMessage{
+		Subscriber: e.Subscriber,
+		EventType:  string(e.EventType),
+		Payload:    e.Payload,
+		SessionID:  e.SessionID,
+		UserID:     e.UserID,
+		UserLevel:  string(e.UserLevel),
+	}
+}
+
+func OutboxMessageEditToDB(e *entity.OutboxMessageEdit) (*dbmodel.OutboxMessageUpdate, int64) {
+	if e == nil {
+		return nil, 0
+	}
+
+	update := &dbmodel.OutboxMessageUpdate{
+		Attempts:      e.Attempts,
+		LastError:     e.LastError,
+		NextAttemptAt: e.NextAttemptAt,
+	}
+
+	if e.Status != nil {
+		status := string(*e.Status)
+		update.Status = &status
+	}
+
+	return update, e.ID
+}
+
+func OutboxFiltersToDB(e *entity.OutboxFilters) *dbmodel.OutboxFilters {
+	if e == nil {
+		return nil
+	}
+
+	a := &dbmodel.OutboxFilters{
+		IDs:          e.IDs,
+		Subscribers:  e.Subscribers,
+		AttemptsFrom: e.AttemptsFrom,
+	}
+
+	for _, status := range e.Statuses {
+		a.Statuses = append(a.Statuses, string(status))
+	}
+
+	for _, eventType := range e.EventTypes {
+		a.EventTypes = append(a.EventTypes, string(eventType))
+	}
+
+	return a
+}
+
+func OutboxMessagesFromDB(a []*dbmodel.OutboxMessage) []*entity.OutboxMessage {
+	e := make([]*entity.OutboxMessage, len(a))
+
+	for i, message := range a {
+		e[i] = &entity.OutboxMessage{
+			ID:            message.ID,
+			Subscriber:    message.Subscriber,
+			EventType:     entity.EventType(message.EventType),
+			Payload:       message.Payload,
+			SessionID:     message.SessionID,
+			UserID:        message.UserID,
+			UserLevel:     entity.UserLevel(message.UserLevel),
+			Status:        entity.OutboxStatus(message.Status),
+			Attempts:      message.Attempts,
+			LastError:     message.LastError,
+			NextAttemptAt: message.NextAttemptAt,
+			CreatedAt:     message.CreatedAt,
+		}
+	}
+
+	return e
+}
@@ -28,12 +28,19 @@ func PostToRest(e *entity.Post) *apimodel.Post {
 	return &apimodel.Post{
 		ID:        e.ID,
 		Text:      e.Text,
+		Status:    apimodel.PostStatus(e.Status),
+		Wiki:      e.Wiki,
 		UserID:    e.UserID,
 		User:      UserToRest(e.User),
 		TopicID:   e.TopicID,
 		Topic:     TopicToRest(e.Topic),
 		CreatedAt: e.CreatedAt,
 		UpdatedAt: e.UpdatedAt,
+		DeletedAt: e.DeletedAt,
+
+		AuthorIgnored: e.AuthorIgnored,
+
+		Contributors: PostContributorsToRest(e.Contributors),
 	}
 }
 
@@ -56,6 +63,7 @@ func PostEditFromRest(p *apimodel.EditPostInput) *entity.PostEdit {
 	return &entity.PostEdit{
 		ID:      p.ID,
 		Text:    p.Text,
+		Wiki:    p.Wiki,
 		UserID:  p.UserID,
 		TopicID: p.TopicID,
 	}
@@ -67,9 +75,10 @@ func PostFiltersFromRest(p *apimodel.PostFilters) *entity.PostFilters {
 	}
 
 	return &entity.PostFilters{
-		IDs:      p.Ids,
-		UserIDs:  p.UserIds,
-		TopicIDs: p.TopicIds,
+		IDs:         p.Ids,
+		UserIDs:     p.UserIds,
+		TopicIDs:    p.TopicIds,
+		HideIgnored: p.HideIgnored,
 	}
 }
 
@@ -91,6 +100,7 @@ func PostAddToDB(e *entity.PostAdd) *dbmodel.Post {
 
 	return &dbmodel.Post{
 		Text:    e.Text,
+		Status:  string(e.Status),
 		TopicID: e.TopicID,
 		UserID:  e.UserID,
 	}
@@ -104,10 +114,13 @@ func PostFromDB(p *dbmodel.Post) *entity.Post {
 	return &entity.Post{
 		ID:        p.ID,
 		Text:      p.Text,
+		Status:    entity.PostStatus(p.Status),
+		Wiki:      p.Wiki,
 		UserID:    p.UserID,
 		TopicID:   p.TopicID,
 		CreatedAt: p.CreatedAt,
 		UpdatedAt: p.UpdatedAt,
+		DeletedAt: p.DeletedAt,
 	}
 }
 
@@ -118,6 +131,7 @@ func PostEditToDB(e *entity.PostEdit) (*dbmodel.PostUpdate, int64) {
 
 	return &dbmodel.PostUpdate{
 		Text:    e.Text,
+		Wiki:    e.Wiki,
 		UserID:  e.UserID,
 		TopicID: e.TopicID,
 	}, e.ID
@@ -129,9 +143,10 @@ func PostFiltersToDB(e *entity.PostFilters) *dbmodel.PostFilters {
 	}
 
 	return &dbmodel.PostFilters{
-		IDs:      e.IDs,
-		UserIDs:  e.UserIDs,
-		TopicIDs: e.TopicIDs,
+		IDs:            e.IDs,
+		UserIDs:        e.UserIDs,
+		TopicIDs:       e.TopicIDs,
+		ExcludeUserIDs: e.ExcludeUserIDs,
 	}
 }
 
@@ -148,3 +163,50 @@ func PostsFromDB(p []*dbmodel.Post) []*entity.Post {
 
 	return e
 }
+
+func PostAuthorCountsFromDB(p []*dbmodel.PostAuthorCount) []*entity.PostAuthorCount {
+	e := make([]*entity.PostAuthorCount, len(p))
+
+	for i, author := range p {
+		e[i] = &entity.PostAuthorCount{
+			UserID: author.UserID,
+			Count:  author.Count,
+		}
+	}
+
+	return e
+}
+
+func PostContributorsToRest(e []*entity.PostContributor) []*apimodel.PostContributor {
+	if e == nil {
+		return nil
+	}
+
+	contributors := make([]*apimodel.PostContributor, len(e))
+
+	for i, contributor := range e {
+		contributors[i] = &apimodel.PostContributor{
+			UserID:       contributor.UserID,
+			User:         UserToRest(contributor.User),
+			CountEdits:   contributor.CountEdits,
+			LastEditedAt: contributor.LastEditedAt,
+		}
+	}
+
+	return contributors
+}
+
+func PostContributorsFromDB(p []*dbmodel.PostContributor) []*entity.PostContributor {
+	e := make([]*entity.PostContributor, len(p))
+
+	for i, contributor := range p {
+		e[i] = &entity.PostContributor{
+			PostID:       contributor.PostID,
+			UserID:       contributor.UserID,
+			CountEdits:   contributor.CountEdits,
+			LastEditedAt: contributor.LastEditedAt,
+		}
+	}
+
+	return e
+}
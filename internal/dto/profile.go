@@ -0,0 +1,253 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+
+	"github.com/lib/pq"
+)
+
+func ProfileFieldAddToDB(e *entity.ProfileFieldAdd) *dbmodel.ProfileField {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.ProfileField{
+		Name:       e.Name,
+		Title:      e.Title,
+		Type:       string(e.Type),
+		Required:   e.Required,
+		Visibility: string(e.Visibility),
+		Pattern:    e.Pattern,
+		MaxLength:  e.MaxLength,
+		Options:    e.Options,
+		Position:   e.Position,
+	}
+}
+
+func ProfileFieldEditToDB(e *entity.ProfileFieldEdit) (*dbmodel.ProfileFieldUpdate, int64) {
+	if e == nil {
+		return nil, 0
+	}
+
+	fieldUpdate := &dbmodel.ProfileFieldUpdate{
+		Title:      e.Title,
+		Required:   e.Required,
+		Visibility: (*string)(e.Visibility),
+		Position:   e.Position,
+	}
+
+	if e.Pattern != nil {
+		var pattern *string
+
+		if *e.Pattern != "" {
+			pattern = e.Pattern
+		}
+
+		fieldUpdate.Pattern = &pattern
+	}
+
+	if e.MaxLength != nil {
+		var maxLength *int64
+
+		if *e.MaxLength != 0 {
+			maxLength = e.MaxLength
+		}
+
+		fieldUpdate.MaxLength = &maxLength
+	}
+
+	if e.Options != nil {
+		options := pq.StringArray(e.Options)
+		fieldUpdate.Options = &options
+	}
+
+	return fieldUpdate, e.ID
+}
+
+func ProfileFieldFromDB(f *dbmodel.ProfileField) *entity.ProfileField {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.ProfileField{
+		ID:         f.ID,
+		Name:       f.Name,
+		Title:      f.Title,
+		Type:       entity.ProfileFieldType(f.Type),
+		Required:   f.Required,
+		Visibility: entity.ProfileVisibility(f.Visibility),
+		Pattern:    f.Pattern,
+		MaxLength:  f.MaxLength,
+		Options:    f.Options,
+		Position:   f.Position,
+		CreatedAt:  f.CreatedAt,
+		UpdatedAt:  f.UpdatedAt,
+	}
+}
+
+func ProfileFieldsFromDB(f []*dbmodel.ProfileField) []*entity.ProfileField {
+	if f == nil {
+		return nil
+	}
+
+	fields := make([]*entity.ProfileField, len(f))
+
+	for i, field := range f {
+		fields[i] = ProfileFieldFromDB(field)
+	}
+
+	return fields
+}
+
+func ProfileFieldValuesToDB(e []*entity.ProfileFieldValue) []*dbmodel.ProfileFieldValue {
+	if e == nil {
+		return nil
+	}
+
+	values := make([]*dbmodel.ProfileFieldValue, len(e))
+
+	for i, value := range e {
+		values[i] = &dbmodel.ProfileFieldValue{
+			UserID:  value.UserID,
+			FieldID: value.FieldID,
+			Value:   value.Value,
+		}
+	}
+
+	return values
+}
+
+func ProfileFieldValuesFromDB(v []*dbmodel.ProfileFieldValue) []*entity.ProfileFieldValue {
+	if v == nil {
+		return nil
+	}
+
+	values := make([]*entity.ProfileFieldValue, len(v))
+
+	for i, value := range v {
+		values[i] = &entity.ProfileFieldValue{
+			UserID:  value.UserID,
+			FieldID: value.FieldID,
+			Value:   value.Value,
+		}
+	}
+
+	return values
+}
+
+func ProfileFieldAddFromRest(f *apimodel.AddProfileFieldInput) *entity.ProfileFieldAdd {
+	if f == nil {
+		return nil
+	}
+
+	e := &entity.ProfileFieldAdd{
+		Name:       f.Name,
+		Title:      f.Title,
+		Type:       entity.ProfileFieldType(f.Type),
+		Required:   f.Required,
+		Visibility: entity.ProfileVisibility(f.Visibility),
+		Pattern:    f.Pattern,
+		MaxLength:  f.MaxLength,
+		Options:    f.Options,
+	}
+
+	if f.Position != nil {
+		e.Position = *f.Position
+	}
+
+	return e
+}
+
+func ProfileFieldEditFromRest(f *apimodel.EditProfileFieldInput) *entity.ProfileFieldEdit {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.ProfileFieldEdit{
+		ID:         f.ID,
+		Title:      f.Title,
+		Required:   f.Required,
+		Visibility: (*entity.ProfileVisibility)(f.Visibility),
+		Pattern:    f.Pattern,
+		MaxLength:  f.MaxLength,
+		Options:    f.Options,
+		Position:   f.Position,
+	}
+}
+
+func ProfileFieldValueSetsFromRest(f []*apimodel.ProfileFieldValueInput) []*entity.ProfileFieldValueSet {
+	if f == nil {
+		return nil
+	}
+
+	values := make([]*entity.ProfileFieldValueSet, len(f))
+
+	for i, value := range f {
+		values[i] = &entity.ProfileFieldValueSet{
+			FieldID: value.FieldID,
+			Value:   value.Value,
+		}
+	}
+
+	return values
+}
+
+func ProfileFieldToRest(e *entity.ProfileField) *apimodel.ProfileField {
+	if e == nil {
+		return nil
+	}
+
+	return &apimodel.ProfileField{
+		ID:         e.ID,
+		Name:       e.Name,
+		Title:      e.Title,
+		Type:       apimodel.ProfileFieldType(e.Type),
+		Required:   e.Required,
+		Visibility: apimodel.ProfileVisibility(e.Visibility),
+		Pattern:    e.Pattern,
+		MaxLength:  e.MaxLength,
+		Options:    e.Options,
+		Position:   e.Position,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
+func ProfileFieldsToRest(e []*entity.ProfileField) []*apimodel.ProfileField {
+	if e == nil {
+		return nil
+	}
+
+	fields := make([]*apimodel.ProfileField, len(e))
+
+	for i, field := range e {
+		fields[i] = ProfileFieldToRest(field)
+	}
+
+	return fields
+}
+
+func ProfileFieldValuesToRest(e []*entity.ProfileFieldValue) []*apimodel.ProfileFieldValue {
+	if e == nil {
+		return nil
+	}
+
+	values := make([]*apimodel.ProfileFieldValue, len(e))
+
+	for i, value := range e {
+		values[i] = &apimodel.ProfileFieldValue{
+			FieldID: value.FieldID,
+			Value:   value.Value,
+		}
+
+		if value.Field != nil {
+			values[i].Name = value.Field.Name
+			values[i].Title = value.Field.Title
+			values[i].Type = apimodel.ProfileFieldType(value.Field.Type)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,159 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+
+	"github.com/lib/pq"
+)
+
+func RankAddToDB(e *entity.RankAdd) *dbmodel.Rank {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.Rank{
+		MinPosts: e.MinPosts,
+		Title:    e.Title,
+		Perks:    rankPerksToDB(e.Perks),
+	}
+}
+
+func RankEditToDB(e *entity.RankEdit) (*dbmodel.RankUpdate, int64) {
+	if e == nil {
+		return nil, 0
+	}
+
+	rankUpdate := &dbmodel.RankUpdate{
+		MinPosts: e.MinPosts,
+		Title:    e.Title,
+	}
+
+	if e.Perks != nil {
+		perks := rankPerksToDB(e.Perks)
+		rankUpdate.Perks = &perks
+	}
+
+	return rankUpdate, e.ID
+}
+
+func RankFromDB(r *dbmodel.Rank) *entity.Rank {
+	if r == nil {
+		return nil
+	}
+
+	perks := make([]entity.RankPerk, len(r.Perks))
+
+	for i, perk := range r.Perks {
+		perks[i] = entity.RankPerk(perk)
+	}
+
+	return &entity.Rank{
+		ID:        r.ID,
+		MinPosts:  r.MinPosts,
+		Title:     r.Title,
+		Perks:     perks,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func RanksFromDB(r []*dbmodel.Rank) []*entity.Rank {
+	if r == nil {
+		return nil
+	}
+
+	ranks := make([]*entity.Rank, len(r))
+
+	for i, rank := range r {
+		ranks[i] = RankFromDB(rank)
+	}
+
+	return ranks
+}
+
+func RankAddFromRest(f *apimodel.AddRankInput) *entity.RankAdd {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.RankAdd{
+		MinPosts: f.MinPosts,
+		Title:    f.Title,
+		Perks:    rankPerksFromRest(f.Perks),
+	}
+}
+
+func RankEditFromRest(f *apimodel.EditRankInput) *entity.RankEdit {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.RankEdit{
+		ID:       f.ID,
+		MinPosts: f.MinPosts,
+		Title:    f.Title,
+		Perks:    rankPerksFromRest(f.Perks),
+	}
+}
+
+func RankToRest(e *entity.Rank) *apimodel.Rank {
+	if e == nil {
+		return nil
+	}
+
+	perks := make([]apimodel.RankPerk, len(e.Perks))
+
+	for i, perk := range e.Perks {
+		perks[i] = apimodel.RankPerk(perk)
+	}
+
+	return &apimodel.Rank{
+		ID:        e.ID,
+		Number:    e.Number,
+		MinPosts:  e.MinPosts,
+		Title:     e.Title,
+		Perks:     perks,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func RanksToRest(e []*entity.Rank) []*apimodel.Rank {
+	if e == nil {
+		return nil
+	}
+
+	ranks := make([]*apimodel.Rank, len(e))
+
+	for i, rank := range e {
+		ranks[i] = RankToRest(rank)
+	}
+
+	return ranks
+}
+
+func rankPerksToDB(e []entity.RankPerk) pq.StringArray {
+	perks := make(pq.StringArray, len(e))
+
+	for i, perk := range e {
+		perks[i] = string(perk)
+	}
+
+	return perks
+}
+
+func rankPerksFromRest(f []apimodel.RankPerk) []entity.RankPerk {
+	if f == nil {
+		return nil
+	}
+
+	perks := make([]entity.RankPerk, len(f))
+
+	for i, perk := range f {
+		perks[i] = entity.RankPerk(perk)
+	}
+
+	return perks
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func RateLimitBucketFromDB(b *dbmodel.RateLimitBucket) *entity.RateLimitBucket {
+	if b == nil {
+		return nil
+	}
+
+	return &entity.RateLimitBucket{
+		Tokens:    b.Tokens,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
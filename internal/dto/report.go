@@ -0,0 +1,194 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func ReportsToRest(e []*entity.Report) []*apimodel.Report {
+	if e == nil {
+		return nil
+	}
+
+	reports := make([]*apimodel.Report, len(e))
+
+	for i, report := range e {
+		reports[i] = ReportToRest(report)
+	}
+
+	return reports
+}
+
+func ReportToRest(e *entity.Report) *apimodel.Report {
+	if e == nil {
+		return nil
+	}
+
+	report := &apimodel.Report{
+		ID:             e.ID,
+		ReporterID:     e.ReporterID,
+		TargetType:     apimodel.ReportTargetType(e.TargetType),
+		TargetID:       e.TargetID,
+		TargetUserID:   e.TargetUserID,
+		Reason:         apimodel.ReportReason(e.Reason),
+		Text:           e.Text,
+		Status:         apimodel.ReportStatus(e.Status),
+		AssigneeID:     e.AssigneeID,
+		ResolutionNote: e.ResolutionNote,
+		ResolvedByID:   e.ResolvedByID,
+		ResolvedAt:     e.ResolvedAt,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+
+	if e.Action != nil {
+		action := apimodel.ReportAction(*e.Action)
+		report.Action = &action
+	}
+
+	return report
+}
+
+func ReportResolveFromRest(r *apimodel.ResolveReportInput) *entity.ReportResolve {
+	if r == nil {
+		return nil
+	}
+
+	e := &entity.ReportResolve{
+		ID:             r.ID,
+		Status:         entity.ReportStatus(r.Status),
+		ResolutionNote: r.Note,
+	}
+
+	if r.Action != nil {
+		action := entity.ReportAction(*r.Action)
+		e.Action = &action
+	}
+
+	return e
+}
+
+func ReportFiltersFromRest(r *apimodel.ReportFilters) *entity.ReportFilters {
+	if r == nil {
+		return nil
+	}
+
+	e := &entity.ReportFilters{
+		IDs:           r.Ids,
+		TargetUserIDs: r.TargetUserIds,
+		AssigneeIDs:   r.AssigneeIds,
+		ReporterIDs:   r.ReporterIds,
+	}
+
+	for _, status := range r.Statuses {
+		e.Statuses = append(e.Statuses, entity.ReportStatus(status))
+	}
+
+	for _, targetType := range r.TargetTypes {
+		e.TargetTypes = append(e.TargetTypes, entity.ReportTargetType(targetType))
+	}
+
+	return e
+}
+
+func ReportAddToDB(e *entity.ReportAdd) *dbmodel.Report {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.Report{
+		ReporterID:   e.ReporterID,
+		TargetType:   string(e.TargetType),
+		TargetID:     e.TargetID,
+		TargetUserID: e.TargetUserID,
+		Reason:       string(e.Reason),
+		Text:         e.Text,
+	}
+}
+
+func ReportResolveToDB(e *entity.ReportResolve) (*dbmodel.ReportResolve, int64) {
+	if e == nil {
+		return nil, 0
+	}
+
+	r := &dbmodel.ReportResolve{
+		Status:         string(e.Status),
+		ResolutionNote: e.ResolutionNote,
+		ResolvedByID:   e.ResolvedByID,
+	}
+
+	if e.Action != nil {
+		action := string(*e.Action)
+		r.Action = &action
+	}
+
+	return r, e.ID
+}
+
+func ReportFiltersToDB(e *entity.ReportFilters) *dbmodel.ReportFilters {
+	if e == nil {
+		return nil
+	}
+
+	r := &dbmodel.ReportFilters{
+		IDs:           e.IDs,
+		TargetUserIDs: e.TargetUserIDs,
+		AssigneeIDs:   e.AssigneeIDs,
+		ReporterIDs:   e.ReporterIDs,
+	}
+
+	for _, status := range e.Statuses {
+		r.Statuses = append(r.Statuses, string(status))
+	}
+
+	for _, targetType := range e.TargetTypes {
+		r.TargetTypes = append(r.TargetTypes, string(targetType))
+	}
+
+	return r
+}
+
+func ReportsFromDB(r []*dbmodel.Report) []*entity.Report {
+	if r == nil {
+		return nil
+	}
+
+	reports := make([]*entity.Report, len(r))
+
+	for i, report := range r {
+		reports[i] = ReportFromDB(report)
+	}
+
+	return reports
+}
+
+func ReportFromDB(r *dbmodel.Report) *entity.Report {
+	if r == nil {
+		return nil
+	}
+
+	e := &entity.Report{
+		ID:             r.ID,
+		ReporterID:     r.ReporterID,
+		TargetType:     entity.ReportTargetType(r.TargetType),
+		TargetID:       r.TargetID,
+		TargetUserID:   r.TargetUserID,
+		Reason:         entity.ReportReason(r.Reason),
+		Text:           r.Text,
+		Status:         entity.ReportStatus(r.Status),
+		AssigneeID:     r.AssigneeID,
+		ResolutionNote: r.ResolutionNote,
+		ResolvedByID:   r.ResolvedByID,
+		ResolvedAt:     r.ResolvedAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+
+	if r.Action != nil {
+		action := entity.ReportAction(*r.Action)
+		e.Action = &action
+	}
+
+	return e
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func SearchHitsToRest(e []*entity.SearchHit) []*apimodel.SearchHit {
+	if e == nil {
+		return nil
+	}
+
+	hits := make([]*apimodel.SearchHit, len(e))
+
+	for i, hit := range e {
+		hits[i] = SearchHitToRest(hit)
+	}
+
+	return hits
+}
+
+func SearchHitToRest(e *entity.SearchHit) *apimodel.SearchHit {
+	if e == nil {
+		return nil
+	}
+
+	return &apimodel.SearchHit{
+		Kind:      apimodel.SearchHitKind(e.Kind),
+		ID:        e.ID,
+		TopicID:   e.TopicID,
+		SectionID: e.SectionID,
+		UserID:    e.UserID,
+		Rank:      e.Rank,
+		Snippet:   e.Snippet,
+		CreatedAt: e.CreatedAt,
+		Post:      PostToRest(e.Post),
+		Topic:     TopicToRest(e.Topic),
+	}
+}
+
+func SearchHitsFromDB(h []*dbmodel.SearchHit) []*entity.SearchHit {
+	e := make([]*entity.SearchHit, len(h))
+
+	for i, hit := range h {
+		e[i] = &entity.SearchHit{
+			Kind:      entity.SearchHitKind(hit.Kind),
+			ID:        hit.ID,
+			TopicID:   hit.TopicID,
+			SectionID: hit.SectionID,
+			UserID:    hit.UserID,
+			Rank:      hit.Rank,
+			Snippet:   hit.Snippet,
+			CreatedAt: hit.CreatedAt,
+		}
+	}
+
+	return e
+}
+
+func SearchDocumentsFromDB(d []*dbmodel.SearchDocument) []*entity.SearchDocument {
+	e := make([]*entity.SearchDocument, len(d))
+
+	for i, document := range d {
+		e[i] = &entity.SearchDocument{
+			Kind:      entity.SearchHitKind(document.Kind),
+			ID:        document.ID,
+			TopicID:   document.TopicID,
+			SectionID: document.SectionID,
+			UserID:    document.UserID,
+			Text:      document.Text,
+			CreatedAt: document.CreatedAt,
+		}
+	}
+
+	return e
+}
@@ -29,6 +29,7 @@ func SectionToRest(e *entity.Section) *apimodel.Section {
 		ID:          e.ID,
 		Name:        e.Name,
 		Description: e.Description,
+		Mode:        apimodel.SectionMode(e.Mode),
 		CountTopics: e.CountTopics,
 		Topics:      TopicsToRest(e.Topics),
 		CreatedAt:   e.CreatedAt,
@@ -44,6 +45,7 @@ func SectionAddFromRest(s *apimodel.AddSectionInput) *entity.SectionAdd {
 	return &entity.SectionAdd{
 		Name:        s.Name,
 		Description: s.Description,
+		Mode:        entity.SectionMode(s.Mode),
 	}
 }
 
@@ -52,11 +54,18 @@ func SectionEditFromRest(s *apimodel.EditSectionInput) *entity.SectionEdit {
 		return nil
 	}
 
-	return &entity.SectionEdit{
+	sectionEdit := &entity.SectionEdit{
 		ID:          s.ID,
 		Name:        s.Name,
 		Description: s.Description,
 	}
+
+	if s.Mode != nil {
+		mode := entity.SectionMode(*s.Mode)
+		sectionEdit.Mode = &mode
+	}
+
+	return sectionEdit
 }
 
 func SectionFiltersFromRest(s *apimodel.SectionFilters) *entity.SectionFilters {
@@ -88,6 +97,7 @@ func SectionAddToDB(e *entity.SectionAdd) *dbmodel.Section {
 	return &dbmodel.Section{
 		Name:        e.Name,
 		Description: e.Description,
+		Mode:        string(e.Mode),
 	}
 }
 
@@ -100,9 +110,11 @@ func SectionFromDB(s *dbmodel.Section) *entity.Section {
 		ID:          s.ID,
 		Name:        s.Name,
 		Description: s.Description,
+		Mode:        entity.SectionMode(s.Mode),
 		CountTopics: s.CountTopics,
 		CreatedAt:   s.CreatedAt,
 		UpdatedAt:   s.UpdatedAt,
+		DeletedAt:   s.DeletedAt,
 	}
 }
 
@@ -125,6 +137,11 @@ func SectionEditToDB(e *entity.SectionEdit) (*dbmodel.SectionUpdate, int64) {
 		sectionUpdate.Description = &ptr
 	}
 
+	if e.Mode != nil {
+		mode := string(*e.Mode)
+		sectionUpdate.Mode = &mode
+	}
+
 	return sectionUpdate, e.ID
 }
 
@@ -36,6 +36,15 @@ func TopicToRest(e *entity.Topic) *apimodel.Topic {
 		Posts:      PostsToRest(e.Posts),
 		CreatedAt:  e.CreatedAt,
 		UpdatedAt:  e.UpdatedAt,
+		DeletedAt:  e.DeletedAt,
+
+		RedirectTopicID: e.RedirectTopicID,
+
+		AcceptedPostID: e.AcceptedPostID,
+		AcceptedAnswer: PostToRest(e.AcceptedAnswer),
+
+		UnreadCount:     e.UnreadCount,
+		FirstUnreadPost: PostToRest(e.FirstUnreadPost),
 	}
 }
 
@@ -68,11 +77,18 @@ func TopicFiltersFromRest(t *apimodel.TopicFilters) *entity.TopicFilters {
 		return nil
 	}
 
-	return &entity.TopicFilters{
+	e := &entity.TopicFilters{
 		IDs:        t.Ids,
 		UserIDs:    t.UserIds,
 		SectionIDs: t.SectionIds,
+		Answered:   t.Answered,
 	}
+
+	if t.UnreadOnly != nil {
+		e.UnreadOnly = *t.UnreadOnly
+	}
+
+	return e
 }
 
 func TopicSortFromRest(t *apimodel.TopicSort) *entity.TopicSort {
@@ -86,6 +102,17 @@ func TopicSortFromRest(t *apimodel.TopicSort) *entity.TopicSort {
 	}
 }
 
+func TrashPurgeResultToRest(e *entity.TrashPurgeResult) *apimodel.TrashPurgeResult {
+	if e == nil {
+		return nil
+	}
+
+	return &apimodel.TrashPurgeResult{
+		Topics: e.Topics,
+		Posts:  e.Posts,
+	}
+}
+
 func TopicAddToDB(e *entity.TopicAdd) *dbmodel.Topic {
 	if e == nil {
 		return nil
@@ -111,6 +138,10 @@ func TopicFromDB(t *dbmodel.Topic) *entity.Topic {
 		CountPosts: t.CountPosts,
 		CreatedAt:  t.CreatedAt,
 		UpdatedAt:  t.UpdatedAt,
+		DeletedAt:  t.DeletedAt,
+
+		RedirectTopicID: t.RedirectTopicID,
+		AcceptedPostID:  t.AcceptedPostID,
 	}
 }
 
@@ -142,6 +173,34 @@ func TopicDeleteToDB(e *entity.TopicDelete) *dbmodel.TopicDelete {
 	return (*dbmodel.TopicDelete)(TopicFiltersToDB((*entity.TopicFilters)(e)))
 }
 
+func TopicReadsFromDB(t []*dbmodel.TopicRead) []*entity.TopicRead {
+	e := make([]*entity.TopicRead, len(t))
+
+	for i, read := range t {
+		e[i] = &entity.TopicRead{
+			TopicID:           read.TopicID,
+			UnreadCount:       read.UnreadCount,
+			FirstUnreadPostID: read.FirstUnreadPostID,
+		}
+	}
+
+	return e
+}
+
+func TopicAnswerWithdrawalsFromDB(t []*dbmodel.TopicAnswerWithdrawal) []*entity.TopicAnswerWithdrawal {
+	e := make([]*entity.TopicAnswerWithdrawal, len(t))
+
+	for i, withdrawal := range t {
+		e[i] = &entity.TopicAnswerWithdrawal{
+			TopicID:      withdrawal.TopicID,
+			TopicUserID:  withdrawal.TopicUserID,
+			AnswerUserID: withdrawal.AnswerUserID,
+		}
+	}
+
+	return e
+}
+
 func TopicsFromDB(t []*dbmodel.Topic) []*entity.Topic {
 	e := make([]*entity.Topic, len(t))
 
@@ -151,3 +210,33 @@ func TopicsFromDB(t []*dbmodel.Topic) []*entity.Topic {
 
 	return e
 }
+
+func SimilarTopicsFromDB(t []*dbmodel.SimilarTopic) []*entity.SimilarTopic {
+	e := make([]*entity.SimilarTopic, len(t))
+
+	for i, topic := range t {
+		e[i] = &entity.SimilarTopic{
+			TopicID:    topic.TopicID,
+			Similarity: topic.Similarity,
+		}
+	}
+
+	return e
+}
+
+func SimilarTopicsToRest(e []*entity.SimilarTopic) []*apimodel.SimilarTopic {
+	if e == nil {
+		return nil
+	}
+
+	topics := make([]*apimodel.SimilarTopic, len(e))
+
+	for i, topic := range e {
+		topics[i] = &apimodel.SimilarTopic{
+			Topic:      TopicToRest(topic.Topic),
+			Similarity: topic.Similarity,
+		}
+	}
+
+	return topics
+}
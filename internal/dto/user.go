@@ -24,11 +24,17 @@ func UserInfoToDB(e *entity.UserInfo, userID int64) *dbmodel.UserInfo {
 	}
 
 	return &dbmodel.UserInfo{
-		UserID:    userID,
-		Phone:     e.Phone,
-		Email:     e.Email,
-		FirstName: e.FirstName,
-		LastName:  e.LastName,
+		UserID:              userID,
+		Phone:               e.Phone,
+		Email:               e.Email,
+		FirstName:           e.FirstName,
+		LastName:            e.LastName,
+		Bio:                 e.Bio,
+		Signature:           e.Signature,
+		PhoneVisibility:     (*string)(e.PhoneVisibility),
+		EmailVisibility:     (*string)(e.EmailVisibility),
+		FirstNameVisibility: (*string)(e.FirstNameVisibility),
+		LastNameVisibility:  (*string)(e.LastNameVisibility),
 	}
 }
 
@@ -38,12 +44,15 @@ func UserFromDB(user *dbmodel.User) *entity.User {
 	}
 
 	e := &entity.User{
-		ID:        user.ID,
-		Nickname:  user.Nickname,
-		ShowInfo:  user.ShowInfo,
-		Rank:      user.Rank,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:          user.ID,
+		Nickname:    user.Nickname,
+		ShowInfo:    user.ShowInfo,
+		Rank:        user.Rank,
+		Reputation:  user.Reputation,
+		CountTopics: user.CountTopics,
+		CountPosts:  user.CountPosts,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
 	}
 
 	if user.Level == nil {
@@ -67,10 +76,16 @@ func UserInfoFromDB(u *dbmodel.UserInfo) *entity.UserInfo {
 	}
 
 	return &entity.UserInfo{
-		Phone:     u.Phone,
-		Email:     u.Email,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
+		Phone:               u.Phone,
+		Email:               u.Email,
+		FirstName:           u.FirstName,
+		LastName:            u.LastName,
+		Bio:                 u.Bio,
+		Signature:           u.Signature,
+		PhoneVisibility:     (*entity.ProfileVisibility)(u.PhoneVisibility),
+		EmailVisibility:     (*entity.ProfileVisibility)(u.EmailVisibility),
+		FirstNameVisibility: (*entity.ProfileVisibility)(u.FirstNameVisibility),
+		LastNameVisibility:  (*entity.ProfileVisibility)(u.LastNameVisibility),
 	}
 }
 
@@ -99,11 +114,18 @@ func UserAddFromRest(u *apimodel.AddUserInput) *entity.UserAdd {
 		Password: u.Password,
 		ShowInfo: u.ShowInfo,
 		UserInfo: &entity.UserInfo{
-			Phone:     u.Phone,
-			Email:     u.Email,
-			FirstName: u.FirstName,
-			LastName:  u.LastName,
+			Phone:               u.Phone,
+			Email:               u.Email,
+			FirstName:           u.FirstName,
+			LastName:            u.LastName,
+			Bio:                 u.Bio,
+			Signature:           u.Signature,
+			PhoneVisibility:     (*entity.ProfileVisibility)(u.PhoneVisibility),
+			EmailVisibility:     (*entity.ProfileVisibility)(u.EmailVisibility),
+			FirstNameVisibility: (*entity.ProfileVisibility)(u.FirstNameVisibility),
+			LastNameVisibility:  (*entity.ProfileVisibility)(u.LastNameVisibility),
 		},
+		ProfileFields: ProfileFieldValueSetsFromRest(u.ProfileFields),
 	}
 }
 
@@ -122,11 +144,18 @@ func UserEditFromRest(u *apimodel.EditUserInput, userID int64) *entity.UserEdit
 		Level:       (*entity.UserLevel)(u.Level),
 		Restriction: (*entity.UserRestriction)(u.Restriction),
 		UserInfo: &entity.UserInfo{
-			Phone:     u.Phone,
-			Email:     u.Email,
-			FirstName: u.FirstName,
-			LastName:  u.LastName,
+			Phone:               u.Phone,
+			Email:               u.Email,
+			FirstName:           u.FirstName,
+			LastName:            u.LastName,
+			Bio:                 u.Bio,
+			Signature:           u.Signature,
+			PhoneVisibility:     (*entity.ProfileVisibility)(u.PhoneVisibility),
+			EmailVisibility:     (*entity.ProfileVisibility)(u.EmailVisibility),
+			FirstNameVisibility: (*entity.ProfileVisibility)(u.FirstNameVisibility),
+			LastNameVisibility:  (*entity.ProfileVisibility)(u.LastNameVisibility),
 		},
+		ProfileFields: ProfileFieldValueSetsFromRest(u.ProfileFields),
 	}
 
 	if u.ID == nil {
@@ -144,19 +173,25 @@ func UserToRest(e *entity.User) *apimodel.User {
 	}
 
 	return &apimodel.User{
-		ID:          e.ID,
-		Nickname:    e.Nickname,
-		ShowInfo:    e.ShowInfo,
-		Rank:        e.Rank,
-		Level:       apimodel.UserLevel(e.Level),
-		Restriction: apimodel.UserRestriction(e.Restriction),
-		UserInfo:    UserInfoToRest(e.UserInfo),
-		CountTopics: e.CountTopics,
-		CountPosts:  e.CountPosts,
-		Topics:      TopicsToRest(e.Topics),
-		Posts:       PostsToRest(e.Posts),
-		CreatedAt:   e.CreatedAt,
-		UpdatedAt:   e.UpdatedAt,
+		ID:            e.ID,
+		Nickname:      e.Nickname,
+		ShowInfo:      e.ShowInfo,
+		Rank:          e.Rank,
+		RankStep:      RankToRest(e.RankStep),
+		Reputation:    e.Reputation,
+		Badges:        UserBadgesToRest(e.Badges),
+		Level:         apimodel.UserLevel(e.Level),
+		Restriction:   apimodel.UserRestriction(e.Restriction),
+		UserInfo:      UserInfoToRest(e.UserInfo),
+		ProfileFields: ProfileFieldValuesToRest(e.ProfileFields),
+		CountTopics:   e.CountTopics,
+		CountPosts:    e.CountPosts,
+		Topics:        TopicsToRest(e.Topics),
+		Posts:         PostsToRest(e.Posts),
+		Followers:     UsersToRest(e.Followers),
+		Following:     UsersToRest(e.Following),
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
 	}
 }
 
@@ -165,12 +200,30 @@ func UserInfoToRest(e *entity.UserInfo) *apimodel.UserInfo {
 		return nil
 	}
 
-	return &apimodel.UserInfo{
-		Phone:     e.Phone,
-		Email:     e.Email,
-		FirstName: e.FirstName,
-		LastName:  e.LastName,
+	u := &apimodel.UserInfo{
+		Phone:               e.Phone,
+		Email:               e.Email,
+		FirstName:           e.FirstName,
+		LastName:            e.LastName,
+		Bio:                 e.Bio,
+		Signature:           e.Signature,
+		PhoneVisibility:     (*apimodel.ProfileVisibility)(e.PhoneVisibility),
+		EmailVisibility:     (*apimodel.ProfileVisibility)(e.EmailVisibility),
+		FirstNameVisibility: (*apimodel.ProfileVisibility)(e.FirstNameVisibility),
+		LastNameVisibility:  (*apimodel.ProfileVisibility)(e.LastNameVisibility),
 	}
+
+	if e.Bio != nil {
+		bioHTML := entity.RenderProfileText(*e.Bio)
+		u.BioHTML = &bioHTML
+	}
+
+	if e.Signature != nil {
+		signatureHTML := entity.RenderProfileText(*e.Signature)
+		u.SignatureHTML = &signatureHTML
+	}
+
+	return u
 }
 
 func UsersToRest(e []*entity.User) []*apimodel.User {
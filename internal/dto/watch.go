@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+func TopicWatchToDB(e *entity.TopicWatch) *dbmodel.TopicWatch {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.TopicWatch{
+		UserID:  e.UserID,
+		TopicID: e.TopicID,
+	}
+}
+
+func SectionWatchToDB(e *entity.SectionWatch) *dbmodel.SectionWatch {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.SectionWatch{
+		UserID:    e.UserID,
+		SectionID: e.SectionID,
+	}
+}
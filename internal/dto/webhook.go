@@ -0,0 +1,287 @@
+package dto
+
+import (
+	"simplestforum/internal/delivery/api/apimodel"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func WebhookAddToDB(e *entity.WebhookAdd) *dbmodel.Webhook {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.Webhook{
+		URL:        e.URL,
+		Secret:     e.Secret,
+		EventTypes: eventTypesToDB(e.EventTypes),
+		SectionIDs: e.SectionIDs,
+		Active:     e.Active,
+	}
+}
+
+func WebhookEditToDB(e *entity.WebhookEdit) (*dbmodel.WebhookUpdate, int64) {
+	if e == nil {
+		return nil, 0
+	}
+
+	webhookUpdate := &dbmodel.WebhookUpdate{
+		URL:    e.URL,
+		Secret: e.Secret,
+		Active: e.Active,
+	}
+
+	if e.EventTypes != nil {
+		eventTypes := eventTypesToDB(e.EventTypes)
+		webhookUpdate.EventTypes = &eventTypes
+	}
+
+	if e.SectionIDs != nil {
+		sectionIDs := pq.Int64Array(e.SectionIDs)
+		webhookUpdate.SectionIDs = &sectionIDs
+	}
+
+	return webhookUpdate, e.ID
+}
+
+func WebhookFromDB(w *dbmodel.Webhook) *entity.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	eventTypes := make([]entity.EventType, len(w.EventTypes))
+
+	for i, eventType := range w.EventTypes {
+		eventTypes[i] = entity.EventType(eventType)
+	}
+
+	return &entity.Webhook{
+		ID:         w.ID,
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventTypes: eventTypes,
+		SectionIDs: w.SectionIDs,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+func WebhooksFromDB(w []*dbmodel.Webhook) []*entity.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	webhooks := make([]*entity.Webhook, len(w))
+
+	for i, webhook := range w {
+		webhooks[i] = WebhookFromDB(webhook)
+	}
+
+	return webhooks
+}
+
+func WebhookDeliveryAddToDB(e *entity.WebhookDeliveryAdd) *dbmodel.WebhookDelivery {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.WebhookDelivery{
+		WebhookID:  e.WebhookID,
+		EventID:    e.EventID,
+		EventType:  string(e.EventType),
+		Payload:    e.Payload,
+		StatusCode: e.StatusCode,
+		Error:      e.Error,
+		DurationMs: e.Duration.Milliseconds(),
+	}
+}
+
+func WebhookDeliveryFromDB(d *dbmodel.WebhookDelivery) *entity.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+
+	return &entity.WebhookDelivery{
+		ID:         d.ID,
+		WebhookID:  d.WebhookID,
+		EventID:    d.EventID,
+		EventType:  entity.EventType(d.EventType),
+		Payload:    d.Payload,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		Duration:   time.Duration(d.DurationMs) * time.Millisecond,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func WebhookDeliveriesFromDB(d []*dbmodel.WebhookDelivery) []*entity.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+
+	deliveries := make([]*entity.WebhookDelivery, len(d))
+
+	for i, delivery := range d {
+		deliveries[i] = WebhookDeliveryFromDB(delivery)
+	}
+
+	return deliveries
+}
+
+func WebhookDeliveryFiltersToDB(e *entity.WebhookDeliveryFilters) *dbmodel.WebhookDeliveryFilters {
+	if e == nil {
+		return nil
+	}
+
+	return &dbmodel.WebhookDeliveryFilters{
+		IDs:        e.IDs,
+		WebhookIDs: e.WebhookIDs,
+		EventIDs:   e.EventIDs,
+		EventTypes: eventTypesToDB(e.EventTypes),
+	}
+}
+
+func WebhookAddFromRest(f *apimodel.AddWebhookInput) *entity.WebhookAdd {
+	if f == nil {
+		return nil
+	}
+
+	e := &entity.WebhookAdd{
+		URL:        f.URL,
+		EventTypes: eventTypesFromRest(f.EventTypes),
+		SectionIDs: f.SectionIds,
+		Active:     f.Active,
+	}
+
+	if f.Secret != nil {
+		e.Secret = *f.Secret
+	}
+
+	return e
+}
+
+func WebhookEditFromRest(f *apimodel.EditWebhookInput) *entity.WebhookEdit {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.WebhookEdit{
+		ID:         f.ID,
+		URL:        f.URL,
+		Secret:     f.Secret,
+		EventTypes: eventTypesFromRest(f.EventTypes),
+		SectionIDs: f.SectionIds,
+		Active:     f.Active,
+	}
+}
+
+func WebhookDeliveryFiltersFromRest(f *apimodel.WebhookDeliveryFilters) *entity.WebhookDeliveryFilters {
+	if f == nil {
+		return nil
+	}
+
+	return &entity.WebhookDeliveryFilters{
+		IDs:        f.Ids,
+		WebhookIDs: f.WebhookIds,
+		EventIDs:   f.EventIds,
+		EventTypes: eventTypesFromRest(f.EventTypes),
+	}
+}
+
+func WebhookToRest(e *entity.Webhook) *apimodel.Webhook {
+	if e == nil {
+		return nil
+	}
+
+	eventTypes := make([]string, len(e.EventTypes))
+
+	for i, eventType := range e.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return &apimodel.Webhook{
+		ID:         e.ID,
+		URL:        e.URL,
+		Secret:     e.Secret,
+		EventTypes: eventTypes,
+		SectionIds: e.SectionIDs,
+		Active:     e.Active,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
+func WebhooksToRest(e []*entity.Webhook) []*apimodel.Webhook {
+	if e == nil {
+		return nil
+	}
+
+	webhooks := make([]*apimodel.Webhook, len(e))
+
+	for i, webhook := range e {
+		webhooks[i] = WebhookToRest(webhook)
+	}
+
+	return webhooks
+}
+
+func WebhookDeliveryToRest(e *entity.WebhookDelivery) *apimodel.WebhookDelivery {
+	if e == nil {
+		return nil
+	}
+
+	return &apimodel.WebhookDelivery{
+		ID:         e.ID,
+		WebhookID:  e.WebhookID,
+		EventID:    e.EventID,
+		EventType:  string(e.EventType),
+		Payload:    e.Payload,
+		StatusCode: e.StatusCode,
+		Error:      e.Error,
+		DurationMs: e.Duration.Milliseconds(),
+		Succeeded:  e.Succeeded(),
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func WebhookDeliveriesToRest(e []*entity.WebhookDelivery) []*apimodel.WebhookDelivery {
+	if e == nil {
+		return nil
+	}
+
+	deliveries := make([]*apimodel.WebhookDelivery, len(e))
+
+	for i, delivery := range e {
+		deliveries[i] = WebhookDeliveryToRest(delivery)
+	}
+
+	return deliveries
+}
+
+func eventTypesToDB(e []entity.EventType) pq.StringArray {
+	eventTypes := make(pq.StringArray, len(e))
+
+	for i, eventType := range e {
+		eventTypes[i] = string(eventType)
+	}
+
+	return eventTypes
+}
+
+func eventTypesFromRest(f []string) []entity.EventType {
+	if f == nil {
+		return nil
+	}
+
+	eventTypes := make([]entity.EventType, len(f))
+
+	for i, eventType := range f {
+		eventTypes[i] = entity.EventType(eventType)
+	}
+
+	return eventTypes
+}
@@ -0,0 +1,112 @@
+package broadcast
+
+import (
+	"context"
+	"simplestforum/internal/domain/entity"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the broadcast messages in the memory of a single process, so they only reach this instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	seq       int64
+	messages  []*entity.BroadcastMessage
+	listeners map[chan struct{}]struct{}
+}
+
+// NewMemoryStore instantiates a MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		listeners: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Insert stores a message under the next number and signals the listeners.
+func (s *MemoryStore) Insert(_ entity.Session, e *entity.BroadcastMessageAdd) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	s.messages = append(s.messages, &entity.BroadcastMessage{
+		Seq:        s.seq,
+		Subscriber: e.Subscriber,
+		EventType:  e.EventType,
+		Payload:    e.Payload,
+		SessionID:  e.SessionID,
+		CreatedAt:  time.Now(),
+	})
+
+	// A listener which is already signalled reads this message along with the previous ones
+	for listener := range s.listeners {
+		select {
+		case listener <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// SelectAfter returns up to limit messages numbered after seq, in their order.
+func (s *MemoryStore) SelectAfter(_ entity.Session, seq int64, limit int64) ([]*entity.BroadcastMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var messages []*entity.BroadcastMessage
+
+	for _, message := range s.messages {
+		if int64(len(messages)) == limit {
+			break
+		}
+
+		if message.Seq > seq {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages, nil
+}
+
+// SelectLastSeq returns the number of the last message, or zero if none were stored.
+func (s *MemoryStore) SelectLastSeq(_ entity.Session) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seq, nil
+}
+
+// DeleteBefore removes the messages stored before the given time and returns their number.
+func (s *MemoryStore) DeleteBefore(_ entity.Session, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The messages are stored in the order of their time
+	n := 0
+	for n < len(s.messages) && s.messages[n].CreatedAt.Before(before) {
+		n++
+	}
+
+	s.messages = append([]*entity.BroadcastMessage(nil), s.messages[n:]...)
+
+	return int64(n), nil
+}
+
+// Listen returns a channel signalled once new messages are stored, until the context is done.
+func (s *MemoryStore) Listen(ctx context.Context) <-chan struct{} {
+	listener := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	s.listeners[listener] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		delete(s.listeners, listener)
+		s.mu.Unlock()
+	}()
+
+	return listener
+}
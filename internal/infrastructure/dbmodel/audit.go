@@ -0,0 +1,27 @@
+package dbmodel
+
+import "time"
+
+// AuditEntry is a structure which represents the 'audit_log' table entry.
+type AuditEntry struct {
+	ID         int64     `db:"id"`
+	ActorID    int64     `db:"actor_id"`
+	Action     string    `db:"action"`
+	TargetType string    `db:"target_type"`
+	TargetID   int64     `db:"target_id"`
+	Before     *string   `db:"before"`
+	After      *string   `db:"after"`
+	SessionID  string    `db:"session_id"`
+	CreatedAt  time.Time `db:"created_at" insert:"false"`
+}
+
+// AuditFilters is a structure which represents audit log filters.
+type AuditFilters struct {
+	ActorIDs    []int64    `db:"actor_id" sign:"="`
+	Actions     []string   `db:"action" sign:"="`
+	TargetTypes []string   `db:"target_type" sign:"="`
+	TargetIDs   []int64    `db:"target_id" sign:"="`
+	SessionID   *string    `db:"session_id" sign:"="`
+	From        *time.Time `db:"created_at" sign:">="`
+	To          *time.Time `db:"created_at" sign:"<"`
+}
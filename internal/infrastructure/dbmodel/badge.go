@@ -0,0 +1,29 @@
+package dbmodel
+
+import "time"
+
+// Badge is a structure which represents the 'badges' table entry.
+type Badge struct {
+	ID          int64     `db:"id"`
+	Name        string    `db:"name"`
+	Title       string    `db:"title"`
+	Description string    `db:"description"`
+	Rule        string    `db:"rule"`
+	Threshold   int64     `db:"threshold"`
+	CreatedAt   time.Time `db:"created_at" insert:"false"`
+	UpdatedAt   time.Time `db:"updated_at" insert:"false"`
+}
+
+// BadgeUpdate is a structure used to store the optional fields to update a Badge.
+type BadgeUpdate struct {
+	Title       *string `db:"title"`
+	Description *string `db:"description"`
+	Threshold   *int64  `db:"threshold"`
+}
+
+// UserBadge is a structure which represents the 'user_badges' table entry.
+type UserBadge struct {
+	UserID    int64     `db:"user_id"`
+	BadgeID   int64     `db:"badge_id"`
+	GrantedAt time.Time `db:"granted_at"`
+}
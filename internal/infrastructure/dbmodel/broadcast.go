@@ -0,0 +1,13 @@
+package dbmodel
+
+import "time"
+
+// BroadcastMessage is a structure which represents the 'broadcast_messages' table entry.
+type BroadcastMessage struct {
+	Seq        int64     `db:"seq" insert:"false"`
+	Subscriber string    `db:"subscriber"`
+	EventType  string    `db:"event_type"`
+	Payload    string    `db:"payload"`
+	SessionID  string    `db:"session_id"`
+	CreatedAt  time.Time `db:"created_at" insert:"false"`
+}
@@ -0,0 +1,24 @@
+package dbmodel
+
+// FilterDecision is a structure which represents the 'content_filter_decisions' table entry.
+type FilterDecision struct {
+	ContentKind string   `db:"content_kind"`
+	UserID      int64    `db:"user_id"`
+	Filter      string   `db:"filter"`
+	Verdict     string   `db:"verdict"`
+	Score       *float64 `db:"score"`
+	Reason      string   `db:"reason"`
+}
+
+// SpamTokenCount is a structure which represents the 'spam_tokens' table entry.
+type SpamTokenCount struct {
+	Token     string `db:"token"`
+	SpamCount int64  `db:"spam_count"`
+	HamCount  int64  `db:"ham_count"`
+}
+
+// SpamTotals is a structure which represents the 'spam_totals' table entry.
+type SpamTotals struct {
+	SpamCount int64 `db:"spam_count"`
+	HamCount  int64 `db:"ham_count"`
+}
@@ -0,0 +1,19 @@
+package dbmodel
+
+// UserFollow is a structure which represents the 'user_follows' table entry.
+type UserFollow struct {
+	UserID         int64 `db:"user_id"`
+	FollowedUserID int64 `db:"followed_user_id"`
+}
+
+// UserIgnore is a structure which represents the 'user_ignores' table entry.
+type UserIgnore struct {
+	UserID        int64 `db:"user_id"`
+	IgnoredUserID int64 `db:"ignored_user_id"`
+}
+
+// UserFollowFilters is a structure which represents follow filters.
+type UserFollowFilters struct {
+	UserIDs         []int64 `db:"user_id" sign:"="`
+	FollowedUserIDs []int64 `db:"followed_user_id" sign:"="`
+}
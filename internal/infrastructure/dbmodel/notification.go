@@ -2,9 +2,47 @@ package dbmodel
 
 import "time"
 
+// Notification is a structure which represents the 'notifications' table entry.
 type Notification struct {
-	ID        int64     `db:"id"`
-	UserID    int64     `db:"user_id"`
-	Text      string    `db:"text"`
-	CreatedAt time.Time `db:"created_at" insert:"false"`
+	ID        int64      `db:"id"`
+	Seq       int64      `db:"seq" insert:"false"`
+	UserID    int64      `db:"user_id"`
+	Kind      string     `db:"kind"`
+	ActorID   *int64     `db:"actor_id"`
+	PostID    *int64     `db:"post_id"`
+	TopicID   *int64     `db:"topic_id"`
+	SectionID *int64     `db:"section_id"`
+	Text      string     `db:"text"`
+	GroupKey  *string    `db:"group_key"`
+	GroupText *string    `db:"group_text"`
+	Count     int64      `db:"count" insert:"false"`
+	ReadAt    *time.Time `db:"read_at" insert:"false"`
+	CreatedAt time.Time  `db:"created_at" insert:"false"`
+}
+
+// NotificationPreference is a structure which represents the 'notification_preferences' table entry.
+type NotificationPreference struct {
+	UserID int64  `db:"user_id"`
+	Kind   string `db:"kind"`
+	InApp  bool   `db:"in_app"`
+	Email  string `db:"email"`
+}
+
+// NotificationEmail is a structure which represents the 'notification_emails' table entry.
+type NotificationEmail struct {
+	ID        int64      `db:"id"`
+	UserID    int64      `db:"user_id"`
+	Kind      string     `db:"kind"`
+	Mode      string     `db:"mode"`
+	Text      string     `db:"text"`
+	CreatedAt time.Time  `db:"created_at" insert:"false"`
+	SentAt    *time.Time `db:"sent_at" insert:"false"`
+}
+
+// NotificationEmailWithUser is a 'notification_emails' entry joined with its recipient.
+type NotificationEmailWithUser struct {
+	Nickname  string  `db:"nickname"`
+	UserEmail *string `db:"user_email"`
+
+	NotificationEmail
 }
@@ -0,0 +1,36 @@
+package dbmodel
+
+import "time"
+
+// OutboxMessage is a structure which represents the 'outbox' table entry.
+type OutboxMessage struct {
+	ID            int64     `db:"id"`
+	Subscriber    string    `db:"subscriber"`
+	EventType     string    `db:"event_type"`
+	Payload       string    `db:"payload"`
+	SessionID     string    `db:"session_id"`
+	UserID        int64     `db:"user_id"`
+	UserLevel     string    `db:"user_level"`
+	Status        string    `db:"status" insert:"false"`
+	Attempts      int64     `db:"attempts" insert:"false"`
+	LastError     *string   `db:"last_error" insert:"false"`
+	NextAttemptAt time.Time `db:"next_attempt_at" insert:"false"`
+	CreatedAt     time.Time `db:"created_at" insert:"false"`
+}
+
+// OutboxMessageUpdate is a structure used to store the optional fields to update an OutboxMessage.
+type OutboxMessageUpdate struct {
+	Status        *string    `db:"status"`
+	Attempts      *int64     `db:"attempts"`
+	LastError     *string    `db:"last_error"`
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
+}
+
+// OutboxFilters is a structure which represents outbox filters.
+type OutboxFilters struct {
+	IDs          []int64  `db:"id" sign:"="`
+	Statuses     []string `db:"status" sign:"="`
+	Subscribers  []string `db:"subscriber" sign:"="`
+	EventTypes   []string `db:"event_type" sign:"="`
+	AttemptsFrom *int64   `db:"attempts" sign:">="`
+}
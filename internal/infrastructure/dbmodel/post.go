@@ -5,7 +5,9 @@ import "time"
 // Post is a structure which represents the 'posts' table entry.
 type Post struct {
 	ID        int64      `db:"id"`
-	Text      string     `db:"name"`
+	Text      string     `db:"text"`
+	Status    string     `db:"status"`
+	Wiki      bool       `db:"wiki" insert:"false"`
 	TopicID   int64      `db:"topic_id"`
 	UserID    int64      `db:"user_id"`
 	CreatedAt time.Time  `db:"created_at" insert:"false"`
@@ -16,16 +18,32 @@ type Post struct {
 // PostUpdate is a structure which is used to modify an existing entry in 'posts' table.
 type PostUpdate struct {
 	Text    *string `db:"text"`
+	Wiki    *bool   `db:"wiki"`
 	UserID  *int64  `db:"user_id"`
 	TopicID *int64  `db:"topic_id"`
 }
 
 // PostFilters is a structure which represents post filters.
 type PostFilters struct {
-	IDs      []int64 `db:"id" sign:"="`
-	UserIDs  []int64 `db:"user_id" sign:"="`
-	TopicIDs []int64 `db:"topic_id" sign:"="`
+	IDs            []int64 `db:"id" sign:"="`
+	UserIDs        []int64 `db:"user_id" sign:"="`
+	TopicIDs       []int64 `db:"topic_id" sign:"="`
+	ExcludeUserIDs []int64 `db:"user_id" sign:"!="`
 }
 
 // PostDelete is a structure which represents post filters for deletion.
 type PostDelete PostFilters
+
+// PostAuthorCount is a structure which represents the number of posts of a user aggregated out of the 'posts' table.
+type PostAuthorCount struct {
+	UserID int64 `db:"user_id"`
+	Count  int64 `db:"count"`
+}
+
+// PostContributor is a structure which represents the edits of a user aggregated out of the 'post_edits' table.
+type PostContributor struct {
+	PostID       int64     `db:"post_id"`
+	UserID       int64     `db:"user_id"`
+	CountEdits   int64     `db:"count_edits"`
+	LastEditedAt time.Time `db:"last_edited_at"`
+}
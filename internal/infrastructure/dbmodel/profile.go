@@ -0,0 +1,41 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ProfileField is a structure which represents the 'profile_fields' table entry.
+type ProfileField struct {
+	ID         int64          `db:"id"`
+	Name       string         `db:"name"`
+	Title      string         `db:"title"`
+	Type       string         `db:"type"`
+	Required   bool           `db:"required"`
+	Visibility string         `db:"visibility"`
+	Pattern    *string        `db:"pattern"`
+	MaxLength  *int64         `db:"max_length"`
+	Options    pq.StringArray `db:"options"`
+	Position   int64          `db:"position"`
+	CreatedAt  time.Time      `db:"created_at" insert:"false"`
+	UpdatedAt  time.Time      `db:"updated_at" insert:"false"`
+}
+
+// ProfileFieldUpdate is a structure used to store the optional fields to update a ProfileField.
+type ProfileFieldUpdate struct {
+	Title      *string         `db:"title"`
+	Required   *bool           `db:"required"`
+	Visibility *string         `db:"visibility"`
+	Pattern    **string        `db:"pattern"`
+	MaxLength  **int64         `db:"max_length"`
+	Options    *pq.StringArray `db:"options"`
+	Position   *int64          `db:"position"`
+}
+
+// ProfileFieldValue is a structure which represents the 'user_profile_values' table entry.
+type ProfileFieldValue struct {
+	UserID  int64  `db:"user_id"`
+	FieldID int64  `db:"field_id"`
+	Value   string `db:"value"`
+}
@@ -0,0 +1,24 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Rank is a structure which represents the 'ranks' table entry.
+type Rank struct {
+	ID        int64          `db:"id"`
+	MinPosts  int64          `db:"min_posts"`
+	Title     string         `db:"title"`
+	Perks     pq.StringArray `db:"perks"`
+	CreatedAt time.Time      `db:"created_at" insert:"false"`
+	UpdatedAt time.Time      `db:"updated_at" insert:"false"`
+}
+
+// RankUpdate is a structure used to store the optional fields to update a Rank.
+type RankUpdate struct {
+	MinPosts *int64          `db:"min_posts"`
+	Title    *string         `db:"title"`
+	Perks    *pq.StringArray `db:"perks"`
+}
@@ -0,0 +1,10 @@
+package dbmodel
+
+import "time"
+
+// RateLimitBucket is a structure which represents the 'rate_limit_buckets' table entry.
+type RateLimitBucket struct {
+	Key       string    `db:"key"`
+	Tokens    float64   `db:"tokens"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
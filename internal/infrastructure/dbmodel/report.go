@@ -0,0 +1,40 @@
+package dbmodel
+
+import "time"
+
+// Report is a structure which represents the 'reports' table entry.
+type Report struct {
+	ID             int64      `db:"id"`
+	ReporterID     int64      `db:"reporter_id"`
+	TargetType     string     `db:"target_type"`
+	TargetID       int64      `db:"target_id"`
+	TargetUserID   int64      `db:"target_user_id"`
+	Reason         string     `db:"reason"`
+	Text           *string    `db:"text"`
+	Status         string     `db:"status" insert:"false"`
+	AssigneeID     *int64     `db:"assignee_id" insert:"false"`
+	ResolutionNote *string    `db:"resolution_note" insert:"false"`
+	Action         *string    `db:"action" insert:"false"`
+	ResolvedByID   *int64     `db:"resolved_by_id" insert:"false"`
+	ResolvedAt     *time.Time `db:"resolved_at" insert:"false"`
+	CreatedAt      time.Time  `db:"created_at" insert:"false"`
+	UpdatedAt      time.Time  `db:"updated_at" insert:"false"`
+}
+
+// ReportResolve is a structure which is used to close an existing entry in 'reports' table.
+type ReportResolve struct {
+	Status         string  `db:"status"`
+	ResolutionNote *string `db:"resolution_note"`
+	Action         *string `db:"action"`
+	ResolvedByID   int64   `db:"resolved_by_id"`
+}
+
+// ReportFilters is a structure which represents report filters.
+type ReportFilters struct {
+	IDs           []int64  `db:"id" sign:"="`
+	Statuses      []string `db:"status" sign:"="`
+	TargetTypes   []string `db:"target_type" sign:"="`
+	TargetUserIDs []int64  `db:"target_user_id" sign:"="`
+	AssigneeIDs   []int64  `db:"assignee_id" sign:"="`
+	ReporterIDs   []int64  `db:"reporter_id" sign:"="`
+}
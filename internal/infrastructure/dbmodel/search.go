@@ -0,0 +1,26 @@
+package dbmodel
+
+import "time"
+
+// SearchHit is a structure which represents a row of the search results over the 'posts' and 'topics' tables.
+type SearchHit struct {
+	Kind      string    `db:"kind"`
+	ID        int64     `db:"id"`
+	TopicID   int64     `db:"topic_id"`
+	SectionID int64     `db:"section_id"`
+	UserID    int64     `db:"user_id"`
+	Rank      float64   `db:"rank"`
+	Snippet   string    `db:"snippet"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// SearchDocument is a structure which represents a searchable row of the 'posts' and 'topics' tables.
+type SearchDocument struct {
+	Kind      string    `db:"kind"`
+	ID        int64     `db:"id"`
+	TopicID   int64     `db:"topic_id"`
+	SectionID int64     `db:"section_id"`
+	UserID    int64     `db:"user_id"`
+	Text      string    `db:"text"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -7,6 +7,7 @@ type Section struct {
 	ID          int64      `db:"id"`
 	Name        string     `db:"name"`
 	Description *string    `db:"description"`
+	Mode        string     `db:"mode"`
 	CountTopics int64      `db:"count_topics" insert:"false"`
 	CreatedAt   time.Time  `db:"created_at" insert:"false"`
 	UpdatedAt   time.Time  `db:"updated_at" insert:"false"`
@@ -17,6 +18,7 @@ type Section struct {
 type SectionUpdate struct {
 	Name        *string  `db:"name"`
 	Description **string `db:"description"`
+	Mode        *string  `db:"mode"`
 }
 
 // SectionFilters is a structure which represents section filters.
@@ -12,6 +12,9 @@ type Topic struct {
 	CreatedAt  time.Time  `db:"created_at" insert:"false"`
 	UpdatedAt  time.Time  `db:"updated_at" insert:"false"`
 	DeletedAt  *time.Time `db:"deleted_at" insert:"false"`
+
+	RedirectTopicID *int64 `db:"redirect_topic_id" insert:"false"`
+	AcceptedPostID  *int64 `db:"accepted_post_id" insert:"false"`
 }
 
 // TopicUpdate is a structure which is used to modify an existing entry in 'topics' table.
@@ -30,3 +33,23 @@ type TopicFilters struct {
 
 // TopicDelete is a structure which represents topic filters for deletion.
 type TopicDelete TopicFilters
+
+// TopicRead is a structure which represents the unread posts of a topic aggregated out of the 'topic_reads' table.
+type TopicRead struct {
+	TopicID           int64  `db:"topic_id"`
+	UnreadCount       int64  `db:"unread_count"`
+	FirstUnreadPostID *int64 `db:"first_unread_post_id"`
+}
+
+// TopicAnswerWithdrawal is a structure which represents an accepted answer withdrawn from a 'topics' table entry.
+type TopicAnswerWithdrawal struct {
+	TopicID      int64 `db:"topic_id"`
+	TopicUserID  int64 `db:"topic_user_id"`
+	AnswerUserID int64 `db:"answer_user_id"`
+}
+
+// SimilarTopic is a structure which represents the similarity of a 'topics' table entry to a name.
+type SimilarTopic struct {
+	TopicID    int64   `db:"topic_id"`
+	Similarity float64 `db:"similarity"`
+}
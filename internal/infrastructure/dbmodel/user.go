@@ -6,11 +6,17 @@ import (
 
 // UserInfo is a structure which represents the 'user_info' table entry.
 type UserInfo struct {
-	UserID    int64   `db:"user_id"`
-	Phone     *string `db:"phone"`
-	Email     *string `db:"email"`
-	FirstName *string `db:"first_name"`
-	LastName  *string `db:"last_name"`
+	UserID              int64   `db:"user_id"`
+	Phone               *string `db:"phone"`
+	Email               *string `db:"email"`
+	FirstName           *string `db:"first_name"`
+	LastName            *string `db:"last_name"`
+	Bio                 *string `db:"bio"`
+	Signature           *string `db:"signature"`
+	PhoneVisibility     *string `db:"phone_visibility"`
+	EmailVisibility     *string `db:"email_visibility"`
+	FirstNameVisibility *string `db:"first_name_visibility"`
+	LastNameVisibility  *string `db:"last_name_visibility"`
 }
 
 // User is a structure which represents the 'users' table entry.
@@ -20,6 +26,7 @@ type User struct {
 	Password    string     `db:"password"`
 	ShowInfo    bool       `db:"show_info"`
 	Rank        int64      `db:"rank" insert:"false"`
+	Reputation  int64      `db:"reputation" insert:"false"`
 	Level       *string    `db:"level" insert:"false"`
 	Restriction *string    `db:"restriction" insert:"false"`
 	CountTopics int64      `db:"count_topics" insert:"false"`
@@ -0,0 +1,13 @@
+package dbmodel
+
+// TopicWatch is a structure which represents the 'topic_watches' table entry.
+type TopicWatch struct {
+	UserID  int64 `db:"user_id"`
+	TopicID int64 `db:"topic_id"`
+}
+
+// SectionWatch is a structure which represents the 'section_watches' table entry.
+type SectionWatch struct {
+	UserID    int64 `db:"user_id"`
+	SectionID int64 `db:"section_id"`
+}
@@ -0,0 +1,49 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Webhook is a structure which represents the 'webhooks' table entry.
+type Webhook struct {
+	ID         int64          `db:"id"`
+	URL        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	SectionIDs pq.Int64Array  `db:"section_ids"`
+	Active     bool           `db:"active"`
+	CreatedAt  time.Time      `db:"created_at" insert:"false"`
+	UpdatedAt  time.Time      `db:"updated_at" insert:"false"`
+}
+
+// WebhookUpdate is a structure used to store the optional fields to update a Webhook.
+type WebhookUpdate struct {
+	URL        *string         `db:"url"`
+	Secret     *string         `db:"secret"`
+	EventTypes *pq.StringArray `db:"event_types"`
+	SectionIDs *pq.Int64Array  `db:"section_ids"`
+	Active     *bool           `db:"active"`
+}
+
+// WebhookDelivery is a structure which represents the 'webhook_deliveries' table entry.
+type WebhookDelivery struct {
+	ID         int64     `db:"id"`
+	WebhookID  int64     `db:"webhook_id"`
+	EventID    string    `db:"event_id"`
+	EventType  string    `db:"event_type"`
+	Payload    string    `db:"payload"`
+	StatusCode *int64    `db:"status_code"`
+	Error      *string   `db:"error"`
+	DurationMs int64     `db:"duration_ms"`
+	CreatedAt  time.Time `db:"created_at" insert:"false"`
+}
+
+// WebhookDeliveryFilters is a structure which represents Webhook delivery filters.
+type WebhookDeliveryFilters struct {
+	IDs        []int64  `db:"id" sign:"="`
+	WebhookIDs []int64  `db:"webhook_id" sign:"="`
+	EventIDs   []string `db:"event_id" sign:"="`
+	EventTypes []string `db:"event_type" sign:"="`
+}
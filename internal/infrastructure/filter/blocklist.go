@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"simplestforum/internal/domain/entity"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Blocklist looks for the listed words in the content. The words to replace are masked with asterisks,
+// the content with the words to flag is held for approval, and the content with the words to reject is refused.
+type Blocklist struct {
+	replace *regexp.Regexp
+	flag    *regexp.Regexp
+	reject  *regexp.Regexp
+}
+
+// NewBlocklist instantiates a Blocklist. The words are matched as whole words regardless of the case.
+func NewBlocklist(replace, flag, reject []string) *Blocklist {
+	return &Blocklist{
+		replace: wordsPattern(replace),
+		flag:    wordsPattern(flag),
+		reject:  wordsPattern(reject),
+	}
+}
+
+// Name returns the name of the filter.
+func (f *Blocklist) Name() string {
+	return "blocklist"
+}
+
+// Check masks the words to replace and decides on the words to flag or reject.
+func (f *Blocklist) Check(_ entity.Session, e *entity.FilterContent) (*entity.FilterDecision, error) {
+	decision := &entity.FilterDecision{
+		Verdict: entity.FilterVerdictAllow,
+	}
+
+	var reasons []string
+
+	if f.replace != nil {
+		words := findWords(f.replace, e.Text)
+
+		if len(words) > 0 {
+			e.Text = maskWords(e.Text, words)
+			reasons = append(reasons, fmt.Sprintf("%d words replaced", len(words)))
+		}
+	}
+
+	if f.reject != nil {
+		if words := findWords(f.reject, e.Text); len(words) > 0 {
+			decision.Verdict = entity.FilterVerdictReject
+			reasons = append(reasons, fmt.Sprintf("contains the blocked word %q", e.Text[words[0][0]:words[0][1]]))
+		}
+	}
+
+	if f.flag != nil && decision.Verdict != entity.FilterVerdictReject {
+		if words := findWords(f.flag, e.Text); len(words) > 0 {
+			decision.Verdict = entity.FilterVerdictFlag
+			reasons = append(reasons, fmt.Sprintf("contains the flagged word %q", e.Text[words[0][0]:words[0][1]]))
+		}
+	}
+
+	decision.Reason = strings.Join(reasons, ", ")
+
+	return decision, nil
+}
+
+// wordsPattern returns a case-insensitive pattern matching any of the words at the start of the text followed by
+// a word boundary, or nil if there are none. The longer words are tried first, so a word isn't missed because
+// of its prefix.
+func wordsPattern(words []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(words))
+
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(quoted, func(i, j int) bool {
+		return len(quoted[i]) > len(quoted[j])
+	})
+
+	// \b only knows the ASCII letters, so the boundary is any character which is not a letter or a digit
+	return regexp.MustCompile(`(?i)^(` + strings.Join(quoted, "|") + `)(?:$|[^\pL\pN_])`)
+}
+
+// findWords returns the start and end offsets of the whole words matched by the pattern, in their order.
+func findWords(pattern *regexp.Regexp, text string) [][]int {
+	var (
+		found    [][]int
+		previous rune
+	)
+
+	for start := 0; start < len(text); {
+		// A word only starts after a character which is not a part of a word
+		if start == 0 || !isWordRune(previous) {
+			if loc := pattern.FindStringSubmatchIndex(text[start:]); loc != nil {
+				found = append(found, []int{start + loc[2], start + loc[3]})
+				previous, _ = utf8.DecodeLastRuneInString(text[:start+loc[3]])
+				start += loc[3]
+
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(text[start:])
+		previous = r
+		start += size
+	}
+
+	return found
+}
+
+// maskWords replaces every rune of the found words with an asterisk.
+func maskWords(text string, words [][]int) string {
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, word := range words {
+		b.WriteString(text[last:word[0]])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[word[0]:word[1]])))
+		last = word[1]
+	}
+
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+// isWordRune checks if the rune is a part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r)
+}
@@ -0,0 +1,41 @@
+package filter
+
+import (
+	"fmt"
+	"simplestforum/internal/domain/entity"
+)
+
+// LinkLimit applies the verdict to the content with more links than allowed.
+type LinkLimit struct {
+	max     int
+	verdict entity.FilterVerdict
+}
+
+// NewLinkLimit instantiates a LinkLimit.
+func NewLinkLimit(max int, verdict entity.FilterVerdict) *LinkLimit {
+	return &LinkLimit{
+		max:     max,
+		verdict: verdict,
+	}
+}
+
+// Name returns the name of the filter.
+func (f *LinkLimit) Name() string {
+	return "link_limit"
+}
+
+// Check counts the links in the content.
+func (f *LinkLimit) Check(_ entity.Session, e *entity.FilterContent) (*entity.FilterDecision, error) {
+	links := entity.CountLinks(e.Text)
+
+	decision := &entity.FilterDecision{
+		Verdict: entity.FilterVerdictAllow,
+		Reason:  fmt.Sprintf("%d links, at most %d allowed", links, f.max),
+	}
+
+	if links > f.max {
+		decision.Verdict = f.verdict
+	}
+
+	return decision, nil
+}
@@ -0,0 +1,190 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"simplestforum/internal/domain/entity"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	minTokenLength   = 2
+	maxTokenLength   = 32
+	maxTokensPerText = 500
+
+	// untrainedRecheckInterval is how long the untrained classifier skips the content before counting the samples again.
+	untrainedRecheckInterval = time.Minute
+)
+
+// SpamStorage is an interface which declares methods to interact with the spam classifier storage.
+type SpamStorage interface {
+	SelectSpamTotals(entity.Session) (*entity.SpamTotals, error)
+	SelectSpamTokens(entity.Session, []string) ([]*entity.SpamTokenCount, error)
+	UpsertSpamSample(entity.Session, []string, bool) error
+}
+
+// SpamClassifier is a naive Bayes classifier trained with the content approved or rejected by the moderators.
+// It rates the content with the probability of it being spam, and flags or rejects it above the thresholds.
+// A zero threshold disables the respective verdict.
+type SpamClassifier struct {
+	repo        SpamStorage
+	flagScore   float64
+	rejectScore float64
+	minSamples  int64
+
+	// untrainedUntil is the time until which the classifier is known to be untrained, so it queries nothing
+	mu             sync.Mutex
+	untrainedUntil time.Time
+}
+
+// NewSpamClassifier instantiates a SpamClassifier. It only rates the content after it has seen at least
+// minSamples of both spam and ham.
+func NewSpamClassifier(repo SpamStorage, flagScore, rejectScore float64, minSamples int64) *SpamClassifier {
+	return &SpamClassifier{
+		repo:        repo,
+		flagScore:   flagScore,
+		rejectScore: rejectScore,
+		minSamples:  minSamples,
+	}
+}
+
+// Name returns the name of the filter.
+func (f *SpamClassifier) Name() string {
+	return "spam_classifier"
+}
+
+// Check rates the content.
+func (f *SpamClassifier) Check(sess entity.Session, e *entity.FilterContent) (*entity.FilterDecision, error) {
+	decision := &entity.FilterDecision{
+		Verdict: entity.FilterVerdictAllow,
+	}
+
+	if f.knownUntrained() {
+		decision.Reason = "not trained yet"
+
+		return decision, nil
+	}
+
+	totals, err := f.repo.SelectSpamTotals(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	if totals.Spam < f.minSamples || totals.Ham < f.minSamples {
+		f.setUntrained(time.Now().Add(untrainedRecheckInterval))
+
+		decision.Reason = fmt.Sprintf("not trained yet: %d spam and %d ham samples", totals.Spam, totals.Ham)
+
+		return decision, nil
+	}
+
+	tokens := tokenize(e.Text)
+
+	counts, err := f.repo.SelectSpamTokens(sess, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	score := spamScore(totals, tokens, counts)
+
+	decision.Score = &score
+	decision.Reason = fmt.Sprintf("spam score %.3f", score)
+
+	switch {
+	case f.rejectScore > 0 && score >= f.rejectScore:
+		decision.Verdict = entity.FilterVerdictReject
+	case f.flagScore > 0 && score >= f.flagScore:
+		decision.Verdict = entity.FilterVerdictFlag
+	}
+
+	return decision, nil
+}
+
+// Train adds the content to the spam or ham samples.
+func (f *SpamClassifier) Train(sess entity.Session, e *entity.SpamSample) error {
+	tokens := tokenize(e.Text)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	err := f.repo.UpsertSpamSample(sess, tokens, e.Spam)
+	if err != nil {
+		return err
+	}
+
+	// The new sample may be the one the classifier has been waiting for
+	f.setUntrained(time.Time{})
+
+	return nil
+}
+
+// knownUntrained checks if the classifier was found untrained recently.
+func (f *SpamClassifier) knownUntrained() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return time.Now().Before(f.untrainedUntil)
+}
+
+// setUntrained remembers the classifier as untrained until the given time.
+func (f *SpamClassifier) setUntrained(until time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.untrainedUntil = until
+}
+
+// spamScore returns the probability of the tokens being spam. The probabilities of the tokens are Laplace-smoothed,
+// and the log-odds are summed up to avoid the underflow.
+func spamScore(totals *entity.SpamTotals, tokens []string, counts []*entity.SpamTokenCount) float64 {
+	countsMap := make(map[string]*entity.SpamTokenCount, len(counts))
+
+	for _, count := range counts {
+		countsMap[count.Token] = count
+	}
+
+	spamSamples, hamSamples := float64(totals.Spam), float64(totals.Ham)
+	logOdds := math.Log((spamSamples + 1) / (hamSamples + 1))
+
+	for _, token := range tokens {
+		var spam, ham float64
+
+		if count, ok := countsMap[token]; ok {
+			spam, ham = float64(count.Spam), float64(count.Ham)
+		}
+
+		logOdds += math.Log((spam+1)/(spamSamples+2)) - math.Log((ham+1)/(hamSamples+2))
+	}
+
+	return 1 / (1 + math.Exp(-logOdds))
+}
+
+// tokenize splits the text into unique lowercase words.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	tokens := make([]string, 0, len(words))
+
+	for _, word := range words {
+		length := utf8.RuneCountInString(word)
+		if length < minTokenLength || length > maxTokenLength || seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		tokens = append(tokens, word)
+
+		if len(tokens) == maxTokensPerText {
+			break
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,66 @@
+package mail
+
+import (
+	"bytes"
+	"io/fs"
+	"simplestforum/internal/domain/entity"
+	"strings"
+	"text/template"
+)
+
+const (
+	immediateTemplate = "immediate.tmpl"
+	digestTemplate    = "digest.tmpl"
+)
+
+// Renderer renders emails out of text templates.
+// Every template must define the "subject" and "body" blocks.
+type Renderer struct {
+	templates map[string]*template.Template
+}
+
+// NewRenderer parses the email templates from the file system.
+func NewRenderer(fsys fs.FS, dir string) (*Renderer, error) {
+	r := &Renderer{
+		templates: make(map[string]*template.Template),
+	}
+
+	for _, name := range []string{immediateTemplate, digestTemplate} {
+		t, err := template.ParseFS(fsys, dir+"/"+name)
+		if err != nil {
+			return nil, err
+		}
+
+		r.templates[name] = t
+	}
+
+	return r, nil
+}
+
+// RenderDigest renders the pending notifications of a User into a single email.
+func (r *Renderer) RenderDigest(e *entity.NotificationDigest) (*entity.Mail, error) {
+	name := digestTemplate
+	if e.Mode == entity.NotificationEmailModeImmediate {
+		name = immediateTemplate
+	}
+
+	t := r.templates[name]
+
+	var subject, body bytes.Buffer
+
+	err := t.ExecuteTemplate(&subject, "subject", e)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.ExecuteTemplate(&body, "body", e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Mail{
+		To:      *e.Email,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}, nil
+}
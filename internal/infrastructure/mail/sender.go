@@ -0,0 +1,90 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"simplestforum/internal/domain/entity"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileSender writes every email into a separate file in the outbox directory instead of sending it.
+// It is meant for development and testing.
+type FileSender struct {
+	dir     string
+	from    string
+	counter uint64
+}
+
+// NewFileSender instantiates a FileSender, creating the outbox directory if needed.
+func NewFileSender(dir, from string) (*FileSender, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSender{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+// Send writes the email into the outbox.
+func (s *FileSender) Send(_ context.Context, m *entity.Mail) error {
+	n := atomic.AddUint64(&s.counter, 1)
+	name := fmt.Sprintf("%s-%06d.eml", time.Now().UTC().Format("20060102T150405"), n)
+
+	return os.WriteFile(filepath.Join(s.dir, name), message(s.from, m), 0o600)
+}
+
+// SMTPSender sends emails through an SMTP server.
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPSender instantiates an SMTPSender. If the username is empty, no authentication is used.
+func NewSMTPSender(host, port, username, password, from string) *SMTPSender {
+	s := &SMTPSender{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+	}
+
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s
+}
+
+// Send sends the email.
+func (s *SMTPSender) Send(_ context.Context, m *entity.Mail) error {
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{m.To}, message(s.from, m))
+}
+
+// message builds a plain text message with the headers.
+func message(from string, m *entity.Mail) []byte {
+	var b strings.Builder
+
+	b.WriteString("From: " + header(from) + "\r\n")
+	b.WriteString("To: " + header(m.To) + "\r\n")
+	b.WriteString("Subject: " + header(m.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
+
+// header strips the line breaks which could be used to inject extra headers.
+func header(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"simplestforum/internal/domain/entity"
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of takes after which the refilled buckets are forgotten.
+const sweepEvery = 1024
+
+// memoryBucket is a bucket along with the quota it was last taken with.
+type memoryBucket struct {
+	bucket *entity.RateLimitBucket
+	quota  entity.RateLimitQuota
+}
+
+// MemoryStore keeps the token buckets in the memory of a single process.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	takes   int
+}
+
+// NewMemoryStore instantiates a MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+// Take spends a token of the bucket and returns zero, or the time until the next token if none is left.
+func (s *MemoryStore) Take(_ entity.Session, e *entity.RateLimitTake) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.takes++
+	if s.takes%sweepEvery == 0 {
+		s.sweep(e.Now)
+	}
+
+	var stored *entity.RateLimitBucket
+
+	if b, ok := s.buckets[e.Key]; ok {
+		stored = b.bucket
+	}
+
+	bucket, retryAfter := e.Quota.Take(stored, e.Now)
+	if retryAfter > 0 {
+		return retryAfter, nil
+	}
+
+	s.buckets[e.Key] = &memoryBucket{
+		bucket: bucket,
+		quota:  e.Quota,
+	}
+
+	return 0, nil
+}
+
+// sweep forgets the buckets which have been refilled completely, as they are the same as the missing ones.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if b.quota.Full(b.bucket, now) {
+			delete(s.buckets, key)
+		}
+	}
+}
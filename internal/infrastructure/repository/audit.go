@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"encoding/json"
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+// AuditRepository represents an audit log Repository.
+type AuditRepository struct {
+	*DBConn
+}
+
+// NewAuditRepository instantiates an AuditRepository.
+func NewAuditRepository(db *DBConn) *AuditRepository {
+	return &AuditRepository{db}
+}
+
+// Insert appends a new entry to the audit log.
+func (r *AuditRepository) Insert(sess entity.Session, e *entity.AuditEntryAdd) error {
+	before, err := marshalAuditState(e.Before)
+	if err != nil {
+		return err
+	}
+
+	after, err := marshalAuditState(e.After)
+	if err != nil {
+		return err
+	}
+
+	entry := dto.AuditEntryAddToDB(e, before, after)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("audit_log")
+
+		insertNotNil(stmt, entry)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// SelectAll returns the entries of the audit log matching the filters, the latest first.
+func (r *AuditRepository) SelectAll(sess entity.Session, f *entity.AuditFilters, p *entity.Pagination) ([]*entity.AuditEntry, error) {
+	var entries []*dbmodel.AuditEntry
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Select("*").
+			From("audit_log").
+			OrderDesc("id")
+
+		if f != nil {
+			whereFilters(stmt, dto.AuditFiltersToDB(f))
+		}
+
+		if p != nil {
+			stmt.Paginate(uint64(p.Page), uint64(p.Limit))
+		}
+
+		_, err := stmt.Load(&entries)
+
+		return err
+	})
+
+	return dto.AuditEntriesFromDB(entries), err
+}
+
+// marshalAuditState turns the state of the target into a JSON document, nil stays nil.
+func marshalAuditState(state entity.AuditState) (*string, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, domain.NewErrorWrap(err, domain.ErrCodeInternal, "Cannot encode the audit log entry")
+	}
+
+	document := string(data)
+
+	return &document, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"simplestforum/internal/domain"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+
+	"github.com/gocraft/dbr"
+)
+
+// badgeRuleConditions are the conditions the Users matching a Badge rule meet, the threshold being the argument.
+var badgeRuleConditions = map[entity.BadgeRule]string{
+	entity.BadgeRulePosts:          "u.count_posts >= ?",
+	entity.BadgeRuleTopics:         "u.count_topics >= ?",
+	entity.BadgeRuleFollowers:      "(SELECT COUNT(*) FROM user_follows f WHERE f.followed_user_id = u.id) >= ?",
+	entity.BadgeRuleMembershipDays: "u.created_at <= NOW() - ? * INTERVAL '1 day'",
+}
+
+// BadgeRepository represents a Badge Repository.
+type BadgeRepository struct {
+	*DBConn
+}
+
+// NewBadgeRepository instantiates a BadgeRepository.
+func NewBadgeRepository(db *DBConn) *BadgeRepository {
+	return &BadgeRepository{db}
+}
+
+// Insert creates a new Badge entry in the database and returns its ID.
+func (r *BadgeRepository) Insert(sess entity.Session, e *entity.BadgeAdd) (int64, error) {
+	var badgeID int64
+
+	badge := dto.BadgeAddToDB(e)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("badges").
+			Returning("id")
+
+		insertNotNil(stmt, badge)
+
+		return stmt.Load(&badgeID)
+	})
+
+	return badgeID, err
+}
+
+// Update modifies an existing Badge entry.
+func (r *BadgeRepository) Update(sess entity.Session, e *entity.BadgeEdit) error {
+	badgeUpdate, id := dto.BadgeEditToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("badges").
+			Where("id = ?", id).
+			Set("updated_at", time.Now())
+
+		updateNotNil(stmt, badgeUpdate)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// Delete removes an existing Badge along with the grants of it.
+func (r *BadgeRepository) Delete(sess entity.Session, id int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("badges").
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectByID returns a Badge by its ID.
+func (r *BadgeRepository) SelectByID(sess entity.Session, id int64) (*entity.Badge, error) {
+	var badge *dbmodel.Badge
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Select("*").
+			From("badges").
+			Where("id = ?", id).
+			LoadOne(&badge)
+	})
+
+	return dto.BadgeFromDB(badge), err
+}
+
+// SelectAll returns the Badges of the given rules, or all of them if none are given, the oldest first.
+func (r *BadgeRepository) SelectAll(sess entity.Session, rules []entity.BadgeRule) ([]*entity.Badge, error) {
+	var badges []*dbmodel.Badge
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Select("*").
+			From("badges").
+			OrderAsc("id")
+
+		if len(rules) > 0 {
+			stmt.Where(dbr.Eq("rule", rules))
+		}
+
+		_, err := stmt.Load(&badges)
+
+		return err
+	})
+
+	return dto.BadgesFromDB(badges), err
+}
+
+// SelectUserBadges returns the Badges granted to the Users, in the order they were granted in.
+func (r *BadgeRepository) SelectUserBadges(sess entity.Session, userIDs []int64) ([]*entity.UserBadge, error) {
+	var badges []*dbmodel.UserBadge
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("user_badges").
+			Where(dbr.Eq("user_id", userIDs)).
+			OrderAsc("granted_at").
+			OrderAsc("badge_id").
+			Load(&badges)
+
+		return err
+	})
+
+	return dto.UserBadgesFromDB(badges), err
+}
+
+// GrantEarned grants the Badge to the given Users, or to everyone if none are given, who have earned it and don't
+// have it yet. The IDs of the Users granted the Badge are returned.
+func (r *BadgeRepository) GrantEarned(sess entity.Session, badge *entity.Badge, userIDs []int64) ([]int64, error) {
+	condition, ok := badgeRuleConditions[badge.Rule]
+	if !ok {
+		return nil, domain.NewError(domain.ErrCodeValidation, "Unknown badge rule %s", badge.Rule)
+	}
+
+	var usersCondition dbr.Builder = dbr.Expr("TRUE")
+	if len(userIDs) > 0 {
+		usersCondition = dbr.Eq("u.id", userIDs)
+	}
+
+	var grantedIDs []int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.SelectBySql(`
+			INSERT INTO user_badges (user_id, badge_id)
+			SELECT u.id, ?
+			FROM users u
+			WHERE u.deleted_at IS NULL AND ? AND `+condition+`
+			ON CONFLICT DO NOTHING
+			RETURNING user_id`,
+			badge.ID, usersCondition, badge.Threshold,
+		).Load(&grantedIDs)
+
+		return err
+	})
+
+	return grantedIDs, err
+}
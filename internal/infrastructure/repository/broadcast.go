@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// broadcastChannel is the name of the channel notified of the new broadcast messages.
+	broadcastChannel = "broadcast_messages"
+
+	// broadcastPingInterval is how often the idle listener checks its connection.
+	broadcastPingInterval = 30 * time.Second
+
+	broadcastMinReconnect = time.Second
+	broadcastMaxReconnect = time.Minute
+)
+
+// BroadcastRepository represents a broadcast message Repository. Every instance is notified of the new messages
+// through LISTEN/NOTIFY on a connection of its own.
+type BroadcastRepository struct {
+	*DBConn
+	dsn string
+}
+
+// NewBroadcastRepository instantiates a BroadcastRepository.
+func NewBroadcastRepository(db *DBConn, dsn string) *BroadcastRepository {
+	return &BroadcastRepository{db, dsn}
+}
+
+// Insert stores a message and notifies the listeners. The table is locked in a short transaction of its own,
+// so the messages are committed in the order of their numbers and none is skipped by a reader.
+func (r *BroadcastRepository) Insert(sess entity.Session, e *entity.BroadcastMessageAdd) error {
+	tx, err := r.NewTransaction(sess.Ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.RollbackUnlessCommitted()
+
+	sess.Transaction = tx
+	message := dto.BroadcastMessageAddToDB(e)
+
+	err = r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.UpdateBySql("LOCK TABLE broadcast_messages IN EXCLUSIVE MODE").Exec()
+		if err != nil {
+			return err
+		}
+
+		// The notification is only sent once the transaction commits
+		_, err = tx.InsertBySql(
+			`WITH message AS (
+				INSERT INTO broadcast_messages (subscriber, event_type, payload, session_id)
+				VALUES (?, ?, ?, ?)
+				RETURNING seq
+			)
+			SELECT pg_notify(?, seq::TEXT) FROM message`,
+			message.Subscriber, message.EventType, message.Payload, message.SessionID, broadcastChannel,
+		).Exec()
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SelectAfter returns up to limit messages numbered after seq, in their order.
+func (r *BroadcastRepository) SelectAfter(sess entity.Session, seq int64,
+	limit int64) ([]*entity.BroadcastMessage, error) {
+	var messages []*dbmodel.BroadcastMessage
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("broadcast_messages").
+			Where("seq > ?", seq).
+			OrderAsc("seq").
+			Limit(uint64(limit)).
+			Load(&messages)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.BroadcastMessagesFromDB(messages), nil
+}
+
+// SelectLastSeq returns the number of the last message, or zero if none are stored.
+func (r *BroadcastRepository) SelectLastSeq(sess entity.Session) (int64, error) {
+	var seq int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Select("COALESCE(MAX(seq), 0)").
+			From("broadcast_messages").
+			LoadOne(&seq)
+	})
+
+	return seq, err
+}
+
+// DeleteBefore removes the messages stored before the given time and returns their number.
+func (r *BroadcastRepository) DeleteBefore(sess entity.Session, before time.Time) (int64, error) {
+	var count int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		res, err := tx.DeleteFrom("broadcast_messages").
+			Where("created_at < ?", before).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+
+		return err
+	})
+
+	return count, err
+}
+
+// Listen returns a channel signalled once new messages are stored, until the context is done.
+// The listener reconnects by itself and signals the channel after every reconnect as well,
+// since the notifications sent in between are lost.
+func (r *BroadcastRepository) Listen(ctx context.Context) <-chan struct{} {
+	notified := make(chan struct{}, 1)
+	listener := pq.NewListener(r.dsn, broadcastMinReconnect, broadcastMaxReconnect, nil)
+
+	signal := func() {
+		select {
+		case notified <- struct{}{}:
+		default:
+		}
+	}
+
+	// Closing the listener interrupts both the connection attempts and the loop below
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	go func() {
+		err := listener.Listen(broadcastChannel)
+		if err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(broadcastPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-listener.Notify:
+				if !ok {
+					return
+				}
+
+				signal()
+			case <-ticker.C:
+				_ = listener.Ping()
+			}
+		}
+	}()
+
+	return notified
+}
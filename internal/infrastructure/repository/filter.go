@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+
+	"github.com/gocraft/dbr"
+	"github.com/lib/pq"
+)
+
+// FilterRepository represents a content filter Repository.
+type FilterRepository struct {
+	*DBConn
+}
+
+// NewFilterRepository instantiates a FilterRepository.
+func NewFilterRepository(db *DBConn) *FilterRepository {
+	return &FilterRepository{db}
+}
+
+// InsertDecisions records the decisions of the content filters.
+func (r *FilterRepository) InsertDecisions(sess entity.Session, e *entity.FilterDecisionLog) error {
+	decisions := dto.FilterDecisionLogToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("content_filter_decisions").
+			Columns("content_kind", "user_id", "filter", "verdict", "score", "reason")
+
+		for _, decision := range decisions {
+			stmt.Record(decision)
+		}
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// SelectSpamTotals returns the number of spam and ham samples the classifier was trained with.
+func (r *FilterRepository) SelectSpamTotals(sess entity.Session) (*entity.SpamTotals, error) {
+	var totals *dbmodel.SpamTotals
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Select("spam_count", "ham_count").
+			From("spam_totals").
+			LoadOne(&totals)
+	})
+
+	return dto.SpamTotalsFromDB(totals), err
+}
+
+// SelectSpamTokens returns the number of spam and ham samples each of the known tokens was seen in.
+func (r *FilterRepository) SelectSpamTokens(sess entity.Session, tokens []string) ([]*entity.SpamTokenCount, error) {
+	var counts []*dbmodel.SpamTokenCount
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("spam_tokens").
+			Where(dbr.Eq("token", tokens)).
+			Load(&counts)
+
+		return err
+	})
+
+	return dto.SpamTokenCountsFromDB(counts), err
+}
+
+// UpsertSpamSample adds a spam or ham sample made of the tokens to the counters.
+func (r *FilterRepository) UpsertSpamSample(sess entity.Session, tokens []string, spam bool) error {
+	var spamCount, hamCount int64 = 0, 1
+
+	if spam {
+		spamCount, hamCount = 1, 0
+	}
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.UpdateBySql(
+			"UPDATE spam_totals SET spam_count = spam_count + ?, ham_count = ham_count + ?",
+			spamCount, hamCount,
+		).Exec()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.InsertBySql(`
+			INSERT INTO spam_tokens (token, spam_count, ham_count)
+			SELECT token, ?, ? FROM UNNEST(?::TEXT[]) AS token
+			ON CONFLICT (token) DO UPDATE
+			SET spam_count = spam_tokens.spam_count + EXCLUDED.spam_count,
+			    ham_count = spam_tokens.ham_count + EXCLUDED.ham_count`,
+			spamCount, hamCount, pq.Array(tokens),
+		).Exec()
+
+		return err
+	})
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+)
+
+// FollowRepository represents a Repository of the follow and ignore lists between Users.
+type FollowRepository struct {
+	*DBConn
+}
+
+// NewFollowRepository instantiates a FollowRepository.
+func NewFollowRepository(db *DBConn) *FollowRepository {
+	return &FollowRepository{db}
+}
+
+// InsertFollow subscribes a User to another one, doing nothing if the subscription already exists.
+func (r *FollowRepository) InsertFollow(sess entity.Session, e *entity.UserFollow) error {
+	follow := dto.UserFollowToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.InsertBySql(
+			"INSERT INTO user_follows (user_id, followed_user_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			follow.UserID, follow.FollowedUserID,
+		).Exec()
+
+		return err
+	})
+}
+
+// DeleteFollow unsubscribes a User from another one.
+func (r *FollowRepository) DeleteFollow(sess entity.Session, e *entity.UserFollow) error {
+	follow := dto.UserFollowToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("user_follows").
+			Where("user_id = ? AND followed_user_id = ?", follow.UserID, follow.FollowedUserID).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectFollows returns the follows matching the filters, the newest first.
+func (r *FollowRepository) SelectFollows(sess entity.Session, f *entity.UserFollowFilters) ([]*entity.UserFollow, error) {
+	var follows []*dbmodel.UserFollow
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Select("user_id", "followed_user_id").
+			From("user_follows").
+			OrderDesc("created_at")
+
+		whereFilters(stmt, dto.UserFollowFiltersToDB(f))
+
+		_, err := stmt.Load(&follows)
+
+		return err
+	})
+
+	return dto.UserFollowsFromDB(follows), err
+}
+
+// InsertIgnore adds a User to the ignore list of another one, doing nothing if they are already there.
+func (r *FollowRepository) InsertIgnore(sess entity.Session, e *entity.UserIgnore) error {
+	ignore := dto.UserIgnoreToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.InsertBySql(
+			"INSERT INTO user_ignores (user_id, ignored_user_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			ignore.UserID, ignore.IgnoredUserID,
+		).Exec()
+
+		return err
+	})
+}
+
+// DeleteIgnore removes a User from the ignore list of another one.
+func (r *FollowRepository) DeleteIgnore(sess entity.Session, e *entity.UserIgnore) error {
+	ignore := dto.UserIgnoreToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("user_ignores").
+			Where("user_id = ? AND ignored_user_id = ?", ignore.UserID, ignore.IgnoredUserID).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectIgnoredIDs returns the IDs of the Users the User ignores.
+func (r *FollowRepository) SelectIgnoredIDs(sess entity.Session, userID int64) ([]int64, error) {
+	var ids []int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("ignored_user_id").
+			From("user_ignores").
+			Where("user_id = ?", userID).
+			Load(&ids)
+
+		return err
+	})
+
+	return ids, err
+}
@@ -4,8 +4,21 @@ import (
 	"simplestforum/internal/domain/entity"
 	"simplestforum/internal/dto"
 	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+
+	"github.com/gocraft/dbr"
 )
 
+// notificationGroupConflict merges a grouped notification into the unread one with the same key.
+const notificationGroupConflict = `
+	ON CONFLICT (user_id, group_key) WHERE read_at IS NULL DO UPDATE
+	SET seq        = NEXTVAL('notifications_seq'),
+	    count      = notifications.count + 1,
+	    actor_id   = EXCLUDED.actor_id,
+	    post_id    = EXCLUDED.post_id,
+	    text       = EXCLUDED.text,
+	    created_at = NOW()`
+
 // NotificationRepository represents a Section Repository.
 type NotificationRepository struct {
 	*DBConn
@@ -17,12 +30,23 @@ func NewNotificationRepository(db *DBConn) *NotificationRepository {
 }
 
 // Insert creates a new Notification entry in the database and returns a Notification object.
+// Grouped notifications are merged into the unread one with the same key, if any.
 func (r *NotificationRepository) Insert(sess entity.Session, e *entity.NotificationAdd) (*entity.Notification, error) {
 	notification := dto.NotificationAddToDB(e)
 
 	err := r.Wrap(sess, func(tx Gateway) error {
+		if notification.GroupKey != nil {
+			return tx.InsertBySql(`
+				INSERT INTO notifications (user_id, kind, actor_id, post_id, topic_id, section_id, text, group_key, group_text)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`+notificationGroupConflict+`
+				RETURNING id, seq, count, created_at`,
+				notification.UserID, notification.Kind, notification.ActorID, notification.PostID, notification.TopicID,
+				notification.SectionID, notification.Text, notification.GroupKey, notification.GroupText,
+			).Load(&notification)
+		}
+
 		stmt := tx.InsertInto("notifications").
-			Returning("id", "created_at")
+			Returning("id", "seq", "count", "created_at")
 
 		insertNotNil(stmt, notification)
 
@@ -32,6 +56,75 @@ func (r *NotificationRepository) Insert(sess entity.Session, e *entity.Notificat
 	return dto.NotificationFromDB(notification), err
 }
 
+// InsertForWatchers creates a Notification for every watcher of the Topic or its Section with a single statement,
+// and queues an email for those who wish to receive one. The author and the users who ignore the author are skipped.
+// The Notifications created or merged are returned.
+func (r *NotificationRepository) InsertForWatchers(sess entity.Session, e *entity.WatchersNotificationAdd) ([]*entity.Notification, error) {
+	var (
+		notification  = dto.NotificationAddToDB(e.Notification)
+		notifications []*dbmodel.Notification
+	)
+
+	// Recipients along with their preferences for the kind
+	recipients := dbr.Select("w.user_id", "COALESCE(p.in_app, TRUE) AS in_app",
+		dbr.Expr("COALESCE(p.email, ?) AS email", entity.NotificationEmailModeNone)).
+		From(dbr.Expr(`(
+			SELECT user_id FROM topic_watches WHERE topic_id = ?
+			UNION
+			SELECT user_id FROM section_watches WHERE section_id = ?
+		) w`, e.TopicID, e.SectionID)).
+		Join(dbr.I("users").As("u"), "u.id = w.user_id AND u.deleted_at IS NULL").
+		LeftJoin(dbr.I("notification_preferences").As("p"), dbr.Expr("p.user_id = w.user_id AND p.kind = ?", notification.Kind)).
+		Where(`w.user_id <> ? AND NOT EXISTS (
+			SELECT 1 FROM user_ignores i WHERE i.user_id = w.user_id AND i.ignored_user_id = ?
+		)`, e.AuthorID, e.AuthorID)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		err := tx.InsertBySql(`
+			INSERT INTO notifications (user_id, kind, actor_id, post_id, topic_id, section_id, text, group_key, group_text)
+			SELECT r.user_id, ?, ?, ?, ?, ?, ?, ?, ?
+			FROM (?) r
+			WHERE r.in_app`+notificationGroupConflict+`
+			RETURNING *`,
+			notification.Kind, notification.ActorID, notification.PostID, notification.TopicID, notification.SectionID,
+			notification.Text, notification.GroupKey, notification.GroupText, recipients,
+		).Load(&notifications)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.InsertBySql(`
+			INSERT INTO notification_emails (user_id, kind, mode, text)
+			SELECT r.user_id, ?, r.email, ?
+			FROM (?) r
+			WHERE r.email <> ?`,
+			notification.Kind, notification.Text, recipients, entity.NotificationEmailModeNone,
+		).Exec()
+
+		return err
+	})
+
+	return dto.NotificationsFromDB(notifications), err
+}
+
+// UpdateRead marks the unread Notifications of a User as read.
+func (r *NotificationRepository) UpdateRead(sess entity.Session, e *entity.NotificationMarkRead) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		conditions := []dbr.Builder{dbr.Eq("user_id", e.UserID), dbr.Eq("read_at", nil)}
+
+		if len(e.IDs) != 0 {
+			conditions = append(conditions, dbr.Eq("id", e.IDs))
+		}
+
+		_, err := tx.Update("notifications").
+			Set("read_at", time.Now()).
+			Where(dbr.And(conditions...)).
+			Exec()
+
+		return err
+	})
+}
+
 // DeleteByUserID removes existing Notifications (softly) by User ID.
 func (r *NotificationRepository) DeleteByUserID(sess entity.Session, userID int64) error {
 	return r.Wrap(sess, func(tx Gateway) error {
@@ -43,14 +136,15 @@ func (r *NotificationRepository) DeleteByUserID(sess entity.Session, userID int6
 	})
 }
 
-// SelectAllByUserID returns all Notifications attributed to the given user.
+// SelectAllByUserID returns all Notifications attributed to the given user, the latest first.
 func (r *NotificationRepository) SelectAllByUserID(sess entity.Session, p *entity.Pagination, userID int64) ([]*entity.Notification, error) {
 	var notifications []*dbmodel.Notification
 
 	err := r.Wrap(sess, func(tx Gateway) error {
 		stmt := tx.Select("*").
 			From("notifications").
-			Where("user_id = ?", userID)
+			Where("user_id = ?", userID).
+			OrderDesc("created_at")
 
 		if p != nil {
 			stmt.Paginate(uint64(p.Page), uint64(p.Limit))
@@ -63,3 +157,142 @@ func (r *NotificationRepository) SelectAllByUserID(sess entity.Session, p *entit
 
 	return dto.NotificationsFromDB(notifications), err
 }
+
+// SelectAfterByUserID returns up to limit Notifications of the given user created or merged into after the change
+// with the given number, in the order of their changes.
+func (r *NotificationRepository) SelectAfterByUserID(sess entity.Session, userID, afterSeq,
+	limit int64) ([]*entity.Notification, error) {
+	var notifications []*dbmodel.Notification
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("notifications").
+			Where("user_id = ? AND seq > ?", userID, afterSeq).
+			OrderAsc("seq").
+			Limit(uint64(limit)).
+			Load(&notifications)
+
+		return err
+	})
+
+	return dto.NotificationsFromDB(notifications), err
+}
+
+// CountUnreadByUserID returns the number of unread Notifications attributed to the given user.
+func (r *NotificationRepository) CountUnreadByUserID(sess entity.Session, userID int64) (int64, error) {
+	var count int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Select("COUNT(*)").
+			From("notifications").
+			Where("user_id = ? AND read_at IS NULL", userID).
+			LoadOne(&count)
+	})
+
+	return count, err
+}
+
+// SelectPreferences returns the Notification preferences a User has configured.
+func (r *NotificationRepository) SelectPreferences(sess entity.Session, userID int64) ([]*entity.NotificationPreference, error) {
+	var preferences []*dbmodel.NotificationPreference
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("notification_preferences").
+			Where("user_id = ?", userID).
+			Load(&preferences)
+
+		return err
+	})
+
+	return dto.NotificationPreferencesFromDB(preferences), err
+}
+
+// UpsertPreference creates or modifies the Notification preference of a User for a single kind.
+// The values which are not set are kept, or take the defaults for a new preference.
+func (r *NotificationRepository) UpsertPreference(sess entity.Session, e *entity.NotificationPreferenceSet) error {
+	defaults := entity.DefaultNotificationPreference(e.Kind)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.InsertBySql(`
+			INSERT INTO notification_preferences (user_id, kind, in_app, email)
+			VALUES (?, ?, COALESCE(?, ?), COALESCE(?, ?))
+			ON CONFLICT (user_id, kind) DO UPDATE
+			SET in_app = COALESCE(?, notification_preferences.in_app),
+			    email  = COALESCE(?, notification_preferences.email)`,
+			e.UserID, e.Kind, e.InApp, defaults.InApp, e.Email, defaults.Email, e.InApp, e.Email,
+		).Exec()
+
+		return err
+	})
+}
+
+// InsertEmail queues a Notification to be sent by email.
+func (r *NotificationRepository) InsertEmail(sess entity.Session, e *entity.NotificationAdd, mode entity.NotificationEmailMode) error {
+	email := dto.NotificationEmailToDB(e, mode)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("notification_emails")
+
+		insertNotNil(stmt, email)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// SelectPendingEmails returns the unsent emails of the given mode grouped by their recipients.
+// Only the users whose oldest pending email was queued before the given time are included.
+func (r *NotificationRepository) SelectPendingEmails(sess entity.Session, mode entity.NotificationEmailMode,
+	queuedBefore time.Time) ([]*entity.NotificationDigest, error) {
+	var emails []*dbmodel.NotificationEmailWithUser
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("e.*", "u.nickname", "ui.email AS user_email").
+			From(dbr.I("notification_emails").As("e")).
+			Join(dbr.I("users").As("u"), "u.id = e.user_id").
+			LeftJoin(dbr.I("users_info").As("ui"), "ui.user_id = e.user_id").
+			Where(`e.mode = ? AND e.sent_at IS NULL AND e.user_id IN (
+				SELECT user_id FROM notification_emails
+				WHERE mode = ? AND sent_at IS NULL
+				GROUP BY user_id
+				HAVING MIN(created_at) <= ?
+			)`, mode, mode, queuedBefore).
+			OrderAsc("e.user_id").
+			OrderAsc("e.id").
+			Load(&emails)
+
+		return err
+	})
+
+	return dto.NotificationDigestsFromDB(emails), err
+}
+
+// ClaimEmails marks the queued emails as sent and returns the IDs of the ones which were still pending,
+// so an email claimed by another sender is never sent twice.
+func (r *NotificationRepository) ClaimEmails(sess entity.Session, ids []int64) ([]int64, error) {
+	var claimed []int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Update("notification_emails").
+			Set("sent_at", time.Now()).
+			Where(dbr.And(dbr.Eq("id", ids), dbr.Eq("sent_at", nil))).
+			Returning("id").
+			Load(&claimed)
+	})
+
+	return claimed, err
+}
+
+// ReleaseEmails returns the claimed emails to the queue.
+func (r *NotificationRepository) ReleaseEmails(sess entity.Session, ids []int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("notification_emails").
+			Set("sent_at", nil).
+			Where(dbr.Eq("id", ids)).
+			Exec()
+
+		return err
+	})
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"sort"
+	"time"
+)
+
+// OutboxRepository represents an outbox Repository.
+type OutboxRepository struct {
+	*DBConn
+}
+
+// NewOutboxRepository instantiates an OutboxRepository.
+func NewOutboxRepository(db *DBConn) *OutboxRepository {
+	return &OutboxRepository{db}
+}
+
+// Insert adds a new message to the outbox.
+func (r *OutboxRepository) Insert(sess entity.Session, e *entity.OutboxMessageAdd) error {
+	message := dto.OutboxMessageAddToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("outbox")
+
+		insertNotNil(stmt, message)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// Claim returns up to limit pending messages which are due, the oldest first, and postpones them for the lease,
+// so the other dispatchers skip them while they are delivered.
+func (r *OutboxRepository) Claim(sess entity.Session, limit int64, lease time.Duration) ([]*entity.OutboxMessage, error) {
+	var messages []*dbmodel.OutboxMessage
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.UpdateBySql(`
+			UPDATE outbox SET next_attempt_at = NOW() + MAKE_INTERVAL(secs => ?)
+			WHERE id IN (
+				SELECT id FROM outbox
+				WHERE status = ? AND next_attempt_at <= NOW()
+				ORDER BY next_attempt_at, id
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *`,
+			lease.Seconds(), string(entity.OutboxStatusPending), limit,
+		).Load(&messages)
+	})
+
+	// RETURNING keeps no order
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+
+	return dto.OutboxMessagesFromDB(messages), err
+}
+
+// Update modifies an existing message.
+func (r *OutboxRepository) Update(sess entity.Session, e *entity.OutboxMessageEdit) error {
+	update, id := dto.OutboxMessageEditToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("outbox").
+			Where("id = ?", id)
+
+		updateNotNil(stmt, update)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// Delete removes a delivered message.
+func (r *OutboxRepository) Delete(sess entity.Session, id int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("outbox").
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectAll returns the messages matching the filters, the oldest first.
+func (r *OutboxRepository) SelectAll(sess entity.Session, f *entity.OutboxFilters, p *entity.Pagination) ([]*entity.OutboxMessage, error) {
+	var messages []*dbmodel.OutboxMessage
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Select("*").
+			From("outbox").
+			OrderAsc("id")
+
+		if f != nil {
+			whereFilters(stmt, dto.OutboxFiltersToDB(f))
+		}
+
+		if p != nil {
+			stmt.Paginate(uint64(p.Page), uint64(p.Limit))
+		}
+
+		_, err := stmt.Load(&messages)
+
+		return err
+	})
+
+	return dto.OutboxMessagesFromDB(messages), err
+}
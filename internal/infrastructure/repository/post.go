@@ -54,6 +54,37 @@ func (r *PostRepository) Update(sess entity.Session, e *entity.PostEdit) error {
 	})
 }
 
+// InsertEdit attributes an edit of the text of the Post to the User.
+func (r *PostRepository) InsertEdit(sess entity.Session, postID, userID int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.InsertInto("post_edits").
+			Pair("post_id", postID).
+			Pair("user_id", userID).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectContributors returns the Users who have edited the Posts with the numbers of their edits,
+// the latest contributor first.
+func (r *PostRepository) SelectContributors(sess entity.Session, postIDs []int64) ([]*entity.PostContributor, error) {
+	var contributors []*dbmodel.PostContributor
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("post_id", "user_id", "COUNT(*) AS count_edits", "MAX(edited_at) AS last_edited_at").
+			From("post_edits").
+			Where(dbr.Eq("post_id", postIDs)).
+			GroupBy("post_id", "user_id").
+			OrderDesc("last_edited_at").
+			Load(&contributors)
+
+		return err
+	})
+
+	return dto.PostContributorsFromDB(contributors), err
+}
+
 // Delete removes existing Posts (softly).
 func (r *PostRepository) Delete(sess entity.Session, ids ...int64) error {
 	return r.Wrap(sess, func(tx Gateway) error {
@@ -87,7 +118,7 @@ func (r *PostRepository) SelectAll(sess entity.Session, f *entity.PostFilters, p
 	err := r.Wrap(sess, func(tx Gateway) error {
 		stmt := tx.Select("*").
 			From("posts")
-		conditions := []dbr.Builder{dbr.Eq("deleted_at", nil)}
+		conditions := []dbr.Builder{deletedCondition(f != nil && f.Deleted, false)}
 
 		if f != nil {
 			df := dto.PostFiltersToDB(f)
@@ -95,6 +126,10 @@ func (r *PostRepository) SelectAll(sess entity.Session, f *entity.PostFilters, p
 			conditions = append(conditions, applyFilters(df)...)
 		}
 
+		if pending := pendingCondition(f); pending != nil {
+			conditions = append(conditions, pending)
+		}
+
 		if p != nil {
 			stmt.Paginate(uint64(p.Page), uint64(p.Limit))
 		}
@@ -111,6 +146,19 @@ func (r *PostRepository) SelectAll(sess entity.Session, f *entity.PostFilters, p
 
 	return dto.PostsFromDB(posts), err
 }
+
+// UpdateStatus changes the moderation status of the Post.
+func (r *PostRepository) UpdateStatus(sess entity.Session, id int64, status entity.PostStatus) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("posts").
+			Set("status", string(status)).
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
 func (r *PostRepository) IDsToDelete(sess entity.Session, e *entity.PostDelete) ([]int64, error) {
 	var ids []int64
 
@@ -134,3 +182,149 @@ func (r *PostRepository) IDsToDelete(sess entity.Session, e *entity.PostDelete)
 
 	return ids, err
 }
+
+// UpdateTopic moves the Posts into another Topic. The creation dates are kept, so the order of the Posts is preserved.
+func (r *PostRepository) UpdateTopic(sess entity.Session, e *entity.PostMove) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("posts").
+			Set("topic_id", e.ToTopicID).
+			Set("updated_at", time.Now())
+
+		if e.FromTopicID != nil {
+			stmt.Where("topic_id = ?", *e.FromTopicID)
+		} else {
+			stmt.Where(dbr.Eq("id", e.IDs))
+		}
+
+		_, err := stmt.Where(dbr.Eq("deleted_at", nil)).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectAuthorCounts returns the number of Posts matching the filters per author.
+func (r *PostRepository) SelectAuthorCounts(sess entity.Session, f *entity.PostFilters) ([]*entity.PostAuthorCount, error) {
+	var authors []*dbmodel.PostAuthorCount
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		conditions := append([]dbr.Builder{dbr.Eq("deleted_at", nil)}, applyFilters(dto.PostFiltersToDB(f))...)
+
+		_, err := tx.Select("user_id", "COUNT(*) AS count").
+			From("posts").
+			Where(dbr.And(conditions...)).
+			GroupBy("user_id").
+			OrderAsc("user_id").
+			Load(&authors)
+
+		return err
+	})
+
+	return dto.PostAuthorCountsFromDB(authors), err
+}
+
+// Restore brings back deleted Posts.
+func (r *PostRepository) Restore(sess entity.Session, ids ...int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("posts").
+			Set("deleted_at", nil).
+			Where(dbr.Eq("id", ids)).
+			Exec()
+
+		return err
+	})
+}
+
+// RestoreByTopic brings back the Posts of the Topic deleted at or after the given time.
+func (r *PostRepository) RestoreByTopic(sess entity.Session, topicID int64, deletedSince time.Time) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("posts").
+			Set("deleted_at", nil).
+			Where("topic_id = ? AND deleted_at >= ?", topicID, deletedSince).
+			Exec()
+
+		return err
+	})
+}
+
+// UpdateCounters recomputes the number of Posts of the selected Topics and Users.
+// Deleted and pending Posts are not counted.
+func (r *PostRepository) UpdateCounters(sess entity.Session, e *entity.PostCounters) error {
+	var (
+		topics = dbr.Or(
+			dbr.Eq("id", e.TopicIDs),
+			dbr.Eq("section_id", e.SectionIDs),
+			dbr.Eq("user_id", e.TopicUserIDs),
+		)
+		users = dbr.Expr(
+			"? OR id IN (SELECT user_id FROM posts WHERE ? OR topic_id IN (SELECT id FROM topics WHERE ?))",
+			dbr.Eq("id", e.UserIDs), dbr.Eq("id", e.PostIDs), topics,
+		)
+	)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("topics").
+			Set("count_posts", dbr.Expr(
+				"(SELECT COUNT(*) FROM posts p WHERE p.topic_id = topics.id AND p.deleted_at IS NULL AND p.status = 'PUBLISHED')",
+			)).
+			Where("? OR id IN (SELECT topic_id FROM posts WHERE ?)", topics, dbr.Eq("id", e.PostIDs)).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Update("users").
+			Set("count_posts", dbr.Expr(
+				"(SELECT COUNT(*) FROM posts p WHERE p.user_id = users.id AND p.deleted_at IS NULL AND p.status = 'PUBLISHED')",
+			)).
+			Where(users).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		// The ranks on the ladder follow the new counts, so they go down along with them as well
+		_, err = tx.Update("users").
+			Set("rank", dbr.Expr(userRankExpr)).
+			Where(users).
+			Where(userRankInLadderExpr).
+			Exec()
+
+		return err
+	})
+}
+
+// Purge removes the Posts deleted before the given time for good and returns their number.
+func (r *PostRepository) Purge(sess entity.Session, deletedBefore time.Time) (int64, error) {
+	var count int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		res, err := tx.DeleteFrom("posts").
+			Where("deleted_at < ?", deletedBefore).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+
+		return err
+	})
+
+	return count, err
+}
+
+// pendingCondition returns the condition which selects the pending Posts, the published ones, or both,
+// or nil if no condition is needed.
+func pendingCondition(f *entity.PostFilters) dbr.Builder {
+	switch {
+	case f != nil && f.Pending:
+		return dbr.Eq("status", string(entity.PostStatusPending))
+	case f != nil && f.IncludePending:
+		return nil
+	case f != nil && f.PendingAuthorID != 0:
+		return dbr.Or(dbr.Eq("status", string(entity.PostStatusPublished)), dbr.Eq("user_id", f.PendingAuthorID))
+	}
+
+	return dbr.Eq("status", string(entity.PostStatusPublished))
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+
+	"github.com/gocraft/dbr"
+)
+
+// ProfileRepository represents a custom profile field Repository.
+type ProfileRepository struct {
+	*DBConn
+}
+
+// NewProfileRepository instantiates a ProfileRepository.
+func NewProfileRepository(db *DBConn) *ProfileRepository {
+	return &ProfileRepository{db}
+}
+
+// InsertField creates a new ProfileField entry in the database and returns its ID.
+func (r *ProfileRepository) InsertField(sess entity.Session, e *entity.ProfileFieldAdd) (int64, error) {
+	var fieldID int64
+
+	field := dto.ProfileFieldAddToDB(e)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("profile_fields").
+			Returning("id")
+
+		insertNotNil(stmt, field)
+
+		return stmt.Load(&fieldID)
+	})
+
+	return fieldID, err
+}
+
+// UpdateField modifies an existing ProfileField entry.
+func (r *ProfileRepository) UpdateField(sess entity.Session, e *entity.ProfileFieldEdit) error {
+	fieldUpdate, id := dto.ProfileFieldEditToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("profile_fields").
+			Where("id = ?", id).
+			Set("updated_at", time.Now())
+
+		updateNotNil(stmt, fieldUpdate)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// DeleteField removes an existing ProfileField along with the values Users gave to it.
+func (r *ProfileRepository) DeleteField(sess entity.Session, id int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("profile_fields").
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectFieldByID returns a ProfileField by its ID.
+func (r *ProfileRepository) SelectFieldByID(sess entity.Session, id int64) (*entity.ProfileField, error) {
+	var field *dbmodel.ProfileField
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Select("*").
+			From("profile_fields").
+			Where("id = ?", id).
+			LoadOne(&field)
+	})
+
+	return dto.ProfileFieldFromDB(field), err
+}
+
+// SelectFields returns all ProfileFields in the order they are shown in.
+func (r *ProfileRepository) SelectFields(sess entity.Session) ([]*entity.ProfileField, error) {
+	var fields []*dbmodel.ProfileField
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("profile_fields").
+			OrderAsc("position").
+			OrderAsc("id").
+			Load(&fields)
+
+		return err
+	})
+
+	return dto.ProfileFieldsFromDB(fields), err
+}
+
+// UpsertValues gives the values to the profile fields of the User, replacing the ones given before.
+func (r *ProfileRepository) UpsertValues(sess entity.Session, e []*entity.ProfileFieldValue) error {
+	values := dto.ProfileFieldValuesToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		for _, value := range values {
+			_, err := tx.InsertBySql(`
+				INSERT INTO user_profile_values (user_id, field_id, value)
+				VALUES (?, ?, ?)
+				ON CONFLICT (user_id, field_id) DO UPDATE
+				SET value = EXCLUDED.value`,
+				value.UserID, value.FieldID, value.Value,
+			).Exec()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteValues removes the values of the profile fields of the User.
+func (r *ProfileRepository) DeleteValues(sess entity.Session, userID int64, fieldIDs []int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("user_profile_values").
+			Where("user_id = ?", userID).
+			Where(dbr.Eq("field_id", fieldIDs)).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectValues returns the values of the profile fields of the Users.
+func (r *ProfileRepository) SelectValues(sess entity.Session, userIDs []int64) ([]*entity.ProfileFieldValue, error) {
+	var values []*dbmodel.ProfileFieldValue
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("user_profile_values").
+			Where(dbr.Eq("user_id", userIDs)).
+			Load(&values)
+
+		return err
+	})
+
+	return dto.ProfileFieldValuesFromDB(values), err
+}
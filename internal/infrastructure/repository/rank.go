@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+
+	"github.com/gocraft/dbr"
+)
+
+const (
+	// userRankExpr computes the rank of a User out of the posts count: the number of the ladder steps reached.
+	userRankExpr = "GREATEST(1, (SELECT COUNT(*) FROM ranks r WHERE r.min_posts <= users.count_posts))"
+
+	// userRankInLadderExpr selects the Users whose rank is on the ladder, the ones above it are given by the admins.
+	userRankInLadderExpr = "users.rank <= (SELECT COUNT(*) FROM ranks)"
+)
+
+// RankRepository represents a rank ladder Repository.
+type RankRepository struct {
+	*DBConn
+}
+
+// NewRankRepository instantiates a RankRepository.
+func NewRankRepository(db *DBConn) *RankRepository {
+	return &RankRepository{db}
+}
+
+// Insert creates a new Rank entry in the database and returns its ID.
+func (r *RankRepository) Insert(sess entity.Session, e *entity.RankAdd) (int64, error) {
+	var rankID int64
+
+	rank := dto.RankAddToDB(e)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("ranks").
+			Returning("id")
+
+		insertNotNil(stmt, rank)
+
+		return stmt.Load(&rankID)
+	})
+
+	return rankID, err
+}
+
+// Update modifies an existing Rank entry.
+func (r *RankRepository) Update(sess entity.Session, e *entity.RankEdit) error {
+	rankUpdate, id := dto.RankEditToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("ranks").
+			Where("id = ?", id).
+			Set("updated_at", time.Now())
+
+		updateNotNil(stmt, rankUpdate)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// Delete removes an existing Rank entry.
+func (r *RankRepository) Delete(sess entity.Session, id int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("ranks").
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectAll returns all Ranks ordered by the number of posts needed to reach them.
+func (r *RankRepository) SelectAll(sess entity.Session) ([]*entity.Rank, error) {
+	var ranks []*dbmodel.Rank
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("*").
+			From("ranks").
+			OrderAsc("min_posts").
+			Load(&ranks)
+
+		return err
+	})
+
+	return dto.RanksFromDB(ranks), err
+}
+
+// UpdateUserRanks recomputes the ranks of the given Users, or of everyone if none are given,
+// and returns the number of Users whose rank has changed. The ranks above maxRank are kept.
+func (r *RankRepository) UpdateUserRanks(sess entity.Session, userIDs []int64, maxRank int64) (int64, error) {
+	var count int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("users").
+			Set("rank", dbr.Expr(userRankExpr)).
+			Where("rank <> "+userRankExpr).
+			Where("rank <= ?", maxRank)
+
+		if len(userIDs) > 0 {
+			stmt.Where(dbr.Eq("id", userIDs))
+		}
+
+		res, err := stmt.Exec()
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+
+		return err
+	})
+
+	return count, err
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+)
+
+// RateLimitRepository represents a token bucket Repository.
+type RateLimitRepository struct {
+	*DBConn
+}
+
+// NewRateLimitRepository instantiates a RateLimitRepository.
+func NewRateLimitRepository(db *DBConn) *RateLimitRepository {
+	return &RateLimitRepository{db}
+}
+
+// Take spends a token of the bucket and returns zero, or the time until the next token if none is left.
+// The bucket is locked in its own short transaction rather than in the one of the request.
+func (r *RateLimitRepository) Take(sess entity.Session, e *entity.RateLimitTake) (time.Duration, error) {
+	tx, err := r.NewTransaction(sess.Ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.RollbackUnlessCommitted()
+
+	sess.Transaction = tx
+
+	var retryAfter time.Duration
+
+	err = r.Wrap(sess, func(tx Gateway) error {
+		// Creating a full bucket first, so there is always a row to lock
+		_, err := tx.InsertBySql(
+			"INSERT INTO rate_limit_buckets (key, tokens, updated_at) VALUES (?, ?, ?) ON CONFLICT (key) DO NOTHING",
+			e.Key, float64(e.Quota.Burst), e.Now,
+		).Exec()
+		if err != nil {
+			return err
+		}
+
+		var stored *dbmodel.RateLimitBucket
+
+		err = tx.SelectBySql("SELECT * FROM rate_limit_buckets WHERE key = ? FOR UPDATE", e.Key).
+			LoadOne(&stored)
+		if err != nil {
+			return err
+		}
+
+		var bucket *entity.RateLimitBucket
+
+		bucket, retryAfter = e.Quota.Take(dto.RateLimitBucketFromDB(stored), e.Now)
+		if retryAfter > 0 {
+			return nil
+		}
+
+		_, err = tx.Update("rate_limit_buckets").
+			Set("tokens", bucket.Tokens).
+			Set("updated_at", bucket.UpdatedAt).
+			Where("key = ?", e.Key).
+			Exec()
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return retryAfter, tx.Commit()
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+)
+
+// ReportRepository represents a Report Repository.
+type ReportRepository struct {
+	*DBConn
+}
+
+// NewReportRepository instantiates a ReportRepository.
+func NewReportRepository(db *DBConn) *ReportRepository {
+	return &ReportRepository{db}
+}
+
+// Upsert files a new Report and returns its ID. If the reporter already has an open Report about the same target,
+// its reason and text are replaced instead.
+func (r *ReportRepository) Upsert(sess entity.Session, e *entity.ReportAdd) (int64, error) {
+	var reportID int64
+
+	report := dto.ReportAddToDB(e)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.InsertBySql(`
+			INSERT INTO reports (reporter_id, target_type, target_id, target_user_id, reason, text)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (reporter_id, target_type, target_id) WHERE status = 'OPEN' DO UPDATE
+			SET reason = EXCLUDED.reason, text = EXCLUDED.text, updated_at = NOW()
+			RETURNING id`,
+			report.ReporterID, report.TargetType, report.TargetID, report.TargetUserID, report.Reason, report.Text,
+		).Load(&reportID)
+	})
+
+	return reportID, err
+}
+
+// UpdateAssignee assigns the Report to a moderator or unassigns it.
+func (r *ReportRepository) UpdateAssignee(sess entity.Session, e *entity.ReportAssign) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("reports").
+			Set("assignee_id", e.AssigneeID).
+			Set("updated_at", time.Now()).
+			Where("id = ?", e.ID).
+			Exec()
+
+		return err
+	})
+}
+
+// Resolve closes the Report with the given status.
+func (r *ReportRepository) Resolve(sess entity.Session, e *entity.ReportResolve) error {
+	reportResolve, id := dto.ReportResolveToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("reports").
+			Where("id = ?", id).
+			Set("resolved_at", time.Now()).
+			Set("updated_at", time.Now())
+
+		updateNotNil(stmt, reportResolve)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// SelectByID returns a Report by its ID.
+func (r *ReportRepository) SelectByID(sess entity.Session, id int64) (*entity.Report, error) {
+	var report *dbmodel.Report
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Select("*").
+			From("reports").
+			Where("id = ?", id).
+			LoadOne(&report)
+	})
+
+	return dto.ReportFromDB(report), err
+}
+
+// SelectAll returns all Reports, the oldest first.
+func (r *ReportRepository) SelectAll(sess entity.Session, f *entity.ReportFilters, p *entity.Pagination) ([]*entity.Report, error) {
+	var reports []*dbmodel.Report
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Select("*").
+			From("reports").
+			OrderAsc("created_at")
+
+		if f != nil {
+			whereFilters(stmt, dto.ReportFiltersToDB(f))
+		}
+
+		if p != nil {
+			stmt.Paginate(uint64(p.Page), uint64(p.Limit))
+		}
+
+		_, err := stmt.Load(&reports)
+
+		return err
+	})
+
+	return dto.ReportsFromDB(reports), err
+}
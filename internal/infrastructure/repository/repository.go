@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gocraft/dbr"
+	"github.com/lib/pq"
 )
 
 // Gateway is an interface which contains basic functions to interact with the database.
@@ -61,6 +62,17 @@ func NewRepository(db *dbr.Connection) *service.Storages {
 		Topic:        NewTopicRepository(base),
 		Post:         NewPostRepository(base),
 		Notification: NewNotificationRepository(base),
+		Watch:        NewWatchRepository(base),
+		Report:       NewReportRepository(base),
+		Filter:       NewFilterRepository(base),
+		Search:       NewSearchRepository(base),
+		Profile:      NewProfileRepository(base),
+		Follow:       NewFollowRepository(base),
+		Rank:         NewRankRepository(base),
+		Badge:        NewBadgeRepository(base),
+		Audit:        NewAuditRepository(base),
+		Outbox:       NewOutboxRepository(base),
+		Webhook:      NewWebhookRepository(base),
 	}
 }
 
@@ -73,15 +85,15 @@ func insertNotNil(stmt *dbr.InsertStmt, insertStruct interface{}) {
 			return
 		}
 
-		// If there is `insert:"false"`, skip
+		// If there is `insert:"false"` or the slice is not set, skip
 		insert, ok := field.Tag.Lookup("insert")
-		if ok && insert == "false" {
+		if ok && insert == "false" || isNilSlice(value) {
 			return
 		}
 
 		// Otherwise add to the InsertStmt if the value is of a known type
 		switch v := value.Interface().(type) {
-		case string, int64, bool, time.Time, *string, *int64, *bool, *time.Time:
+		case string, int64, bool, time.Time, *string, *int64, *bool, *time.Time, pq.StringArray, pq.Int64Array:
 			stmt.Pair(column, v)
 		}
 	})
@@ -90,9 +102,9 @@ func insertNotNil(stmt *dbr.InsertStmt, insertStruct interface{}) {
 // updateNotNil iterates over all fields of updateStruct and adds non-nil values to the UpdateStmt.
 func updateNotNil(stmt *dbr.UpdateStmt, updateStruct interface{}) {
 	helpers.ProcessExportedNonEmptyFields(updateStruct, func(value reflect.Value, field reflect.StructField) {
-		// If there is no `db` tag, skip
+		// If there is no `db` tag or the slice is not set, skip
 		column, ok := field.Tag.Lookup("db")
-		if !ok {
+		if !ok || isNilSlice(value) {
 			return
 		}
 
@@ -101,6 +113,18 @@ func updateNotNil(stmt *dbr.UpdateStmt, updateStruct interface{}) {
 	})
 }
 
+// deletedCondition returns the condition which selects either the existing rows, the deleted ones, or both.
+func deletedCondition(onlyDeleted, includeDeleted bool) dbr.Builder {
+	switch {
+	case onlyDeleted:
+		return dbr.Neq("deleted_at", nil)
+	case includeDeleted:
+		return dbr.Expr("TRUE")
+	}
+
+	return dbr.Eq("deleted_at", nil)
+}
+
 // applyFilters applies relevant filters from df.
 func applyFilters(df interface{}) []dbr.Builder {
 	var res []dbr.Builder
@@ -112,9 +136,9 @@ func applyFilters(df interface{}) []dbr.Builder {
 			return
 		}
 
-		// If there is no `sign` tag, skip
+		// If there is no `sign` tag or the slice is not set, skip. An empty slice matches nothing
 		sign, ok := field.Tag.Lookup("sign")
-		if !ok {
+		if !ok || isNilSlice(value) {
 			return
 		}
 
@@ -144,3 +168,16 @@ func applyFilters(df interface{}) []dbr.Builder {
 
 	return res
 }
+
+// whereFilters restricts the SelectStmt with the filters from df, if any of them are set.
+func whereFilters(stmt *dbr.SelectStmt, df interface{}) {
+	conditions := applyFilters(df)
+	if len(conditions) > 0 {
+		stmt.Where(dbr.And(conditions...))
+	}
+}
+
+// isNilSlice checks if the value is a slice which is not set, unlike an empty one.
+func isNilSlice(value reflect.Value) bool {
+	return value.Kind() == reflect.Slice && value.IsNil()
+}
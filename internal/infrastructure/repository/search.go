@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"html"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"strings"
+
+	"github.com/gocraft/dbr"
+)
+
+// searchMarkStart and searchMarkStop are the private use characters which surround the matched words in the
+// snippets made by ts_headline. They are removed from the text beforehand, so only the marks remain once the
+// snippet is escaped as HTML, and they are turned into the highlight tags.
+const (
+	searchMarkStart = "\uE000"
+	searchMarkStop  = "\uE001"
+)
+
+// searchHeadlineOptions are the ts_headline options of the snippets.
+const searchHeadlineOptions = "StartSel=" + searchMarkStart + ", StopSel=" + searchMarkStop +
+	", MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=\" ... \""
+
+// searchHighlighter turns the marks into the highlight tags.
+var searchHighlighter = strings.NewReplacer(searchMarkStart, entity.SearchHighlightStart,
+	searchMarkStop, entity.SearchHighlightStop)
+
+// SearchRepository represents a full-text search Repository.
+type SearchRepository struct {
+	*DBConn
+}
+
+// NewSearchRepository instantiates a SearchRepository.
+func NewSearchRepository(db *DBConn) *SearchRepository {
+	return &SearchRepository{db}
+}
+
+// Search returns the Posts and Topics matching the query, the best ranked first.
+// The query is written in the web search syntax: "quoted phrases", OR and -excluded words.
+func (r *SearchRepository) Search(sess entity.Session, e *entity.SearchQuery, p *entity.Pagination) ([]*entity.SearchHit, error) {
+	var hits []*dbmodel.SearchHit
+
+	query := dbr.Expr("websearch_to_tsquery('simple', ?)", e.Query)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		posts := dbr.Select("'POST' AS kind", "p.id", "p.topic_id", "t.section_id", "p.user_id", "p.text", "p.created_at",
+			dbr.Expr("ts_rank(p.search_vector, ?) AS rank", query)).
+			From(dbr.I("posts").As("p")).
+			Join(dbr.I("topics").As("t"), "t.id = p.topic_id").
+			Where(dbr.Expr("p.search_vector @@ ?", query)).
+			Where(dbr.And(searchPostConditions(e)...))
+
+		topics := dbr.Select("'TOPIC' AS kind", "t.id", "t.id AS topic_id", "t.section_id", "t.user_id", "t.name AS text",
+			"t.created_at", dbr.Expr("ts_rank(t.search_vector, ?) AS rank", query)).
+			From(dbr.I("topics").As("t")).
+			Where(dbr.Expr("t.search_vector @@ ?", query)).
+			Where(dbr.And(searchTopicConditions(e)...))
+
+		// Ranking everything first, then highlighting the page of hits only
+		page := dbr.Select("*").
+			From(dbr.UnionAll(posts, topics).As("matches")).
+			OrderDesc("rank").
+			OrderDesc("created_at").
+			Paginate(uint64(p.Page), uint64(p.Limit))
+
+		_, err := tx.SelectBySql(`
+			SELECT kind, id, topic_id, section_id, user_id, rank, created_at,
+			       ts_headline('simple', TRANSLATE(text, ?, ''), ?, ?) AS snippet
+			FROM ?
+			ORDER BY rank DESC, created_at DESC`,
+			searchMarkStart+searchMarkStop, query, searchHeadlineOptions, page.As("hits"),
+		).Load(&hits)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The text is not HTML, so it's escaped except for the highlight tags
+	for _, hit := range hits {
+		hit.Snippet = searchHighlighter.Replace(html.EscapeString(hit.Snippet))
+	}
+
+	return dto.SearchHitsFromDB(hits), nil
+}
+
+// SelectDocuments returns every searchable Post and Topic matching the query filters, the text is not matched.
+func (r *SearchRepository) SelectDocuments(sess entity.Session, e *entity.SearchQuery) ([]*entity.SearchDocument, error) {
+	var documents []*dbmodel.SearchDocument
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		posts := dbr.Select("'POST' AS kind", "p.id", "p.topic_id", "t.section_id", "p.user_id", "p.text", "p.created_at").
+			From(dbr.I("posts").As("p")).
+			Join(dbr.I("topics").As("t"), "t.id = p.topic_id").
+			Where(dbr.And(searchPostConditions(e)...))
+
+		topics := dbr.Select("'TOPIC' AS kind", "t.id", "t.id AS topic_id", "t.section_id", "t.user_id", "t.name AS text",
+			"t.created_at").
+			From(dbr.I("topics").As("t")).
+			Where(dbr.And(searchTopicConditions(e)...))
+
+		_, err := tx.Select("*").
+			From(dbr.UnionAll(posts, topics).As("documents")).
+			Load(&documents)
+
+		return err
+	})
+
+	return dto.SearchDocumentsFromDB(documents), err
+}
+
+// searchPostConditions returns the conditions selecting the searchable Posts, aliased as p, of the Topics, aliased as t.
+func searchPostConditions(e *entity.SearchQuery) []dbr.Builder {
+	conditions := []dbr.Builder{
+		dbr.Eq("p.deleted_at", nil),
+		dbr.Eq("p.status", string(entity.PostStatusPublished)),
+		dbr.Eq("t.deleted_at", nil),
+	}
+
+	if len(e.SectionIDs) > 0 {
+		conditions = append(conditions, dbr.Eq("t.section_id", e.SectionIDs))
+	}
+
+	if len(e.UserIDs) > 0 {
+		conditions = append(conditions, dbr.Eq("p.user_id", e.UserIDs))
+	}
+
+	return append(conditions, searchDateConditions("p", e)...)
+}
+
+// searchTopicConditions returns the conditions selecting the searchable Topics, aliased as t.
+// The Topics left behind by merges only redirect to the others, so they are skipped.
+func searchTopicConditions(e *entity.SearchQuery) []dbr.Builder {
+	conditions := []dbr.Builder{
+		dbr.Eq("t.deleted_at", nil),
+		dbr.Eq("t.redirect_topic_id", nil),
+	}
+
+	if len(e.SectionIDs) > 0 {
+		conditions = append(conditions, dbr.Eq("t.section_id", e.SectionIDs))
+	}
+
+	if len(e.UserIDs) > 0 {
+		conditions = append(conditions, dbr.Eq("t.user_id", e.UserIDs))
+	}
+
+	return append(conditions, searchDateConditions("t", e)...)
+}
+
+// searchDateConditions returns the conditions on the creation time of the table with the alias.
+func searchDateConditions(alias string, e *entity.SearchQuery) []dbr.Builder {
+	var conditions []dbr.Builder
+
+	if e.DateFrom != nil {
+		conditions = append(conditions, dbr.Gte(alias+".created_at", *e.DateFrom))
+	}
+
+	if e.DateTo != nil {
+		conditions = append(conditions, dbr.Lte(alias+".created_at", *e.DateTo))
+	}
+
+	return conditions
+}
@@ -9,6 +9,12 @@ import (
 	"github.com/gocraft/dbr"
 )
 
+// acceptedAnswerCondition is met by the Topics whose accepted answer is still a published Post of theirs.
+const acceptedAnswerCondition = `EXISTS (
+	SELECT 1 FROM posts p
+	WHERE p.id = topics.accepted_post_id AND p.topic_id = topics.id AND p.deleted_at IS NULL AND p.status = 'PUBLISHED'
+)`
+
 // TopicRepository represents a Topic Repository.
 type TopicRepository struct {
 	*DBConn
@@ -87,12 +93,33 @@ func (r *TopicRepository) SelectAll(sess entity.Session, f *entity.TopicFilters,
 	err := r.Wrap(sess, func(tx Gateway) error {
 		stmt := tx.Select("*").
 			From("topics")
-		conditions := []dbr.Builder{dbr.Eq("deleted_at", nil)}
+		conditions := []dbr.Builder{deletedCondition(f != nil && f.Deleted, f != nil && f.IncludeDeleted)}
 
 		if f != nil {
 			df := dto.TopicFiltersToDB(f)
 
 			conditions = append(conditions, applyFilters(df)...)
+
+			// Only the topics with posts newer than the last read one
+			if f.UnreadOnly {
+				conditions = append(conditions, dbr.Expr(`EXISTS (
+					SELECT 1 FROM posts p
+					LEFT JOIN topic_reads r ON r.topic_id = p.topic_id AND r.user_id = ?
+					WHERE p.topic_id = topics.id AND ?
+				)`, sess.UserID, unreadPostCondition(sess.UserID)))
+			}
+
+			// Only the questions with or without an accepted answer which is still there
+			if f.Answered != nil {
+				if *f.Answered {
+					conditions = append(conditions, dbr.Expr(acceptedAnswerCondition))
+				} else {
+					conditions = append(conditions, dbr.Expr(
+						"NOT "+acceptedAnswerCondition+" AND section_id IN (SELECT id FROM sections WHERE mode = ?)",
+						entity.SectionModeQA,
+					))
+				}
+			}
 		}
 
 		if p != nil {
@@ -111,6 +138,162 @@ func (r *TopicRepository) SelectAll(sess entity.Session, f *entity.TopicFilters,
 
 	return dto.TopicsFromDB(topics), err
 }
+
+// Restore brings back a deleted Topic.
+func (r *TopicRepository) Restore(sess entity.Session, id int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("topics").
+			Set("deleted_at", nil).
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// Purge removes the Topics deleted before the given time for good and returns their number.
+// The Topics which still have any Posts, deleted or not, are kept until the Posts are gone.
+func (r *TopicRepository) Purge(sess entity.Session, deletedBefore time.Time) (int64, error) {
+	var count int64
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		res, err := tx.DeleteFrom("topics").
+			Where("deleted_at < ? AND NOT EXISTS (SELECT 1 FROM posts p WHERE p.topic_id = topics.id)", deletedBefore).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+
+		return err
+	})
+
+	return count, err
+}
+
+// UpdateRedirect turns the Topic into a stub which redirects to another one.
+func (r *TopicRepository) UpdateRedirect(sess entity.Session, id, redirectTopicID int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("topics").
+			Set("redirect_topic_id", redirectTopicID).
+			Set("updated_at", time.Now()).
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// UpdateAcceptedPost sets the accepted answer of the Topic, nil withdraws it.
+func (r *TopicRepository) UpdateAcceptedPost(sess entity.Session, id int64, postID *int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("topics").
+			Set("accepted_post_id", postID).
+			Set("updated_at", time.Now()).
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// WithdrawAcceptedPosts withdraws the accepted answers which are among the given Posts and returns them.
+func (r *TopicRepository) WithdrawAcceptedPosts(sess entity.Session, postIDs []int64) ([]*entity.TopicAnswerWithdrawal, error) {
+	var withdrawals []*dbmodel.TopicAnswerWithdrawal
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.UpdateBySql(`
+			UPDATE topics t SET accepted_post_id = NULL, updated_at = NOW()
+			FROM posts p
+			WHERE p.id = t.accepted_post_id AND ?
+			RETURNING t.id AS topic_id, t.user_id AS topic_user_id, p.user_id AS answer_user_id`,
+			dbr.Eq("p.id", postIDs),
+		).Load(&withdrawals)
+	})
+
+	return dto.TopicAnswerWithdrawalsFromDB(withdrawals), err
+}
+
+// UpdateCounters recomputes the number of Posts of the selected Topics, and the number of Topics of the selected
+// Sections and Users. Deleted Topics and deleted or pending Posts are not counted, neither are redirect stubs
+// in the Sections.
+func (r *TopicRepository) UpdateCounters(sess entity.Session, e *entity.TopicCounters) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("topics").
+			Set("count_posts", dbr.Expr(
+				"(SELECT COUNT(*) FROM posts p WHERE p.topic_id = topics.id AND p.deleted_at IS NULL AND p.status = 'PUBLISHED')",
+			)).
+			Where(dbr.Eq("id", e.TopicIDs)).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Update("sections").
+			Set("count_topics", dbr.Expr(`(
+				SELECT COUNT(*) FROM topics t
+				WHERE t.section_id = sections.id AND t.deleted_at IS NULL AND t.redirect_topic_id IS NULL
+			)`)).
+			Where("? OR id IN (SELECT section_id FROM topics WHERE ? OR ?)",
+				dbr.Eq("id", e.SectionIDs), dbr.Eq("id", e.TopicIDs), dbr.Eq("user_id", e.UserIDs)).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Update("users").
+			Set("count_topics", dbr.Expr(
+				"(SELECT COUNT(*) FROM topics t WHERE t.user_id = users.id AND t.deleted_at IS NULL)",
+			)).
+			Where("? OR id IN (SELECT user_id FROM topics WHERE ? OR ?)",
+				dbr.Eq("id", e.UserIDs), dbr.Eq("id", e.TopicIDs), dbr.Eq("section_id", e.SectionIDs)).
+			Exec()
+
+		return err
+	})
+}
+
+// UpsertReads moves the last read post of the User to the latest post of every given topic and of every topic in the
+// given sections.
+func (r *TopicRepository) UpsertReads(sess entity.Session, e *entity.TopicMarkRead) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.InsertBySql(`
+			INSERT INTO topic_reads (user_id, topic_id, last_read_post_id)
+			SELECT ?, p.topic_id, MAX(p.id)
+			FROM posts p
+			JOIN topics t ON t.id = p.topic_id
+			WHERE p.deleted_at IS NULL AND p.status = 'PUBLISHED' AND t.deleted_at IS NULL AND ?
+			GROUP BY p.topic_id
+			ON CONFLICT (user_id, topic_id) DO UPDATE
+			SET last_read_post_id = GREATEST(topic_reads.last_read_post_id, EXCLUDED.last_read_post_id),
+			    updated_at = NOW()`,
+			e.UserID, dbr.Or(dbr.Eq("t.id", e.TopicIDs), dbr.Eq("t.section_id", e.SectionIDs)),
+		).Exec()
+
+		return err
+	})
+}
+
+// SelectReads returns the number of unread posts and the first unread post of the given topics for the User.
+// Topics without unread posts are omitted.
+func (r *TopicRepository) SelectReads(sess entity.Session, userID int64, topicIDs []int64) ([]*entity.TopicRead, error) {
+	var reads []*dbmodel.TopicRead
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Select("p.topic_id", "COUNT(*) AS unread_count", "MIN(p.id) AS first_unread_post_id").
+			From(dbr.I("posts").As("p")).
+			LeftJoin(dbr.I("topic_reads").As("r"), dbr.Expr("r.topic_id = p.topic_id AND r.user_id = ?", userID)).
+			Where(dbr.And(dbr.Eq("p.topic_id", topicIDs), unreadPostCondition(userID))).
+			GroupBy("p.topic_id").
+			Load(&reads)
+
+		return err
+	})
+
+	return dto.TopicReadsFromDB(reads), err
+}
+
 func (r *TopicRepository) IDsToDelete(sess entity.Session, e *entity.TopicDelete) ([]int64, error) {
 	var ids []int64
 
@@ -134,3 +317,68 @@ func (r *TopicRepository) IDsToDelete(sess entity.Session, e *entity.TopicDelete
 
 	return ids, err
 }
+
+// SelectSimilar returns the Topics whose names or first Posts are similar to the name, the most similar first.
+// The candidates are found with the trigram indexes, so the pg_trgm similarity thresholds apply on top of
+// MinSimilarity.
+func (r *TopicRepository) SelectSimilar(sess entity.Session, f *entity.TopicSimilarFilters) ([]*entity.SimilarTopic, error) {
+	var topics []*dbmodel.SimilarTopic
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		similarity := dbr.Expr("similarity(t.name, ?)", f.Name)
+		candidates := dbr.Expr("t.name % ?", f.Name)
+
+		if !f.NameOnly {
+			// The first post is matched against the name word by word, as it is usually much longer
+			similarity = dbr.Expr(`GREATEST(?, (
+				SELECT word_similarity(?, p.text) FROM posts p
+				WHERE p.topic_id = t.id AND p.deleted_at IS NULL AND p.status = 'PUBLISHED'
+				ORDER BY p.id LIMIT 1
+			))`, similarity, f.Name)
+			candidates = dbr.Or(candidates, dbr.Expr(`t.id IN (
+				SELECT p.topic_id FROM posts p
+				WHERE ? <% p.text AND p.deleted_at IS NULL AND p.status = 'PUBLISHED'
+			)`, f.Name))
+		}
+
+		conditions := []dbr.Builder{
+			dbr.Eq("t.deleted_at", nil),
+			dbr.Eq("t.redirect_topic_id", nil),
+			candidates,
+		}
+
+		if f.SectionID != nil {
+			conditions = append(conditions, dbr.Eq("t.section_id", *f.SectionID))
+		}
+
+		if f.CreatedAfter != nil {
+			conditions = append(conditions, dbr.Gt("t.created_at", *f.CreatedAfter))
+		}
+
+		scored := dbr.Select("t.id AS topic_id", dbr.Expr("? AS similarity", similarity)).
+			From(dbr.I("topics").As("t")).
+			Where(dbr.And(conditions...))
+
+		_, err := tx.Select("*").
+			From(scored.As("scored")).
+			Where("similarity >= ?", f.MinSimilarity).
+			OrderDesc("similarity").
+			Limit(uint64(f.Limit)).
+			Load(&topics)
+
+		return err
+	})
+
+	return dto.SimilarTopicsFromDB(topics), err
+}
+
+// unreadPostCondition selects the published posts p newer than the last read post r of the User. The own posts of
+// the User are never unread, as writing a post doesn't move the last read one.
+func unreadPostCondition(userID int64) dbr.Builder {
+	return dbr.And(
+		dbr.Eq("p.deleted_at", nil),
+		dbr.Eq("p.status", string(entity.PostStatusPublished)),
+		dbr.Expr("p.id > COALESCE(r.last_read_post_id, 0)"),
+		dbr.Neq("p.user_id", userID),
+	)
+}
@@ -0,0 +1,152 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/infrastructure/repository"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gocraft/dbr"
+	"github.com/gocraft/dbr/dialect"
+)
+
+// recorder is a database driver which records the queries and finds no rows.
+type recorder struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (d *recorder) Open(string) (driver.Conn, error) {
+	return &recorderConn{d}, nil
+}
+
+func (d *recorder) record(query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.queries = append(d.queries, query)
+}
+
+type recorderConn struct {
+	driver *recorder
+}
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return &recorderStmt{driver: c.driver, query: query}, nil
+}
+
+func (c *recorderConn) Close() error {
+	return nil
+}
+
+func (c *recorderConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *recorderConn) Commit() error {
+	return nil
+}
+
+func (c *recorderConn) Rollback() error {
+	return nil
+}
+
+type recorderStmt struct {
+	driver *recorder
+	query  string
+}
+
+func (s *recorderStmt) Close() error {
+	return nil
+}
+
+func (s *recorderStmt) NumInput() int {
+	return -1
+}
+
+func (s *recorderStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.driver.record(s.query)
+
+	return driver.RowsAffected(0), nil
+}
+
+func (s *recorderStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.driver.record(s.query)
+
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string {
+	return nil
+}
+
+func (emptyRows) Close() error {
+	return nil
+}
+
+func (emptyRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+// queries records the queries of the running test, as a driver is registered only once.
+var queries = &recorder{}
+
+func init() {
+	sql.Register("recorder", queries)
+}
+
+// newRecorder opens a connection which records the queries instead of running them.
+func newRecorder(t *testing.T) (*repository.DBConn, *recorder) {
+	t.Helper()
+
+	queries.queries = nil
+
+	db, err := sql.Open("recorder", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return &repository.DBConn{Connection: &dbr.Connection{
+		DB:            db,
+		Dialect:       dialect.PostgreSQL,
+		EventReceiver: &dbr.NullEventReceiver{},
+	}}, queries
+}
+
+func TestTopicUnreadExcludesOwnPosts(t *testing.T) {
+	db, rec := newRecorder(t)
+	topics := repository.NewTopicRepository(db)
+	sess := entity.Session{Ctx: context.Background(), UserID: 42}
+
+	_, err := topics.SelectReads(sess, sess.UserID, []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = topics.SelectAll(sess, &entity.TopicFilters{UnreadOnly: true}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.queries) != 2 {
+		t.Fatalf("expected 2 queries, got %d", len(rec.queries))
+	}
+
+	// The viewer's own posts never count as unread
+	for _, query := range rec.queries {
+		if !strings.Contains(query, `"p"."user_id" != 42`) {
+			t.Errorf("expected the posts of the viewer to be left out, got %s", query)
+		}
+	}
+}
@@ -73,7 +73,7 @@ func (r *UserRepository) UpdateInfo(sess entity.Session, e *entity.UserInfo, use
 
 	return r.Wrap(sess, func(tx Gateway) error {
 		stmt := tx.Update("users_info").
-			Where("user_id", userInfo.UserID)
+			Where("user_id = ?", userInfo.UserID)
 
 		updateNotNil(stmt, userInfo)
 
@@ -83,6 +83,18 @@ func (r *UserRepository) UpdateInfo(sess entity.Session, e *entity.UserInfo, use
 	})
 }
 
+// UpdateReputation adds the delta, which may be negative, to the reputation of the User.
+func (r *UserRepository) UpdateReputation(sess entity.Session, id, delta int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.Update("users").
+			Set("reputation", dbr.Expr("reputation + ?", delta)).
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
 // Delete removes an existing User (softly).
 func (r *UserRepository) Delete(sess entity.Session, id int64) error {
 	return r.Wrap(sess, func(tx Gateway) error {
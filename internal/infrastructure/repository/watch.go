@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+)
+
+// WatchRepository represents a Watch Repository.
+type WatchRepository struct {
+	*DBConn
+}
+
+// NewWatchRepository instantiates a WatchRepository.
+func NewWatchRepository(db *DBConn) *WatchRepository {
+	return &WatchRepository{db}
+}
+
+// InsertTopicWatch subscribes a User to a Topic, doing nothing if the subscription already exists.
+func (r *WatchRepository) InsertTopicWatch(sess entity.Session, e *entity.TopicWatch) error {
+	watch := dto.TopicWatchToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.InsertBySql(
+			"INSERT INTO topic_watches (user_id, topic_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			watch.UserID, watch.TopicID,
+		).Exec()
+
+		return err
+	})
+}
+
+// DeleteTopicWatch unsubscribes a User from a Topic.
+func (r *WatchRepository) DeleteTopicWatch(sess entity.Session, e *entity.TopicWatch) error {
+	watch := dto.TopicWatchToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("topic_watches").
+			Where("user_id = ? AND topic_id = ?", watch.UserID, watch.TopicID).
+			Exec()
+
+		return err
+	})
+}
+
+// InsertSectionWatch subscribes a User to a Section, doing nothing if the subscription already exists.
+func (r *WatchRepository) InsertSectionWatch(sess entity.Session, e *entity.SectionWatch) error {
+	watch := dto.SectionWatchToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.InsertBySql(
+			"INSERT INTO section_watches (user_id, section_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			watch.UserID, watch.SectionID,
+		).Exec()
+
+		return err
+	})
+}
+
+// DeleteSectionWatch unsubscribes a User from a Section.
+func (r *WatchRepository) DeleteSectionWatch(sess entity.Session, e *entity.SectionWatch) error {
+	watch := dto.SectionWatchToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("section_watches").
+			Where("user_id = ? AND section_id = ?", watch.UserID, watch.SectionID).
+			Exec()
+
+		return err
+	})
+}
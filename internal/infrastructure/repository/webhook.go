@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"simplestforum/internal/domain/entity"
+	"simplestforum/internal/dto"
+	"simplestforum/internal/infrastructure/dbmodel"
+	"time"
+)
+
+// WebhookRepository represents a Webhook Repository.
+type WebhookRepository struct {
+	*DBConn
+}
+
+// NewWebhookRepository instantiates a WebhookRepository.
+func NewWebhookRepository(db *DBConn) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+// Insert creates a new Webhook entry in the database and returns its ID.
+func (r *WebhookRepository) Insert(sess entity.Session, e *entity.WebhookAdd) (int64, error) {
+	var webhookID int64
+
+	webhook := dto.WebhookAddToDB(e)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("webhooks").
+			Returning("id")
+
+		insertNotNil(stmt, webhook)
+
+		return stmt.Load(&webhookID)
+	})
+
+	return webhookID, err
+}
+
+// Update modifies an existing Webhook entry.
+func (r *WebhookRepository) Update(sess entity.Session, e *entity.WebhookEdit) error {
+	webhookUpdate, id := dto.WebhookEditToDB(e)
+
+	return r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Update("webhooks").
+			Where("id = ?", id).
+			Set("updated_at", time.Now())
+
+		updateNotNil(stmt, webhookUpdate)
+
+		_, err := stmt.Exec()
+
+		return err
+	})
+}
+
+// Delete removes an existing Webhook along with its delivery log.
+func (r *WebhookRepository) Delete(sess entity.Session, id int64) error {
+	return r.Wrap(sess, func(tx Gateway) error {
+		_, err := tx.DeleteFrom("webhooks").
+			Where("id = ?", id).
+			Exec()
+
+		return err
+	})
+}
+
+// SelectByID returns a Webhook by its ID.
+func (r *WebhookRepository) SelectByID(sess entity.Session, id int64) (*entity.Webhook, error) {
+	var webhook *dbmodel.Webhook
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		return tx.Select("*").
+			From("webhooks").
+			Where("id = ?", id).
+			LoadOne(&webhook)
+	})
+
+	return dto.WebhookFromDB(webhook), err
+}
+
+// SelectAll returns every Webhook, or only the active ones, the oldest first.
+func (r *WebhookRepository) SelectAll(sess entity.Session, onlyActive bool) ([]*entity.Webhook, error) {
+	var webhooks []*dbmodel.Webhook
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Select("*").
+			From("webhooks").
+			OrderAsc("id")
+
+		if onlyActive {
+			stmt.Where("active")
+		}
+
+		_, err := stmt.Load(&webhooks)
+
+		return err
+	})
+
+	return dto.WebhooksFromDB(webhooks), err
+}
+
+// InsertDelivery adds a new entry to the delivery log and returns it.
+func (r *WebhookRepository) InsertDelivery(sess entity.Session, e *entity.WebhookDeliveryAdd) (*entity.WebhookDelivery, error) {
+	delivery := dto.WebhookDeliveryAddToDB(e)
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.InsertInto("webhook_deliveries").
+			Returning("id", "created_at")
+
+		insertNotNil(stmt, delivery)
+
+		return stmt.Load(&delivery)
+	})
+
+	return dto.WebhookDeliveryFromDB(delivery), err
+}
+
+// SelectDeliveries returns the entries of the delivery log matching the filters, the latest first.
+func (r *WebhookRepository) SelectDeliveries(sess entity.Session, f *entity.WebhookDeliveryFilters,
+	p *entity.Pagination) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*dbmodel.WebhookDelivery
+
+	err := r.Wrap(sess, func(tx Gateway) error {
+		stmt := tx.Select("*").
+			From("webhook_deliveries").
+			OrderDesc("id")
+
+		if f != nil {
+			whereFilters(stmt, dto.WebhookDeliveryFiltersToDB(f))
+		}
+
+		if p != nil {
+			stmt.Paginate(uint64(p.Page), uint64(p.Limit))
+		}
+
+		_, err := stmt.Load(&deliveries)
+
+		return err
+	})
+
+	return dto.WebhookDeliveriesFromDB(deliveries), err
+}
@@ -0,0 +1,188 @@
+package search
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"simplestforum/internal/domain/entity"
+	"sort"
+	"strings"
+)
+
+// snippetWords is the number of words shown around the first match in a snippet.
+const snippetWords = 30
+
+// wordPattern matches the words of a text.
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// DocumentSource is an interface which declares methods to fetch the searchable content.
+type DocumentSource interface {
+	SelectDocuments(entity.Session, *entity.SearchQuery) ([]*entity.SearchDocument, error)
+}
+
+// InProcess is a search engine which matches and ranks the documents in the memory of the process.
+// Every word of the query must be found, the query syntax of the full-text engines is not supported.
+// It scans the whole content on every search, so it only suits the small forums and the storages without
+// a full-text index.
+type InProcess struct {
+	source DocumentSource
+}
+
+// NewInProcess instantiates an InProcess search engine.
+func NewInProcess(source DocumentSource) *InProcess {
+	return &InProcess{source: source}
+}
+
+// Search returns the Posts and Topics matching the query, the best ranked first.
+func (s *InProcess) Search(sess entity.Session, e *entity.SearchQuery, p *entity.Pagination) ([]*entity.SearchHit, error) {
+	terms := uniqueWords(e.Query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	documents, err := s.source.SelectDocuments(sess, e)
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		document *entity.SearchDocument
+		rank     float64
+	}
+
+	var matches []match
+
+	for _, document := range documents {
+		rank, ok := rankDocument(document.Text, terms)
+		if ok {
+			matches = append(matches, match{document: document, rank: rank})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank > matches[j].rank
+		}
+
+		return matches[i].document.CreatedAt.After(matches[j].document.CreatedAt)
+	})
+
+	// Cutting the page out of the matches
+	from := (p.Page - 1) * p.Limit
+	if from < 0 || from >= int64(len(matches)) {
+		return nil, nil
+	}
+
+	to := from + p.Limit
+	if to > int64(len(matches)) {
+		to = int64(len(matches))
+	}
+
+	hits := make([]*entity.SearchHit, 0, to-from)
+
+	for _, m := range matches[from:to] {
+		hits = append(hits, &entity.SearchHit{
+			Kind:      m.document.Kind,
+			ID:        m.document.ID,
+			TopicID:   m.document.TopicID,
+			SectionID: m.document.SectionID,
+			UserID:    m.document.UserID,
+			Rank:      m.rank,
+			Snippet:   snippet(m.document.Text, terms),
+			CreatedAt: m.document.CreatedAt,
+		})
+	}
+
+	return hits, nil
+}
+
+// rankDocument returns the rank of the text if it contains every term. Each repeated term adds less to the rank,
+// and the longer texts are ranked lower.
+func rankDocument(text string, terms map[string]bool) (float64, bool) {
+	words := wordPattern.FindAllString(strings.ToLower(text), -1)
+	counts := make(map[string]int, len(terms))
+
+	for _, word := range words {
+		if terms[word] {
+			counts[word]++
+		}
+	}
+
+	if len(counts) < len(terms) {
+		return 0, false
+	}
+
+	var rank float64
+
+	for _, count := range counts {
+		rank += 1 + math.Log(float64(count))
+	}
+
+	return rank / math.Log(float64(len(words))+math.E), true
+}
+
+// snippet returns a fragment of the text around the first matched term with every matched term highlighted.
+// The text is escaped as HTML, so the highlight tags are the only markup of the snippet.
+func snippet(text string, terms map[string]bool) string {
+	words := wordPattern.FindAllStringIndex(text, -1)
+
+	first := 0
+
+	for i, word := range words {
+		if terms[strings.ToLower(text[word[0]:word[1]])] {
+			first = i
+
+			break
+		}
+	}
+
+	// Starting a few words before the match
+	from := first - snippetWords/4
+	if from < 0 {
+		from = 0
+	}
+
+	to := from + snippetWords
+	if to > len(words) {
+		to = len(words)
+	}
+
+	var b strings.Builder
+
+	if from > 0 {
+		b.WriteString("... ")
+	}
+
+	start := words[from][0]
+
+	for _, word := range words[from:to] {
+		b.WriteString(html.EscapeString(text[start:word[0]]))
+
+		if terms[strings.ToLower(text[word[0]:word[1]])] {
+			b.WriteString(entity.SearchHighlightStart + html.EscapeString(text[word[0]:word[1]]) + entity.SearchHighlightStop)
+		} else {
+			b.WriteString(html.EscapeString(text[word[0]:word[1]]))
+		}
+
+		start = word[1]
+	}
+
+	if to < len(words) {
+		b.WriteString(" ...")
+	} else {
+		b.WriteString(html.EscapeString(text[start:]))
+	}
+
+	return b.String()
+}
+
+// uniqueWords returns the set of the lowercase words of the text.
+func uniqueWords(text string) map[string]bool {
+	words := make(map[string]bool)
+
+	for _, word := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		words[word] = true
+	}
+
+	return words
+}
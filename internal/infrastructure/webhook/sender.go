@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"simplestforum/internal/domain/entity"
+	"strings"
+)
+
+// The headers of the requests sent to the Webhooks.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-ID"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// userAgent identifies the forum to the receivers.
+const userAgent = "simplestforum-webhooks"
+
+// maxResponseSize is how much of the response body is read, so the connection can be reused.
+const maxResponseSize = 64 << 10
+
+// HTTPSender sends the payloads to the Webhooks with an HTTP POST request.
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender instantiates an HTTPSender sending the requests with the client, which sets the timeout.
+// Any client can be used, e.g. the one of a local test server.
+func NewHTTPSender(client *http.Client) *HTTPSender {
+	return &HTTPSender{
+		client: client,
+	}
+}
+
+// Send posts the payload to the URL of the Webhook and returns the status code of the response.
+func (s *HTTPSender) Send(ctx context.Context, r *entity.WebhookRequest) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, strings.NewReader(r.Payload.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, string(r.Payload.EventType))
+	req.Header.Set(HeaderEventID, r.Payload.ID)
+	req.Header.Set(HeaderSignature, r.Signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	// The body is of no interest, it is drained to keep the connection alive
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	return int64(resp.StatusCode), nil
+}
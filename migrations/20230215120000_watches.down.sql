@@ -0,0 +1,2 @@
+DROP TABLE section_watches;
+DROP TABLE topic_watches;
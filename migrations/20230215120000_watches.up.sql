@@ -0,0 +1,23 @@
+-- topic_watches --
+CREATE TABLE topic_watches
+(
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    topic_id   BIGINT      NOT NULL REFERENCES topics (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, topic_id)
+);
+
+CREATE INDEX topic_watches_topic_id_idx ON topic_watches (topic_id);
+
+-- section_watches --
+CREATE TABLE section_watches
+(
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    section_id BIGINT      NOT NULL REFERENCES sections (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, section_id)
+);
+
+CREATE INDEX section_watches_section_id_idx ON section_watches (section_id);
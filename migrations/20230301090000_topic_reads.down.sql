@@ -0,0 +1,2 @@
+DROP INDEX posts_topic_id_id_idx;
+DROP TABLE topic_reads;
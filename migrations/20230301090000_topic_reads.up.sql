@@ -0,0 +1,12 @@
+-- topic_reads --
+CREATE TABLE topic_reads
+(
+    user_id           BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    topic_id          BIGINT      NOT NULL REFERENCES topics (id) ON DELETE CASCADE,
+    last_read_post_id BIGINT      NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, topic_id)
+);
+
+CREATE INDEX posts_topic_id_id_idx ON posts (topic_id, id) WHERE deleted_at IS NULL;
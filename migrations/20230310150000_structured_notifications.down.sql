@@ -0,0 +1,15 @@
+DROP INDEX notifications_user_id_group_key_idx;
+DROP INDEX notifications_user_id_created_at_idx;
+
+ALTER TABLE notifications ALTER COLUMN text DROP NOT NULL;
+
+ALTER TABLE notifications
+    DROP COLUMN read_at,
+    DROP COLUMN count,
+    DROP COLUMN group_text,
+    DROP COLUMN group_key,
+    DROP COLUMN section_id,
+    DROP COLUMN topic_id,
+    DROP COLUMN post_id,
+    DROP COLUMN actor_id,
+    DROP COLUMN kind;
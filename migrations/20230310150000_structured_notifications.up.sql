@@ -0,0 +1,17 @@
+-- The grouped notifications keep the subject in group_text, e.g. the topic name, the description comes from the kind
+ALTER TABLE notifications
+    ADD COLUMN kind       TEXT   NOT NULL DEFAULT 'GENERAL',
+    ADD COLUMN actor_id   BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN post_id    BIGINT,
+    ADD COLUMN topic_id   BIGINT,
+    ADD COLUMN section_id BIGINT,
+    ADD COLUMN group_key  TEXT,
+    ADD COLUMN group_text TEXT,
+    ADD COLUMN count      BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN read_at    TIMESTAMPTZ;
+
+UPDATE notifications SET text = '' WHERE text IS NULL;
+ALTER TABLE notifications ALTER COLUMN text SET NOT NULL;
+
+CREATE INDEX notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC);
+CREATE UNIQUE INDEX notifications_user_id_group_key_idx ON notifications (user_id, group_key) WHERE read_at IS NULL;
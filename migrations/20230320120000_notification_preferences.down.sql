@@ -0,0 +1,2 @@
+DROP TABLE notification_emails;
+DROP TABLE notification_preferences;
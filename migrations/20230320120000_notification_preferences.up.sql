@@ -0,0 +1,23 @@
+-- notification_preferences --
+CREATE TABLE notification_preferences
+(
+    user_id BIGINT  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind    TEXT    NOT NULL,
+    in_app  BOOLEAN NOT NULL DEFAULT TRUE,
+    email   TEXT    NOT NULL DEFAULT 'NONE',
+    PRIMARY KEY (user_id, kind)
+);
+
+-- notification_emails --
+CREATE TABLE notification_emails
+(
+    id         BIGSERIAL   PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind       TEXT        NOT NULL,
+    mode       TEXT        NOT NULL,
+    text       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at    TIMESTAMPTZ
+);
+
+CREATE INDEX notification_emails_pending_idx ON notification_emails (mode, user_id, created_at) WHERE sent_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE topics DROP COLUMN redirect_topic_id;
@@ -0,0 +1,10 @@
+ALTER TABLE topics ADD COLUMN redirect_topic_id BIGINT REFERENCES topics (id) ON DELETE SET NULL;
+
+-- The counters were never maintained before, bringing them in line with the data
+UPDATE topics SET count_posts = (
+    SELECT COUNT(*) FROM posts p WHERE p.topic_id = topics.id AND p.deleted_at IS NULL
+);
+
+UPDATE sections SET count_topics = (
+    SELECT COUNT(*) FROM topics t WHERE t.section_id = sections.id AND t.deleted_at IS NULL
+);
@@ -0,0 +1,23 @@
+-- reports --
+CREATE TABLE reports
+(
+    id              BIGSERIAL   PRIMARY KEY,
+    reporter_id     BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    target_type     TEXT        NOT NULL,
+    target_id       BIGINT      NOT NULL,
+    target_user_id  BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reason          TEXT        NOT NULL,
+    text            TEXT,
+    status          TEXT        NOT NULL DEFAULT 'OPEN',
+    assignee_id     BIGINT      REFERENCES users (id) ON DELETE SET NULL,
+    resolution_note TEXT,
+    action          TEXT,
+    resolved_by_id  BIGINT      REFERENCES users (id) ON DELETE SET NULL,
+    resolved_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A reporter has at most one open report per target
+CREATE UNIQUE INDEX reports_reporter_target_open_idx ON reports (reporter_id, target_type, target_id) WHERE status = 'OPEN';
+CREATE INDEX reports_status_created_at_idx ON reports (status, created_at);
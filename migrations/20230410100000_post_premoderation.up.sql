@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN status TEXT NOT NULL DEFAULT 'PUBLISHED';
+
+CREATE INDEX posts_pending_idx ON posts (created_at) WHERE status = 'PENDING';
@@ -0,0 +1,3 @@
+DROP TABLE spam_totals;
+DROP TABLE spam_tokens;
+DROP TABLE content_filter_decisions;
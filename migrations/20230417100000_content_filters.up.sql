@@ -0,0 +1,31 @@
+-- content_filter_decisions --
+CREATE TABLE content_filter_decisions
+(
+    id           BIGSERIAL        PRIMARY KEY,
+    content_kind TEXT             NOT NULL,
+    user_id      BIGINT           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    filter       TEXT             NOT NULL,
+    verdict      TEXT             NOT NULL,
+    score        DOUBLE PRECISION,
+    reason       TEXT             NOT NULL,
+    created_at   TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX content_filter_decisions_filter_created_at_idx ON content_filter_decisions (filter, created_at);
+
+-- spam_tokens --
+CREATE TABLE spam_tokens
+(
+    token      TEXT   PRIMARY KEY,
+    spam_count BIGINT NOT NULL DEFAULT 0,
+    ham_count  BIGINT NOT NULL DEFAULT 0
+);
+
+-- spam_totals --
+CREATE TABLE spam_totals
+(
+    spam_count BIGINT NOT NULL DEFAULT 0,
+    ham_count  BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO spam_totals DEFAULT VALUES;
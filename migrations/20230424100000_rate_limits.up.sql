@@ -0,0 +1,7 @@
+-- rate_limit_buckets --
+CREATE TABLE rate_limit_buckets
+(
+    key        TEXT             PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ      NOT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE topics DROP COLUMN search_vector;
+ALTER TABLE posts DROP COLUMN search_vector;
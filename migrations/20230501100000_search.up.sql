@@ -0,0 +1,9 @@
+-- posts --
+ALTER TABLE posts ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', text)) STORED;
+
+CREATE INDEX posts_search_vector_idx ON posts USING GIN (search_vector);
+
+-- topics --
+ALTER TABLE topics ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', name)) STORED;
+
+CREATE INDEX topics_search_vector_idx ON topics USING GIN (search_vector);
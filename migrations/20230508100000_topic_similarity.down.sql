@@ -0,0 +1,2 @@
+DROP INDEX posts_text_trgm_idx;
+DROP INDEX topics_name_trgm_idx;
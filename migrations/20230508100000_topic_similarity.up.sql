@@ -0,0 +1,4 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX topics_name_trgm_idx ON topics USING GIN (name gin_trgm_ops);
+CREATE INDEX posts_text_trgm_idx ON posts USING GIN (text gin_trgm_ops);
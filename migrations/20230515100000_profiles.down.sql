@@ -0,0 +1,10 @@
+DROP TABLE user_profile_values;
+DROP TABLE profile_fields;
+
+ALTER TABLE users_info
+    DROP COLUMN bio,
+    DROP COLUMN signature,
+    DROP COLUMN phone_visibility,
+    DROP COLUMN email_visibility,
+    DROP COLUMN first_name_visibility,
+    DROP COLUMN last_name_visibility;
@@ -0,0 +1,43 @@
+-- users_info --
+ALTER TABLE users_info
+    ADD COLUMN bio                   TEXT,
+    ADD COLUMN signature             TEXT,
+    ADD COLUMN phone_visibility      TEXT NOT NULL DEFAULT 'PRIVATE',
+    ADD COLUMN email_visibility      TEXT NOT NULL DEFAULT 'PRIVATE',
+    ADD COLUMN first_name_visibility TEXT NOT NULL DEFAULT 'PRIVATE',
+    ADD COLUMN last_name_visibility  TEXT NOT NULL DEFAULT 'PRIVATE';
+
+-- The info shown to everyone stays public field by field
+UPDATE users_info
+SET phone_visibility      = 'PUBLIC',
+    email_visibility      = 'PUBLIC',
+    first_name_visibility = 'PUBLIC',
+    last_name_visibility  = 'PUBLIC'
+FROM users
+WHERE users.id = users_info.user_id AND users.show_info;
+
+-- profile_fields --
+CREATE TABLE profile_fields
+(
+    id         BIGSERIAL   PRIMARY KEY,
+    name       TEXT        NOT NULL UNIQUE,
+    title      TEXT        NOT NULL,
+    type       TEXT        NOT NULL,
+    required   BOOLEAN     NOT NULL DEFAULT FALSE,
+    visibility TEXT        NOT NULL DEFAULT 'PUBLIC',
+    pattern    TEXT,
+    max_length BIGINT,
+    options    TEXT[],
+    position   BIGINT      NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- user_profile_values --
+CREATE TABLE user_profile_values
+(
+    user_id  BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    field_id BIGINT NOT NULL REFERENCES profile_fields (id) ON DELETE CASCADE,
+    value    TEXT   NOT NULL,
+    PRIMARY KEY (user_id, field_id)
+);
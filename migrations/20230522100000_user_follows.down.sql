@@ -0,0 +1,2 @@
+DROP TABLE user_ignores;
+DROP TABLE user_follows;
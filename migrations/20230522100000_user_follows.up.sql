@@ -0,0 +1,23 @@
+-- user_follows --
+CREATE TABLE user_follows
+(
+    user_id          BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    followed_user_id BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, followed_user_id)
+);
+
+CREATE INDEX user_follows_followed_user_id_idx ON user_follows (followed_user_id);
+
+-- user_ignores --
+CREATE TABLE user_ignores
+(
+    user_id         BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    ignored_user_id BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, ignored_user_id)
+);
+
+CREATE INDEX user_ignores_ignored_user_id_idx ON user_ignores (ignored_user_id);
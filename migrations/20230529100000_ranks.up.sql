@@ -0,0 +1,27 @@
+-- ranks --
+CREATE TABLE ranks
+(
+    id         BIGSERIAL   PRIMARY KEY,
+    min_posts  BIGINT      NOT NULL UNIQUE CHECK (min_posts >= 0),
+    title      TEXT        NOT NULL,
+    perks      TEXT[]      NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The ladder starts the way the ranks were given before: a new rank every 50 posts
+INSERT INTO ranks (min_posts, title, perks)
+VALUES (0, 'Newcomer', '{SIGNATURE}'),
+       (50, 'Novice', '{SIGNATURE,AVATAR}'),
+       (100, 'Apprentice', '{SIGNATURE,AVATAR}'),
+       (150, 'Regular', '{SIGNATURE,AVATAR}'),
+       (200, 'Member', '{SIGNATURE,AVATAR}'),
+       (250, 'Senior Member', '{SIGNATURE,AVATAR}'),
+       (300, 'Veteran', '{SIGNATURE,AVATAR}'),
+       (350, 'Expert', '{SIGNATURE,AVATAR}'),
+       (400, 'Elite', '{SIGNATURE,AVATAR}'),
+       (450, 'Legend', '{SIGNATURE,AVATAR}');
+
+-- The ranks of the existing users follow the ladder from now on
+UPDATE users
+SET rank = GREATEST(1, (SELECT COUNT(*) FROM ranks r WHERE r.min_posts <= users.count_posts));
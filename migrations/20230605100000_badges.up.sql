@@ -0,0 +1,31 @@
+-- badges --
+CREATE TABLE badges
+(
+    id          BIGSERIAL   PRIMARY KEY,
+    name        TEXT        NOT NULL UNIQUE,
+    title       TEXT        NOT NULL,
+    description TEXT        NOT NULL DEFAULT '',
+    rule        TEXT        NOT NULL,
+    threshold   BIGINT      NOT NULL CHECK (threshold > 0),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO badges (name, title, description, rule, threshold)
+VALUES ('first_post', 'First Post', 'Wrote the first post', 'POSTS', 1),
+       ('posts_100', 'Centurion', 'Wrote 100 posts', 'POSTS', 100),
+       ('first_topic', 'Conversation Starter', 'Started the first topic', 'TOPICS', 1),
+       ('followers_10', 'Influencer', 'Followed by 10 users', 'FOLLOWERS', 10),
+       ('member_1_year', 'One Year Member', 'Has been a member for a year', 'MEMBERSHIP_DAYS', 365);
+
+-- user_badges --
+CREATE TABLE user_badges
+(
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    badge_id   BIGINT      NOT NULL REFERENCES badges (id) ON DELETE CASCADE,
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, badge_id)
+);
+
+CREATE INDEX user_badges_badge_id_idx ON user_badges (badge_id);
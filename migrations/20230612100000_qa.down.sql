@@ -0,0 +1,8 @@
+ALTER TABLE users
+    DROP COLUMN reputation;
+
+ALTER TABLE topics
+    DROP COLUMN accepted_post_id;
+
+ALTER TABLE sections
+    DROP COLUMN mode;
@@ -0,0 +1,13 @@
+-- sections --
+ALTER TABLE sections
+    ADD COLUMN mode TEXT NOT NULL DEFAULT 'DISCUSSION';
+
+-- topics --
+ALTER TABLE topics
+    ADD COLUMN accepted_post_id BIGINT REFERENCES posts (id) ON DELETE SET NULL;
+
+CREATE INDEX topics_accepted_post_id_idx ON topics (accepted_post_id);
+
+-- users --
+ALTER TABLE users
+    ADD COLUMN reputation BIGINT NOT NULL DEFAULT 0;
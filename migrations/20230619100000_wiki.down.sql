@@ -0,0 +1,4 @@
+DROP TABLE post_edits;
+
+ALTER TABLE posts
+    DROP COLUMN wiki;
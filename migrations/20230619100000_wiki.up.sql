@@ -0,0 +1,14 @@
+-- posts --
+ALTER TABLE posts
+    ADD COLUMN wiki BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- post_edits --
+CREATE TABLE post_edits
+(
+    id        BIGSERIAL   PRIMARY KEY,
+    post_id   BIGINT      NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    user_id   BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    edited_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX post_edits_post_id_idx ON post_edits (post_id);
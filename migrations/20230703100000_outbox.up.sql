@@ -0,0 +1,20 @@
+-- outbox --
+CREATE TABLE outbox
+(
+    id              BIGSERIAL   PRIMARY KEY,
+    subscriber      TEXT        NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    session_id      TEXT        NOT NULL,
+    user_id         BIGINT      NOT NULL DEFAULT 0,
+    user_level      TEXT        NOT NULL DEFAULT '',
+    status          TEXT        NOT NULL DEFAULT 'PENDING',
+    attempts        BIGINT      NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The dispatcher only looks for the pending messages which are due
+CREATE INDEX outbox_due_idx ON outbox (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX outbox_status_idx ON outbox (status);
@@ -0,0 +1,30 @@
+-- webhooks --
+CREATE TABLE webhooks
+(
+    id          BIGSERIAL   PRIMARY KEY,
+    url         TEXT        NOT NULL,
+    secret      TEXT        NOT NULL,
+    event_types TEXT[]      NOT NULL DEFAULT '{}',
+    section_ids BIGINT[]    NOT NULL DEFAULT '{}',
+    active      BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- webhook_deliveries --
+CREATE TABLE webhook_deliveries
+(
+    id          BIGSERIAL   PRIMARY KEY,
+    webhook_id  BIGINT      NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id    TEXT        NOT NULL,
+    event_type  TEXT        NOT NULL,
+    -- The body exactly as it was sent, so that it's redelivered with the same signature
+    payload     TEXT        NOT NULL,
+    status_code BIGINT,
+    error       TEXT,
+    duration_ms BIGINT      NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+CREATE INDEX webhook_deliveries_event_id_idx ON webhook_deliveries (event_id);
@@ -0,0 +1,13 @@
+-- broadcast messages --
+CREATE TABLE broadcast_messages
+(
+    seq        BIGSERIAL   PRIMARY KEY,
+    subscriber TEXT        NOT NULL,
+    event_type TEXT        NOT NULL,
+    payload    TEXT        NOT NULL,
+    session_id TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The messages are only kept for the instances catching up, the old ones are pruned
+CREATE INDEX broadcast_messages_created_at_idx ON broadcast_messages (created_at);
@@ -0,0 +1,3 @@
+DROP INDEX notifications_user_id_seq_idx;
+ALTER TABLE notifications DROP COLUMN seq;
+DROP SEQUENCE notifications_seq;
@@ -0,0 +1,13 @@
+-- Every change of a notification takes the next number, so the merged ones are streamed again on resume.
+-- The existing notifications keep their IDs as the numbers, so the IDs the clients hold stay valid.
+CREATE SEQUENCE notifications_seq;
+
+ALTER TABLE notifications ADD COLUMN seq BIGINT;
+UPDATE notifications SET seq = id;
+SELECT SETVAL('notifications_seq', COALESCE((SELECT MAX(id) FROM notifications), 0) + 1, FALSE);
+
+ALTER TABLE notifications
+    ALTER COLUMN seq SET DEFAULT NEXTVAL('notifications_seq'),
+    ALTER COLUMN seq SET NOT NULL;
+
+CREATE INDEX notifications_user_id_seq_idx ON notifications (user_id, seq);